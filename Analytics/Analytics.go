@@ -0,0 +1,234 @@
+// Analytics/Analytics.go
+package Analytics
+
+import (
+	"complaint-portal/Common"
+	"complaint-portal/Storage"
+	"context"
+	"errors"
+	"sort"
+	"time"
+)
+
+// Bucketing is the length of the periods complaints are counted in.
+type Bucketing int
+
+const (
+	// Daily buckets start at midnight UTC.
+	Daily Bucketing = iota
+	// Weekly buckets start on Monday at midnight UTC.
+	Weekly
+)
+
+// MaxBuckets caps the number of buckets a window may be split into.
+const MaxBuckets = 400
+
+// pageSize is the number of complaints read from the store at a time. It is a
+// variable so that tests can make it small.
+var pageSize = 500
+
+var (
+	// ErrInvalidWindow is returned when the window does not end after it starts.
+	ErrInvalidWindow = errors.New("window must end after it starts")
+	// ErrTooManyBuckets is returned when the window spans more than MaxBuckets buckets.
+	ErrTooManyBuckets = errors.New("window spans too many buckets")
+)
+
+// Options selects the complaints counted and how.
+type Options struct {
+	// Since is the earliest creation time counted, and Until the first time past
+	// the window.
+	Since, Until time.Time
+	Bucketing    Bucketing
+	// TopSubmitters is how many of the users who submitted the most complaints are listed.
+	TopSubmitters int
+}
+
+// Stats are aggregates over the complaints created in a window.
+type Stats struct {
+	Total    int
+	Open     int
+	Resolved int
+	// BySeverity counts complaints per severity, lowest severity first.
+	BySeverity []SeverityCount
+	// MeanTimeToResolution is the mean time from creation to resolution of the
+	// resolved complaints, or 0 if none is.
+	MeanTimeToResolution time.Duration
+	TopSubmitters        []Submitter
+	// Buckets split the window by creation time, oldest first. The first bucket
+	// may start before the window, and every bucket is listed, even empty ones.
+	Buckets []Bucket
+}
+
+// SeverityCount is the number of complaints of a severity.
+type SeverityCount struct {
+	Severity int
+	Count    int
+}
+
+// Submitter is a user and the number of complaints they submitted. Name and
+// Email are empty if the user no longer exists.
+type Submitter struct {
+	UserID string
+	Name   string
+	Email  string
+	Count  int
+}
+
+// Bucket counts the complaints created in a period. Resolved and
+// MeanTimeToResolution are about those complaints, whenever they were resolved.
+type Bucket struct {
+	Start                time.Time
+	Submitted            int
+	Resolved             int
+	MeanTimeToResolution time.Duration
+}
+
+// Compute reads the complaints created in the window from the store and
+// aggregates them. It only uses the Store interface, so it runs on every backend.
+func Compute(ctx context.Context, store Storage.Store, opts Options) (Stats, error) {
+	if !opts.Until.After(opts.Since) {
+		return Stats{}, ErrInvalidWindow
+	}
+	starts := bucketStarts(opts.Since, opts.Until, opts.Bucketing)
+	if len(starts) > MaxBuckets {
+		return Stats{}, ErrTooManyBuckets
+	}
+
+	var stats Stats
+	buckets := make([]resolution, len(starts))
+	var total resolution
+	severities := map[int]int{}
+	submitters := map[string]int{}
+
+	err := forEachComplaint(ctx, store, opts.Since, opts.Until, func(c Common.Complaint) {
+		stats.Total++
+		severities[c.Severity]++
+		submitters[c.UserID]++
+		i := sort.Search(len(starts), func(i int) bool { return starts[i].After(c.CreatedAt) }) - 1
+		buckets[i].submitted++
+		if !c.Resolved {
+			stats.Open++
+			return
+		}
+		stats.Resolved++
+		buckets[i].add(c)
+		total.add(c)
+	})
+	if err != nil {
+		return Stats{}, err
+	}
+
+	stats.MeanTimeToResolution = total.mean()
+	for severity, count := range severities {
+		stats.BySeverity = append(stats.BySeverity, SeverityCount{Severity: severity, Count: count})
+	}
+	sort.Slice(stats.BySeverity, func(i, j int) bool { return stats.BySeverity[i].Severity < stats.BySeverity[j].Severity })
+	for i, start := range starts {
+		stats.Buckets = append(stats.Buckets, Bucket{
+			Start:                start,
+			Submitted:            buckets[i].submitted,
+			Resolved:             buckets[i].resolved,
+			MeanTimeToResolution: buckets[i].mean(),
+		})
+	}
+	stats.TopSubmitters, err = topSubmitters(ctx, store, submitters, opts.TopSubmitters)
+	return stats, err
+}
+
+// forEachComplaint calls fn for every complaint created in [since, until),
+// oldest first, reading them a page at a time.
+func forEachComplaint(ctx context.Context, store Storage.Store, since, until time.Time, fn func(Common.Complaint)) error {
+	query := Storage.ComplaintQuery{CreatedSince: since, CreatedUntil: until, Sort: Storage.OldestFirst, Limit: pageSize}
+	for {
+		page, err := store.QueryComplaints(ctx, query)
+		if err != nil {
+			return err
+		}
+		for _, c := range page {
+			fn(c)
+		}
+		if len(page) < pageSize {
+			return nil
+		}
+		after := Storage.CursorOf(page[len(page)-1])
+		query.After = &after
+	}
+}
+
+// resolution accumulates the times to resolution of complaints.
+type resolution struct {
+	submitted int
+	resolved  int
+	// timed counts the resolved complaints whose creation and resolution times
+	// are both known, and sum their times to resolution.
+	timed int
+	sum   time.Duration
+}
+
+func (r *resolution) add(c Common.Complaint) {
+	r.resolved++
+	if !c.CreatedAt.IsZero() && !c.ResolvedAt.IsZero() {
+		r.timed++
+		r.sum += c.ResolvedAt.Sub(c.CreatedAt)
+	}
+}
+
+func (r resolution) mean() time.Duration {
+	if r.timed == 0 {
+		return 0
+	}
+	return r.sum / time.Duration(r.timed)
+}
+
+// bucketStarts returns the start of every bucket overlapping [since, until).
+func bucketStarts(since, until time.Time, b Bucketing) []time.Time {
+	start := since.UTC().Truncate(24 * time.Hour)
+	step := 24 * time.Hour
+	if b == Weekly {
+		// time.Monday is 1, so this goes back to the last Monday.
+		start = start.AddDate(0, 0, -((int(start.Weekday()) + 6) % 7))
+		step = 7 * 24 * time.Hour
+	}
+	var starts []time.Time
+	for t := start; t.Before(until) && len(starts) <= MaxBuckets; t = t.Add(step) {
+		starts = append(starts, t)
+	}
+	return starts
+}
+
+// topSubmitters returns the n users with the most complaints, most first, ties
+// broken by user ID, with their names and emails.
+func topSubmitters(ctx context.Context, store Storage.Store, counts map[string]int, n int) ([]Submitter, error) {
+	if n <= 0 || len(counts) == 0 {
+		return nil, nil
+	}
+	var result []Submitter
+	for id, count := range counts {
+		result = append(result, Submitter{UserID: id, Count: count})
+	}
+	sort.Slice(result, func(i, j int) bool {
+		if result[i].Count != result[j].Count {
+			return result[i].Count > result[j].Count
+		}
+		return result[i].UserID < result[j].UserID
+	})
+	if len(result) > n {
+		result = result[:n]
+	}
+
+	ids := make([]string, len(result))
+	for i, s := range result {
+		ids[i] = s.UserID
+	}
+	users, err := store.GetUsers(ctx, ids)
+	if err != nil {
+		return nil, err
+	}
+	for i := range result {
+		if u, ok := users[result[i].UserID]; ok {
+			result[i].Name, result[i].Email = u.Name, u.Email
+		}
+	}
+	return result, nil
+}
@@ -0,0 +1,119 @@
+// Analytics/Analytics_test.go
+package Analytics
+
+import (
+	"complaint-portal/Common"
+	"complaint-portal/Storage"
+	"context"
+	"errors"
+	"testing"
+	"time"
+)
+
+// TestComputeMemory runs the analytics tests against the in-memory store.
+func TestComputeMemory(t *testing.T) {
+	store := Storage.NewMemoryStore()
+	store.Reset()
+	defer store.Reset()
+	runComputeTests(t, store)
+}
+
+// TestComputeSQL runs the analytics tests against the SQLite store.
+func TestComputeSQL(t *testing.T) {
+	store, err := Storage.OpenSQLite(":memory:")
+	if err != nil {
+		t.Fatalf("Failed to open SQLite store: %v", err)
+	}
+	defer store.Close()
+	runComputeTests(t, store)
+}
+
+// day returns the given time of March 2026, a month starting on a Sunday.
+func day(d, hour int) time.Time {
+	return time.Date(2026, time.March, d, hour, 0, 0, 0, time.UTC)
+}
+
+func runComputeTests(t *testing.T, store Storage.Store) {
+	ctx := context.Background()
+	for _, u := range []Common.User{
+		{ID: "u1", SecretHash: "h1", Name: "Ada", Email: "ada@example.com"},
+		{ID: "u2", SecretHash: "h2", Name: "Bob", Email: "bob@example.com"},
+	} {
+		if err := store.CreateUser(ctx, u); err != nil {
+			t.Fatalf("Failed to create user: %v", err)
+		}
+	}
+	complaints := []Common.Complaint{
+		{ID: "c1", UserID: "u1", Severity: 1, CreatedAt: day(2, 10), Resolved: true, ResolvedAt: day(2, 12)},
+		{ID: "c2", UserID: "u1", Severity: 3, CreatedAt: day(3, 9)},
+		{ID: "c3", UserID: "u2", Severity: 3, CreatedAt: day(10, 8), Resolved: true, ResolvedAt: day(11, 8)},
+		// Outside the window.
+		{ID: "c4", UserID: "u2", Severity: 5, CreatedAt: day(1, 23)},
+		{ID: "c5", UserID: "u2", Severity: 5, CreatedAt: day(16, 0)},
+	}
+	for _, c := range complaints {
+		if err := store.CreateComplaint(ctx, c); err != nil {
+			t.Fatalf("Failed to create complaint: %v", err)
+		}
+	}
+	opts := Options{Since: day(2, 0), Until: day(16, 0), Bucketing: Weekly, TopSubmitters: 5}
+
+	// Test case 1: Totals, severities and the mean time to resolution
+	stats, err := Compute(ctx, store, opts)
+	if err != nil {
+		t.Fatalf("Expected no error, but got %v", err)
+	}
+	if stats.Total != 3 || stats.Open != 1 || stats.Resolved != 2 {
+		t.Errorf("Expected 3 complaints, 1 open and 2 resolved, but got %+v", stats)
+	}
+	if len(stats.BySeverity) != 2 || stats.BySeverity[0] != (SeverityCount{1, 1}) || stats.BySeverity[1] != (SeverityCount{3, 2}) {
+		t.Errorf("Expected severities [1:1 3:2], but got %v", stats.BySeverity)
+	}
+	if stats.MeanTimeToResolution != 13*time.Hour {
+		t.Errorf("Expected a mean time to resolution of 13h, but got %v", stats.MeanTimeToResolution)
+	}
+
+	// Test case 2: Top submitters with their names
+	if len(stats.TopSubmitters) != 2 || stats.TopSubmitters[0] != (Submitter{"u1", "Ada", "ada@example.com", 2}) || stats.TopSubmitters[1].UserID != "u2" {
+		t.Errorf("Expected Ada then Bob, but got %+v", stats.TopSubmitters)
+	}
+	opts.TopSubmitters = 1
+	if stats, _ := Compute(ctx, store, opts); len(stats.TopSubmitters) != 1 {
+		t.Errorf("Expected 1 top submitter, but got %+v", stats.TopSubmitters)
+	}
+
+	// Test case 3: Weekly buckets start on Mondays
+	want := []Bucket{
+		{Start: day(2, 0), Submitted: 2, Resolved: 1, MeanTimeToResolution: 2 * time.Hour},
+		{Start: day(9, 0), Submitted: 1, Resolved: 1, MeanTimeToResolution: 24 * time.Hour},
+	}
+	if len(stats.Buckets) != len(want) {
+		t.Fatalf("Expected %d buckets, but got %+v", len(want), stats.Buckets)
+	}
+	for i := range want {
+		if !stats.Buckets[i].Start.Equal(want[i].Start) || stats.Buckets[i].Submitted != want[i].Submitted ||
+			stats.Buckets[i].Resolved != want[i].Resolved || stats.Buckets[i].MeanTimeToResolution != want[i].MeanTimeToResolution {
+			t.Errorf("Expected bucket %d to be %+v, but got %+v", i, want[i], stats.Buckets[i])
+		}
+	}
+
+	// Test case 4: Daily buckets list every day, even empty ones, and read every page
+	defer func(n int) { pageSize = n }(pageSize)
+	pageSize = 1
+	opts.Bucketing = Daily
+	stats, err = Compute(ctx, store, opts)
+	if err != nil || stats.Total != 3 || len(stats.Buckets) != 14 {
+		t.Fatalf("Expected 3 complaints in 14 days, but got %d in %d (err: %v)", stats.Total, len(stats.Buckets), err)
+	}
+	if stats.Buckets[1].Submitted != 1 || stats.Buckets[2].Submitted != 0 || stats.Buckets[8].Resolved != 1 {
+		t.Errorf("Expected complaints on March 3 and 10, but got %+v", stats.Buckets)
+	}
+
+	// Test case 5: Invalid windows are refused
+	if _, err := Compute(ctx, store, Options{Since: day(5, 0), Until: day(5, 0)}); !errors.Is(err, ErrInvalidWindow) {
+		t.Errorf("Expected ErrInvalidWindow, but got %v", err)
+	}
+	if _, err := Compute(ctx, store, Options{Since: day(1, 0), Until: day(1, 0).AddDate(2, 0, 0)}); !errors.Is(err, ErrTooManyBuckets) {
+		t.Errorf("Expected ErrTooManyBuckets, but got %v", err)
+	}
+}
@@ -0,0 +1,275 @@
+// Audit/Audit.go
+package Audit
+
+import (
+	"complaint-portal/Auth"
+	"complaint-portal/Common"
+	"complaint-portal/Storage"
+	"context"
+	"errors"
+	"log/slog"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"google.golang.org/grpc"
+)
+
+// servicePrefix is the start of every ComplaintService method name.
+const servicePrefix = "/complaint.ComplaintService/"
+
+// mutation describes how calls to a method that changes stored data are audited.
+type mutation struct {
+	action string
+	kind   kind
+	// target returns the ID of the object the call changes. It is nil for calls
+	// that create their target, whose ID is read from the response instead.
+	target func(ctx context.Context, req interface{}) string
+}
+
+// mutations lists the audited methods, keyed by full gRPC method name. Every
+// method that changes stored data must be added here; sessions are not stored,
+// so Login, Logout and RefreshSession are not audited.
+var mutations = map[string]mutation{
+	servicePrefix + "Register":            {action: Common.AuditUserRegistered, kind: users},
+	servicePrefix + "CreateStaffUser":     {action: Common.AuditStaffCreated, kind: users},
+	servicePrefix + "SubmitComplaint":     {action: Common.AuditComplaintSubmitted, kind: complaints},
+	servicePrefix + "ResolveComplaint":    {action: Common.AuditComplaintResolved, kind: complaints, target: requestComplaintID},
+	servicePrefix + "TransitionComplaint": {action: Common.AuditComplaintStatus, kind: complaints, target: requestComplaintID},
+	servicePrefix + "AddComment":          {action: Common.AuditCommentAdded, kind: comments},
+	servicePrefix + "RotateSecretCode":    {action: Common.AuditSecretRotated, kind: users, target: callerID},
+	servicePrefix + "ResetSecretCode":     {action: Common.AuditSecretReset, kind: users, target: requestUserID},
+	servicePrefix + "RedeemRecoveryCode":  {action: Common.AuditSecretRecovered, kind: users, target: requestUserID},
+	servicePrefix + "CreateWebhook":       {action: Common.AuditWebhookCreated, kind: webhooks},
+	servicePrefix + "DeleteWebhook":       {action: Common.AuditWebhookDeleted, kind: webhooks, target: requestWebhookID},
+	servicePrefix + "RedeliverWebhook":    {action: Common.AuditWebhookRedelivered, kind: deliveries},
+}
+
+// UnaryServerInterceptor writes an audit entry for every successful call to a
+// method in mutations, with the fields of the target before and after the call.
+// It must run after Auth.UnaryServerInterceptor, which identifies the caller.
+// Failed calls change nothing and are not recorded. The call has already
+// happened when the entry is written, so a failure to write it is logged rather
+// than returned.
+func UnaryServerInterceptor(store Storage.Store) grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+		m, ok := mutations[info.FullMethod]
+		if !ok {
+			return handler(ctx, req)
+		}
+
+		var targetID string
+		var before map[string]string
+		if m.target != nil {
+			targetID = m.target(ctx, req)
+			before = m.kind.snapshot(ctx, store, req, targetID)
+		}
+
+		res, err := handler(ctx, req)
+		if err != nil {
+			return res, err
+		}
+
+		if m.target == nil {
+			if r, ok := res.(interface{ GetId() string }); ok {
+				targetID = r.GetId()
+			}
+		}
+		after := m.kind.snapshot(ctx, store, req, targetID)
+
+		// Calls made without credentials, such as Register, act on their own behalf.
+		actorID := targetID
+		if user, ok := Auth.UserFromContext(ctx); ok {
+			actorID = user.ID
+		}
+		entry := Common.AuditEntry{
+			ID:         Common.GenerateID(),
+			At:         time.Now().UTC(),
+			ActorID:    actorID,
+			Action:     m.action,
+			TargetID:   targetID,
+			Method:     info.FullMethod,
+			ClientAddr: Auth.ClientAddr(ctx),
+			Changes:    diff(before, after),
+		}
+		if err := store.AddAuditEntry(ctx, entry); err != nil {
+			slog.ErrorContext(ctx, Common.LogFailedToAudit, Common.LogKeyAction, m.action, Common.LogKeyError, err)
+		}
+		return res, nil
+	}
+}
+
+// kind is a type of audited object.
+type kind struct {
+	name string
+	// load returns the audited fields of the object with the given ID.
+	load func(ctx context.Context, store Storage.Store, req interface{}, id string) (map[string]string, error)
+}
+
+var (
+	users      = kind{name: "user", load: loadUser}
+	complaints = kind{name: "complaint", load: loadComplaint}
+	comments   = kind{name: "comment", load: loadComment}
+	webhooks   = kind{name: "webhook", load: loadWebhook}
+	deliveries = kind{name: "webhook delivery", load: loadDelivery}
+)
+
+// snapshot returns the audited fields of the object, or nil if it does not exist
+// or cannot be read.
+func (k kind) snapshot(ctx context.Context, store Storage.Store, req interface{}, id string) map[string]string {
+	if id == "" {
+		return nil
+	}
+	fields, err := k.load(ctx, store, req, id)
+	if err != nil {
+		if !errors.Is(err, Storage.ErrNotFound) {
+			slog.ErrorContext(ctx, Common.LogFailedToSnapshot, Common.LogKeyKind, k.name, Common.LogKeyTarget, id, Common.LogKeyError, err)
+		}
+		return nil
+	}
+	return fields
+}
+
+// secretFields are the fields recorded as Common.RedactedValue.
+var secretFields = map[string]bool{"SecretCode": true, "RecoveryCode": true, "Secret": true}
+
+func loadUser(ctx context.Context, store Storage.Store, req interface{}, id string) (map[string]string, error) {
+	user, err := store.GetUser(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	fields := map[string]string{
+		"Name":         user.Name,
+		"Email":        user.Email,
+		"Role":         string(user.EffectiveRole()),
+		"SecretCode":   user.SecretHash,
+		"RecoveryCode": user.RecoveryHash,
+	}
+	if !user.RecoveryExpiresAt.IsZero() {
+		fields["RecoveryExpiresAt"] = user.RecoveryExpiresAt.UTC().Format(time.RFC3339)
+	}
+	return fields, nil
+}
+
+func loadComplaint(ctx context.Context, store Storage.Store, req interface{}, id string) (map[string]string, error) {
+	complaint, err := store.GetComplaint(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	return map[string]string{
+		"Title":    complaint.Title,
+		"Summary":  complaint.Summary,
+		"Severity": strconv.Itoa(complaint.Severity),
+		"Status":   string(complaint.CurrentStatus()),
+		"Resolved": strconv.FormatBool(complaint.Resolved),
+		"UserID":   complaint.UserID,
+	}, nil
+}
+
+// loadComment finds the comment in the thread of the complaint named by the request.
+func loadComment(ctx context.Context, store Storage.Store, req interface{}, id string) (map[string]string, error) {
+	thread, err := store.ListComments(ctx, requestComplaintID(ctx, req))
+	if err != nil {
+		return nil, err
+	}
+	for _, comment := range thread {
+		if comment.ID == id {
+			return map[string]string{
+				"ComplaintID": comment.ComplaintID,
+				"AuthorID":    comment.AuthorID,
+				"Role":        string(comment.Role),
+				"Body":        comment.Body,
+			}, nil
+		}
+	}
+	return nil, Storage.ErrNotFound
+}
+
+func loadWebhook(ctx context.Context, store Storage.Store, req interface{}, id string) (map[string]string, error) {
+	webhook, err := store.GetWebhook(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	return map[string]string{
+		"URL":    webhook.URL,
+		"Events": strings.Join(webhook.Events, ","),
+		"Secret": webhook.Secret,
+	}, nil
+}
+
+// loadDelivery records what was redelivered. The status is left out: the
+// delivery is attempted in the background.
+func loadDelivery(ctx context.Context, store Storage.Store, req interface{}, id string) (map[string]string, error) {
+	delivery, err := store.GetWebhookDelivery(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	return map[string]string{
+		"WebhookID": delivery.WebhookID,
+		"Event":     delivery.Event,
+	}, nil
+}
+
+// diff returns the fields whose value differs between before and after, sorted
+// by name. A missing snapshot has every field empty.
+func diff(before, after map[string]string) []Common.AuditChange {
+	var names []string
+	for name := range before {
+		names = append(names, name)
+	}
+	for name := range after {
+		if _, ok := before[name]; !ok {
+			names = append(names, name)
+		}
+	}
+	sort.Strings(names)
+
+	var changes []Common.AuditChange
+	for _, name := range names {
+		change := Common.AuditChange{Field: name, Before: before[name], After: after[name]}
+		if change.Before == change.After {
+			continue
+		}
+		if secretFields[name] {
+			change.Before, change.After = redact(change.Before), redact(change.After)
+		}
+		changes = append(changes, change)
+	}
+	return changes
+}
+
+// redact hides a secret, keeping only whether it is set.
+func redact(value string) string {
+	if value == "" {
+		return ""
+	}
+	return Common.RedactedValue
+}
+
+func requestComplaintID(ctx context.Context, req interface{}) string {
+	if r, ok := req.(interface{ GetComplaintId() string }); ok {
+		return r.GetComplaintId()
+	}
+	return ""
+}
+
+func requestUserID(ctx context.Context, req interface{}) string {
+	if r, ok := req.(interface{ GetUserId() string }); ok {
+		return r.GetUserId()
+	}
+	return ""
+}
+
+func requestWebhookID(ctx context.Context, req interface{}) string {
+	if r, ok := req.(interface{ GetWebhookId() string }); ok {
+		return r.GetWebhookId()
+	}
+	return ""
+}
+
+// callerID returns the authenticated caller, for methods acting on their own account.
+func callerID(ctx context.Context, req interface{}) string {
+	user, _ := Auth.UserFromContext(ctx)
+	return user.ID
+}
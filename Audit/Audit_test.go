@@ -0,0 +1,179 @@
+// Audit/Audit_test.go
+package Audit
+
+import (
+	"complaint-portal/Auth"
+	"complaint-portal/Common"
+	"complaint-portal/ComplaintService"
+	pb "complaint-portal/Generated/ComplaintService"
+	"complaint-portal/Storage"
+	"context"
+	"net"
+	"strings"
+	"testing"
+	"time"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/peer"
+)
+
+// readOnlyMethods are the service methods that change no stored data.
+var readOnlyMethods = map[string]bool{
+	"Login":                 true,
+	"Logout":                true,
+	"RefreshSession":        true,
+	"GetUserComplaints":     true,
+	"GetAdminComplaints":    true,
+	"ViewComplaint":         true,
+	"ListComments":          true,
+	"QueryAuditLog":         true,
+	"SearchComplaints":      true,
+	"GetComplaintStats":     true,
+	"ListWebhooks":          true,
+	"ListWebhookDeliveries": true,
+}
+
+// TestMutationsCoverService makes sure every new RPC is either audited or known not to change anything.
+func TestMutationsCoverService(t *testing.T) {
+	for _, m := range pb.ComplaintService_ServiceDesc.Methods {
+		name := "/" + pb.ComplaintService_ServiceDesc.ServiceName + "/" + m.MethodName
+		if _, ok := mutations[name]; ok == readOnlyMethods[m.MethodName] {
+			t.Errorf("Expected %s to be listed in exactly one of mutations and readOnlyMethods", name)
+		}
+	}
+}
+
+// TestUnaryServerInterceptor runs service calls through the interceptor and checks the entries written.
+func TestUnaryServerInterceptor(t *testing.T) {
+	ctx := peer.NewContext(context.Background(), &peer.Peer{Addr: &net.TCPAddr{IP: net.ParseIP("192.0.2.1"), Port: 50000}})
+	store := Storage.NewMemoryStore()
+	store.Reset()
+	defer store.Reset()
+	hasher, _ := Auth.NewSecretHasher(Auth.RandomKey())
+	s := ComplaintService.NewServer(store, hasher, nil)
+	interceptor := UnaryServerInterceptor(store)
+
+	call := func(ctx context.Context, method string, req interface{}, handler func(context.Context) (interface{}, error)) (interface{}, error) {
+		info := &grpc.UnaryServerInfo{FullMethod: servicePrefix + method}
+		return interceptor(ctx, req, info, func(ctx context.Context, req interface{}) (interface{}, error) { return handler(ctx) })
+	}
+	lastEntry := func() Common.AuditEntry {
+		entries, _ := store.ListAuditEntries(ctx, Storage.AuditFilter{})
+		if len(entries) == 0 {
+			return Common.AuditEntry{}
+		}
+		return entries[len(entries)-1]
+	}
+	change := func(entry Common.AuditEntry, field string) (Common.AuditChange, bool) {
+		for _, c := range entry.Changes {
+			if c.Field == field {
+				return c, true
+			}
+		}
+		return Common.AuditChange{}, false
+	}
+
+	// Test case 1: Registration is attributed to the new user, with every field created
+	// and the secret code redacted
+	regReq := &pb.RegisterRequest{Name: "Ann", Email: "ann@example.com"}
+	res, err := call(ctx, "Register", regReq, func(ctx context.Context) (interface{}, error) { return s.Register(ctx, regReq) })
+	if err != nil {
+		t.Fatalf("Expected no error registering, but got: %v", err)
+	}
+	ann := res.(*pb.User)
+	entry := lastEntry()
+	if entry.Action != Common.AuditUserRegistered || entry.ActorID != ann.GetId() || entry.TargetID != ann.GetId() ||
+		entry.Method != servicePrefix+"Register" || entry.ClientAddr != "192.0.2.1" || entry.At.IsZero() {
+		t.Errorf("Expected a registration entry for %s from 192.0.2.1, but got %+v", ann.GetId(), entry)
+	}
+	if c, _ := change(entry, "Name"); c != (Common.AuditChange{Field: "Name", After: "Ann"}) {
+		t.Errorf("Expected the name to be created, but got %+v", c)
+	}
+	if c, _ := change(entry, "SecretCode"); c.After != Common.RedactedValue {
+		t.Errorf("Expected the secret code to be redacted, but got %+v", c)
+	}
+	annUser, _ := store.GetUser(ctx, ann.GetId())
+	for _, c := range entry.Changes {
+		if strings.Contains(c.After, ann.GetSecretCode()) || strings.Contains(c.After, annUser.SecretHash) {
+			t.Errorf("Expected no secret in the audit log, but got %+v", c)
+		}
+	}
+
+	// Test case 2: A complaint submitted by a user is attributed to them
+	annCtx := Auth.WithUser(ctx, annUser)
+	submitReq := &pb.SubmitComplaintRequest{Title: "Broken", Summary: "It broke", Severity: 3}
+	res, err = call(annCtx, "SubmitComplaint", submitReq, func(ctx context.Context) (interface{}, error) { return s.SubmitComplaint(ctx, submitReq) })
+	if err != nil {
+		t.Fatalf("Expected no error submitting, but got: %v", err)
+	}
+	complaintID := res.(*pb.Complaint).GetId()
+	if entry := lastEntry(); entry.Action != Common.AuditComplaintSubmitted || entry.ActorID != ann.GetId() || entry.TargetID != complaintID {
+		t.Errorf("Expected a submission entry by %s, but got %+v", ann.GetId(), entry)
+	}
+
+	// Test case 3: Resolving records only the fields that changed
+	agent, _, _ := s.CreateAccount(ctx, "Agent", "agent@example.com", Common.RoleAgent)
+	agentCtx := Auth.WithUser(ctx, agent)
+	resolveReq := &pb.ResolveComplaintRequest{ComplaintId: complaintID}
+	if _, err := call(agentCtx, "ResolveComplaint", resolveReq, func(ctx context.Context) (interface{}, error) { return s.ResolveComplaint(ctx, resolveReq) }); err != nil {
+		t.Fatalf("Expected no error resolving, but got: %v", err)
+	}
+	entry = lastEntry()
+	want := []Common.AuditChange{{Field: "Resolved", Before: "false", After: "true"}, {Field: "Status", Before: "Open", After: "Resolved"}}
+	if entry.Action != Common.AuditComplaintResolved || entry.ActorID != agent.ID || len(entry.Changes) != 2 || entry.Changes[0] != want[0] || entry.Changes[1] != want[1] {
+		t.Errorf("Expected a resolution by the agent changing %+v, but got %+v", want, entry)
+	}
+
+	// Test case 4: Comments are audited as their own target
+	commentReq := &pb.AddCommentRequest{ComplaintId: complaintID, Body: "Fixed"}
+	res, err = call(agentCtx, "AddComment", commentReq, func(ctx context.Context) (interface{}, error) { return s.AddComment(ctx, commentReq) })
+	if err != nil {
+		t.Fatalf("Expected no error commenting, but got: %v", err)
+	}
+	entry = lastEntry()
+	if c, _ := change(entry, "Body"); entry.TargetID != res.(*pb.Comment).GetId() || c.After != "Fixed" {
+		t.Errorf("Expected the comment to be recorded, but got %+v", entry)
+	}
+
+	// Test case 5: Failed calls and read-only calls are not recorded
+	before, _ := store.ListAuditEntries(ctx, Storage.AuditFilter{})
+	missingReq := &pb.ResolveComplaintRequest{ComplaintId: "missing"}
+	if _, err := call(agentCtx, "ResolveComplaint", missingReq, func(ctx context.Context) (interface{}, error) { return s.ResolveComplaint(ctx, missingReq) }); err == nil {
+		t.Fatal("Expected resolving a missing complaint to fail")
+	}
+	viewReq := &pb.ViewComplaintRequest{ComplaintId: complaintID}
+	call(annCtx, "ViewComplaint", viewReq, func(ctx context.Context) (interface{}, error) { return s.ViewComplaint(ctx, viewReq) })
+	if after, _ := store.ListAuditEntries(ctx, Storage.AuditFilter{}); len(after) != len(before) {
+		t.Errorf("Expected no new entry, but got %+v", after[len(before):])
+	}
+
+	// Test case 6: Rotations, resets and recoveries show that the codes changed
+	rotateReq := &pb.RotateSecretCodeRequest{}
+	call(annCtx, "RotateSecretCode", rotateReq, func(ctx context.Context) (interface{}, error) { return s.RotateSecretCode(ctx, rotateReq) })
+	entry = lastEntry()
+	if c, _ := change(entry, "SecretCode"); entry.Action != Common.AuditSecretRotated || entry.TargetID != ann.GetId() || c.Before != Common.RedactedValue || c.After != Common.RedactedValue {
+		t.Errorf("Expected a rotation of the secret code, but got %+v", entry)
+	}
+
+	admin, _, _ := s.CreateAccount(ctx, "Admin", "admin@example.com", Common.RoleAdmin)
+	resetReq := &pb.ResetSecretCodeRequest{UserId: ann.GetId()}
+	res, err = call(Auth.WithUser(ctx, admin), "ResetSecretCode", resetReq, func(ctx context.Context) (interface{}, error) { return s.ResetSecretCode(ctx, resetReq) })
+	if err != nil {
+		t.Fatalf("Expected no error resetting, but got: %v", err)
+	}
+	entry = lastEntry()
+	expiry, _ := change(entry, "RecoveryExpiresAt")
+	if entry.Action != Common.AuditSecretReset || entry.ActorID != admin.ID || entry.TargetID != ann.GetId() ||
+		expiry.After != res.(*pb.ResetSecretCodeResponse).GetExpiresAt().AsTime().Format(time.RFC3339) {
+		t.Errorf("Expected a reset of %s by the admin with the recovery expiry, but got %+v", ann.GetId(), entry)
+	}
+
+	redeemReq := &pb.RedeemRecoveryCodeRequest{UserId: ann.GetId(), RecoveryCode: res.(*pb.ResetSecretCodeResponse).GetRecoveryCode()}
+	if _, err := call(ctx, "RedeemRecoveryCode", redeemReq, func(ctx context.Context) (interface{}, error) { return s.RedeemRecoveryCode(ctx, redeemReq) }); err != nil {
+		t.Fatalf("Expected no error redeeming, but got: %v", err)
+	}
+	entry = lastEntry()
+	if c, _ := change(entry, "RecoveryCode"); entry.Action != Common.AuditSecretRecovered || entry.ActorID != ann.GetId() || c.Before != Common.RedactedValue || c.After != "" {
+		t.Errorf("Expected the recovery to be attributed to the user, but got %+v", entry)
+	}
+}
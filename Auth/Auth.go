@@ -0,0 +1,267 @@
+// Auth/Auth.go
+package Auth
+
+import (
+	"complaint-portal/Common"
+	"complaint-portal/Logging"
+	"complaint-portal/Storage"
+	"context"
+	"errors"
+	"strings"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+)
+
+// Access is the minimum level of trust needed to call an RPC.
+type Access int
+
+const (
+	// Public methods need no credentials.
+	Public Access = iota
+	// Authenticated methods can be called by any registered user.
+	Authenticated
+	// Staff methods can be called by agents and admins.
+	Staff
+	// Admin methods can only be called by admins.
+	Admin
+)
+
+// servicePrefix is the start of every ComplaintService method name.
+const servicePrefix = "/complaint.ComplaintService/"
+
+// MethodAccess is the permission table enforced by UnaryServerInterceptor,
+// keyed by full gRPC method name. Methods missing from it are refused, so
+// every new RPC must be added here.
+var MethodAccess = map[string]Access{
+	servicePrefix + "Register":              Public,
+	servicePrefix + "Login":                 Public,
+	servicePrefix + "SubmitComplaint":       Authenticated,
+	servicePrefix + "GetUserComplaints":     Authenticated,
+	servicePrefix + "ViewComplaint":         Authenticated,
+	servicePrefix + "TransitionComplaint":   Authenticated,
+	servicePrefix + "AddComment":            Authenticated,
+	servicePrefix + "ListComments":          Authenticated,
+	servicePrefix + "GetAdminComplaints":    Staff,
+	servicePrefix + "ResolveComplaint":      Staff,
+	servicePrefix + "CreateStaffUser":       Admin,
+	servicePrefix + "Logout":                Authenticated,
+	servicePrefix + "RefreshSession":        Authenticated,
+	servicePrefix + "RotateSecretCode":      Authenticated,
+	servicePrefix + "ResetSecretCode":       Admin,
+	servicePrefix + "RedeemRecoveryCode":    Public,
+	servicePrefix + "QueryAuditLog":         Admin,
+	servicePrefix + "SearchComplaints":      Authenticated,
+	servicePrefix + "WatchComplaints":       Authenticated,
+	servicePrefix + "GetComplaintStats":     Admin,
+	servicePrefix + "ExportComplaints":      Admin,
+	servicePrefix + "CreateWebhook":         Admin,
+	servicePrefix + "ListWebhooks":          Admin,
+	servicePrefix + "DeleteWebhook":         Admin,
+	servicePrefix + "ListWebhookDeliveries": Admin,
+	servicePrefix + "RedeliverWebhook":      Admin,
+
+	// Probes and tools only learn whether the server is up and what its API
+	// is, which the REST gateway publishes anyway.
+	"/grpc.health.v1.Health/Check":                                   Public,
+	"/grpc.health.v1.Health/List":                                    Public,
+	"/grpc.health.v1.Health/Watch":                                   Public,
+	"/grpc.reflection.v1.ServerReflection/ServerReflectionInfo":      Public,
+	"/grpc.reflection.v1alpha.ServerReflection/ServerReflectionInfo": Public,
+}
+
+// Allows reports whether a user with the given role may call a method with this access level.
+func (a Access) Allows(role Common.Role) bool {
+	switch a {
+	case Public, Authenticated:
+		return true
+	case Staff:
+		return role == Common.RoleAgent || role == Common.RoleAdmin
+	case Admin:
+		return role == Common.RoleAdmin
+	default:
+		return false
+	}
+}
+
+// secretCodeRequest is implemented by every request message that carries credentials.
+type secretCodeRequest interface {
+	GetSecretCode() string
+}
+
+// UnaryServerInterceptor authenticates the caller of every non-public RPC, checks
+// the method's entry in MethodAccess, and stores the user in the context for the
+// handler. Callers authenticate with a session token in the authorization metadata
+// or, until every client has moved to sessions, with the secret code in the request.
+// It returns Unauthenticated when the credentials are missing or wrong, and
+// PermissionDenied when the user's role is not allowed to call the method.
+func UnaryServerInterceptor(store Storage.Store, hasher *SecretHasher, sessions *Sessions) grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+		access, ok := MethodAccess[info.FullMethod]
+		if !ok {
+			return nil, status.Errorf(codes.PermissionDenied, Common.ErrPermissionDenied)
+		}
+		if access == Public {
+			return handler(ctx, req)
+		}
+		ctx, err := authorize(ctx, store, hasher, sessions, access, req)
+		if err != nil {
+			return nil, err
+		}
+		return handler(ctx, req)
+	}
+}
+
+// StreamServerInterceptor is UnaryServerInterceptor for streaming RPCs. The
+// credentials are checked against the first message the handler receives, and
+// the handler may not send anything before it.
+func StreamServerInterceptor(store Storage.Store, hasher *SecretHasher, sessions *Sessions) grpc.StreamServerInterceptor {
+	return func(srv interface{}, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+		access, ok := MethodAccess[info.FullMethod]
+		if !ok {
+			return status.Errorf(codes.PermissionDenied, Common.ErrPermissionDenied)
+		}
+		if access == Public {
+			return handler(srv, ss)
+		}
+		return handler(srv, newFirstMessageStream(ss, func(ctx context.Context, req interface{}) (context.Context, error) {
+			return authorize(ctx, store, hasher, sessions, access, req)
+		}))
+	}
+}
+
+// authorize authenticates the caller of a non-public method and checks its
+// access level, returning ctx with the user and any session stored in it. The
+// user is logged with the call even when it may not make it.
+func authorize(ctx context.Context, store Storage.Store, hasher *SecretHasher, sessions *Sessions, access Access, req interface{}) (context.Context, error) {
+	if token, ok, err := bearerToken(ctx); err != nil {
+		return nil, err
+	} else if ok {
+		session, err := sessions.Verify(token)
+		if err != nil {
+			return nil, status.Errorf(codes.Unauthenticated, Common.ErrInvalidSession)
+		}
+		// The user is looked up so that tokens issued before a secret code change,
+		// or for users who no longer exist, are refused, and the role is current.
+		user, err := store.GetUser(ctx, session.UserID)
+		if errors.Is(err, Storage.ErrNotFound) {
+			return nil, status.Errorf(codes.Unauthenticated, Common.ErrInvalidSession)
+		}
+		if err != nil {
+			return nil, status.Errorf(codes.Internal, "Failed to query user: %v", err)
+		}
+		if session.CheckUser(user) != nil {
+			return nil, status.Errorf(codes.Unauthenticated, Common.ErrInvalidSession)
+		}
+		Logging.SetUserID(ctx, user.ID)
+		if !access.Allows(user.EffectiveRole()) {
+			return nil, status.Errorf(codes.PermissionDenied, Common.ErrPermissionDenied)
+		}
+		return WithSession(WithUser(ctx, user), session), nil
+	}
+
+	var secretCode string
+	if r, ok := req.(secretCodeRequest); ok {
+		secretCode = r.GetSecretCode()
+	}
+	if secretCode == "" {
+		return nil, status.Errorf(codes.Unauthenticated, Common.ErrMissingCredentials)
+	}
+
+	user, err := hasher.FindUser(ctx, store, secretCode)
+	if errors.Is(err, Storage.ErrNotFound) {
+		return nil, status.Errorf(codes.Unauthenticated, Common.ErrUnauthorized)
+	}
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "Failed to query user: %v", err)
+	}
+
+	Logging.SetUserID(ctx, user.ID)
+	if !access.Allows(user.EffectiveRole()) {
+		return nil, status.Errorf(codes.PermissionDenied, Common.ErrPermissionDenied)
+	}
+	return WithUser(ctx, user), nil
+}
+
+// firstMessageStream runs check on the first message received from the client,
+// and from then on serves the context check returned. Sending before that
+// message was received and checked fails.
+type firstMessageStream struct {
+	grpc.ServerStream
+	ctx     context.Context
+	check   func(ctx context.Context, req interface{}) (context.Context, error)
+	checked bool
+}
+
+func newFirstMessageStream(ss grpc.ServerStream, check func(ctx context.Context, req interface{}) (context.Context, error)) *firstMessageStream {
+	return &firstMessageStream{ServerStream: ss, ctx: ss.Context(), check: check}
+}
+
+func (s *firstMessageStream) Context() context.Context {
+	return s.ctx
+}
+
+func (s *firstMessageStream) RecvMsg(m interface{}) error {
+	if err := s.ServerStream.RecvMsg(m); err != nil || s.checked {
+		return err
+	}
+	ctx, err := s.check(s.ctx, m)
+	if err != nil {
+		return err
+	}
+	s.ctx, s.checked = ctx, true
+	return nil
+}
+
+func (s *firstMessageStream) SendMsg(m interface{}) error {
+	if !s.checked {
+		return status.Errorf(codes.PermissionDenied, Common.ErrPermissionDenied)
+	}
+	return s.ServerStream.SendMsg(m)
+}
+
+// bearerToken returns the session token from the authorization metadata, if the
+// caller sent one. A header that is not a bearer token is an error.
+func bearerToken(ctx context.Context) (string, bool, error) {
+	md, _ := metadata.FromIncomingContext(ctx)
+	values := md.Get(Common.AuthorizationMetadata)
+	if len(values) == 0 {
+		return "", false, nil
+	}
+	token, ok := strings.CutPrefix(values[0], Common.BearerPrefix)
+	if !ok || token == "" {
+		return "", false, status.Errorf(codes.Unauthenticated, Common.ErrInvalidSession)
+	}
+	return token, true, nil
+}
+
+// userKey is the context key under which the authenticated user is stored.
+type userKey struct{}
+
+// WithUser returns a copy of ctx carrying the authenticated user.
+func WithUser(ctx context.Context, user Common.User) context.Context {
+	return context.WithValue(ctx, userKey{}, user)
+}
+
+// UserFromContext returns the user stored by the interceptor, if any.
+func UserFromContext(ctx context.Context) (Common.User, bool) {
+	user, ok := ctx.Value(userKey{}).(Common.User)
+	return user, ok
+}
+
+// sessionKey is the context key under which the caller's session is stored.
+type sessionKey struct{}
+
+// WithSession returns a copy of ctx carrying the caller's session.
+func WithSession(ctx context.Context, session Session) context.Context {
+	return context.WithValue(ctx, sessionKey{}, session)
+}
+
+// SessionFromContext returns the session the caller authenticated with. It is
+// only set when the caller sent a session token rather than a secret code.
+func SessionFromContext(ctx context.Context) (Session, bool) {
+	session, ok := ctx.Value(sessionKey{}).(Session)
+	return session, ok
+}
@@ -0,0 +1,568 @@
+// Auth/Auth_test.go
+package Auth
+
+import (
+	"complaint-portal/Common"
+	pb "complaint-portal/Generated/ComplaintService"
+	"complaint-portal/Storage"
+	"context"
+	"errors"
+	"net"
+	"strings"
+	"testing"
+	"time"
+
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/peer"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/proto"
+)
+
+// TestMethodAccessCoversService checks that every RPC has an entry in the permission table.
+func TestMethodAccessCoversService(t *testing.T) {
+	var names []string
+	for _, m := range pb.ComplaintService_ServiceDesc.Methods {
+		names = append(names, m.MethodName)
+	}
+	for _, s := range pb.ComplaintService_ServiceDesc.Streams {
+		names = append(names, s.StreamName)
+	}
+	for _, n := range names {
+		name := "/" + pb.ComplaintService_ServiceDesc.ServiceName + "/" + n
+		if _, ok := MethodAccess[name]; !ok {
+			t.Errorf("Expected %s to be listed in MethodAccess", name)
+		}
+	}
+}
+
+// TestUnaryServerInterceptor tests authentication and role checks.
+func TestUnaryServerInterceptor(t *testing.T) {
+	ctx := context.Background()
+	store := Storage.NewMemoryStore()
+	store.Reset()
+	defer store.Reset()
+
+	hasher := newTestHasher(t)
+	users := []Common.User{
+		{ID: "cust", SecretHash: hasher.Hash("cust-code"), Name: "Customer", Email: "cust@example.com"},
+		{ID: "agent", SecretHash: hasher.Hash("agent-code"), Name: "Agent", Email: "agent@example.com", Role: Common.RoleAgent},
+		{ID: "admin", SecretHash: hasher.Hash("admin-code"), Name: "Admin", Email: "admin@example.com", Role: Common.RoleAdmin, SessionGeneration: 1},
+	}
+	for _, u := range users {
+		if err := store.CreateUser(ctx, u); err != nil {
+			t.Fatalf("CreateUser failed: %v", err)
+		}
+	}
+
+	sessions := newTestSessions(t)
+	token := func(user Common.User) string {
+		session, err := sessions.Issue(user)
+		if err != nil {
+			t.Fatalf("Issue failed: %v", err)
+		}
+		return session.Token
+	}
+	revoked, _ := sessions.Issue(users[0])
+	sessions.Revoke(revoked)
+	// Sessions issued before the admin's secret code last changed, for a role the
+	// user does not have, or for a user who no longer exists.
+	stale, _ := sessions.Issue(Common.User{ID: "admin", Role: Common.RoleAdmin})
+	promoted, _ := sessions.Issue(Common.User{ID: "cust", Role: Common.RoleAdmin})
+	deleted, _ := sessions.Issue(Common.User{ID: "gone", Role: Common.RoleAdmin})
+
+	interceptor := UnaryServerInterceptor(store, hasher, sessions)
+	var caller Common.User
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		caller, _ = UserFromContext(ctx)
+		return "ok", nil
+	}
+	call := func(method string, req interface{}, authorization string) error {
+		caller = Common.User{}
+		ctx := ctx
+		if authorization != "" {
+			ctx = metadata.NewIncomingContext(ctx, metadata.Pairs(Common.AuthorizationMetadata, authorization))
+		}
+		_, err := interceptor(ctx, req, &grpc.UnaryServerInfo{FullMethod: servicePrefix + method}, handler)
+		return err
+	}
+
+	tests := []struct {
+		name          string
+		method        string
+		req           interface{}
+		authorization string
+		want          codes.Code
+		caller        string
+	}{
+		{"public method needs no code", "Register", &pb.RegisterRequest{}, "", codes.OK, ""},
+		{"missing code", "SubmitComplaint", &pb.SubmitComplaintRequest{}, "", codes.Unauthenticated, ""},
+		{"wrong code", "SubmitComplaint", &pb.SubmitComplaintRequest{SecretCode: "nope"}, "", codes.Unauthenticated, ""},
+		{"customer submits", "SubmitComplaint", &pb.SubmitComplaintRequest{SecretCode: "cust-code"}, "", codes.OK, "cust"},
+		{"customer on staff method", "GetAdminComplaints", &pb.GetAdminComplaintsRequest{SecretCode: "cust-code"}, "", codes.PermissionDenied, ""},
+		{"agent on staff method", "GetAdminComplaints", &pb.GetAdminComplaintsRequest{SecretCode: "agent-code"}, "", codes.OK, "agent"},
+		{"agent on admin method", "CreateStaffUser", &pb.CreateStaffUserRequest{SecretCode: "agent-code"}, "", codes.PermissionDenied, ""},
+		{"admin on admin method", "CreateStaffUser", &pb.CreateStaffUserRequest{SecretCode: "admin-code"}, "", codes.OK, "admin"},
+		{"unknown method", "Unknown", &pb.RegisterRequest{}, "", codes.PermissionDenied, ""},
+		{"customer session", "SubmitComplaint", &pb.SubmitComplaintRequest{}, "Bearer " + token(users[0]), codes.OK, "cust"},
+		{"session wins over secret code", "SubmitComplaint", &pb.SubmitComplaintRequest{SecretCode: "admin-code"}, "Bearer " + token(users[0]), codes.OK, "cust"},
+		{"customer session on staff method", "GetAdminComplaints", &pb.GetAdminComplaintsRequest{}, "Bearer " + token(users[0]), codes.PermissionDenied, ""},
+		{"agent session on staff method", "GetAdminComplaints", &pb.GetAdminComplaintsRequest{}, "Bearer " + token(users[1]), codes.OK, "agent"},
+		{"logout with a session", "Logout", &pb.LogoutRequest{}, "Bearer " + token(users[0]), codes.OK, "cust"},
+		{"logout without credentials", "Logout", &pb.LogoutRequest{}, "", codes.Unauthenticated, ""},
+		{"revoked session", "SubmitComplaint", &pb.SubmitComplaintRequest{}, "Bearer " + revoked.Token, codes.Unauthenticated, ""},
+		{"admin session", "CreateStaffUser", &pb.CreateStaffUserRequest{}, "Bearer " + token(users[2]), codes.OK, "admin"},
+		{"session from before a secret code change", "CreateStaffUser", &pb.CreateStaffUserRequest{}, "Bearer " + stale.Token, codes.Unauthenticated, ""},
+		{"session with a role the user lost", "CreateStaffUser", &pb.CreateStaffUserRequest{}, "Bearer " + promoted.Token, codes.PermissionDenied, ""},
+		{"session of a deleted user", "SubmitComplaint", &pb.SubmitComplaintRequest{}, "Bearer " + deleted.Token, codes.Unauthenticated, ""},
+		{"forged session", "SubmitComplaint", &pb.SubmitComplaintRequest{}, "Bearer " + token(users[0]) + "x", codes.Unauthenticated, ""},
+		{"not a bearer token", "SubmitComplaint", &pb.SubmitComplaintRequest{SecretCode: "cust-code"}, "Basic abc", codes.Unauthenticated, ""},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := call(tt.method, tt.req, tt.authorization)
+			if status.Code(err) != tt.want {
+				t.Fatalf("Expected %v, but got %v", tt.want, status.Code(err))
+			}
+			if caller.ID != tt.caller {
+				t.Errorf("Expected the handler to see user %q, but got %q", tt.caller, caller.ID)
+			}
+		})
+	}
+}
+
+// fakeStream is a server stream whose client sends a single request.
+type fakeStream struct {
+	grpc.ServerStream
+	ctx  context.Context
+	req  proto.Message
+	sent int
+}
+
+func (f *fakeStream) Context() context.Context { return f.ctx }
+
+func (f *fakeStream) RecvMsg(m interface{}) error {
+	proto.Merge(m.(proto.Message), f.req)
+	return nil
+}
+
+func (f *fakeStream) SendMsg(m interface{}) error {
+	f.sent++
+	return nil
+}
+
+// TestStreamServerInterceptor tests that streams are authorized on their first message.
+func TestStreamServerInterceptor(t *testing.T) {
+	ctx := context.Background()
+	store := Storage.NewMemoryStore()
+	store.Reset()
+	defer store.Reset()
+
+	hasher := newTestHasher(t)
+	sessions := newTestSessions(t)
+	customer := Common.User{ID: "cust", SecretHash: hasher.Hash("cust-code"), Name: "Customer", Email: "cust@example.com"}
+	if err := store.CreateUser(ctx, customer); err != nil {
+		t.Fatalf("CreateUser failed: %v", err)
+	}
+	session, _ := sessions.Issue(customer)
+
+	interceptor := StreamServerInterceptor(store, hasher, sessions)
+	var caller Common.User
+	var sendErr error
+	handler := func(srv interface{}, stream grpc.ServerStream) error {
+		caller = Common.User{}
+		req := &pb.WatchComplaintsRequest{}
+		if err := stream.RecvMsg(req); err != nil {
+			return err
+		}
+		caller, _ = UserFromContext(stream.Context())
+		return stream.SendMsg(&pb.ComplaintEvent{})
+	}
+
+	tests := []struct {
+		name          string
+		method        string
+		req           proto.Message
+		authorization string
+		want          codes.Code
+		caller        string
+	}{
+		{"secret code", "WatchComplaints", &pb.WatchComplaintsRequest{SecretCode: "cust-code"}, "", codes.OK, "cust"},
+		{"session", "WatchComplaints", &pb.WatchComplaintsRequest{}, "Bearer " + session.Token, codes.OK, "cust"},
+		{"missing code", "WatchComplaints", &pb.WatchComplaintsRequest{}, "", codes.Unauthenticated, ""},
+		{"wrong code", "WatchComplaints", &pb.WatchComplaintsRequest{SecretCode: "nope"}, "", codes.Unauthenticated, ""},
+		{"unknown method", "Unknown", &pb.WatchComplaintsRequest{SecretCode: "cust-code"}, "", codes.PermissionDenied, ""},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := ctx
+			if tt.authorization != "" {
+				ctx = metadata.NewIncomingContext(ctx, metadata.Pairs(Common.AuthorizationMetadata, tt.authorization))
+			}
+			stream := &fakeStream{ctx: ctx, req: tt.req}
+			err := interceptor(nil, stream, &grpc.StreamServerInfo{FullMethod: servicePrefix + tt.method, IsServerStream: true}, handler)
+			if status.Code(err) != tt.want {
+				t.Fatalf("Expected %v, but got %v", tt.want, err)
+			}
+			if caller.ID != tt.caller {
+				t.Errorf("Expected the handler to see user %q, but got %q", tt.caller, caller.ID)
+			}
+			wantSent := 0
+			if tt.want == codes.OK {
+				wantSent = 1
+			}
+			if stream.sent != wantSent {
+				t.Errorf("Expected %d message(s) sent, but got %d", wantSent, stream.sent)
+			}
+		})
+	}
+
+	// Sending before the request was received and checked is refused.
+	early := func(srv interface{}, stream grpc.ServerStream) error {
+		sendErr = stream.SendMsg(&pb.ComplaintEvent{})
+		return nil
+	}
+	stream := &fakeStream{ctx: ctx, req: &pb.WatchComplaintsRequest{SecretCode: "cust-code"}}
+	interceptor(nil, stream, &grpc.StreamServerInfo{FullMethod: servicePrefix + "WatchComplaints"}, early)
+	if status.Code(sendErr) != codes.PermissionDenied || stream.sent != 0 {
+		t.Errorf("Expected PermissionDenied for a send before the first message, but got %v", sendErr)
+	}
+}
+
+// newTestHasher returns a SecretHasher with a fixed pepper.
+func newTestHasher(t *testing.T) *SecretHasher {
+	t.Helper()
+	hasher, err := NewSecretHasher([]byte("pepper-pepper-pepper"))
+	if err != nil {
+		t.Fatalf("NewSecretHasher failed: %v", err)
+	}
+	return hasher
+}
+
+// TestSecretHasher tests hashing, verifying and looking up secret codes.
+func TestSecretHasher(t *testing.T) {
+	ctx := context.Background()
+	hasher := newTestHasher(t)
+
+	// Test case 1: Hashes are deterministic, keyed and never the code itself
+	hash := hasher.Hash("abc123")
+	if hash != hasher.Hash("abc123") || hash == "abc123" || !IsSecretHash(hash) {
+		t.Errorf("Unexpected hash %q", hash)
+	}
+	other, _ := NewSecretHasher([]byte("another-pepper-value"))
+	if other.Hash("abc123") == hash {
+		t.Error("Expected a different pepper to give a different hash")
+	}
+	if !hasher.Verify("abc123", hash) || hasher.Verify("abc124", hash) {
+		t.Error("Expected Verify to accept only the right code")
+	}
+	if _, err := NewSecretHasher([]byte("short")); err == nil {
+		t.Error("Expected a short pepper to be refused")
+	}
+
+	// Test case 2: Plaintext codes left by older versions are rehashed once
+	store := Storage.NewMemoryStore()
+	store.Reset()
+	defer store.Reset()
+	store.CreateUser(ctx, Common.User{ID: "old", SecretHash: "plain-code", Email: "old@example.com"})
+	store.CreateUser(ctx, Common.User{ID: "new", SecretHash: hasher.Hash("new-code"), Email: "new@example.com"})
+	for _, want := range []int{1, 0} {
+		n, err := RehashSecretCodes(ctx, store, hasher)
+		if err != nil || n != want {
+			t.Errorf("Expected %d user(s) rehashed, but got %d (err: %v)", want, n, err)
+		}
+	}
+
+	// Test case 3: Both users can be found by their code, and only by it
+	for id, code := range map[string]string{"old": "plain-code", "new": "new-code"} {
+		if user, err := hasher.FindUser(ctx, store, code); err != nil || user.ID != id {
+			t.Errorf("Expected code %q to find user %s, but got %q (err: %v)", code, id, user.ID, err)
+		}
+	}
+	for _, code := range []string{"", "wrong", hasher.Hash("new-code")} {
+		if _, err := hasher.FindUser(ctx, store, code); !errors.Is(err, Storage.ErrNotFound) {
+			t.Errorf("Expected ErrNotFound for code %q, but got %v", code, err)
+		}
+	}
+}
+
+// newTestSessions returns a Sessions with a fixed key and a one hour lifetime.
+func newTestSessions(t *testing.T) *Sessions {
+	t.Helper()
+	sessions, err := NewSessions([]byte("0123456789abcdef0123456789abcdef"), time.Hour)
+	if err != nil {
+		t.Fatalf("NewSessions failed: %v", err)
+	}
+	return sessions
+}
+
+// TestSessions tests issuing, verifying, refreshing and revoking session tokens.
+func TestSessions(t *testing.T) {
+	sessions := newTestSessions(t)
+	now := time.Date(2024, 1, 1, 12, 0, 0, 0, time.UTC)
+	sessions.now = func() time.Time { return now }
+	user := Common.User{ID: "u1", Role: Common.RoleAgent, SessionGeneration: 3}
+
+	// Test case 1: An issued token verifies to the same user and session generation
+	session, err := sessions.Issue(user)
+	if err != nil {
+		t.Fatalf("Issue failed: %v", err)
+	}
+	got, err := sessions.Verify(session.Token)
+	if err != nil {
+		t.Fatalf("Expected the token to verify, but got: %v", err)
+	}
+	if got.UserID != "u1" || got.Role != Common.RoleAgent || got.Generation != 3 || !got.ExpiresAt.Equal(now.Add(time.Hour)) {
+		t.Errorf("Unexpected session %+v", got)
+	}
+	if err := got.CheckUser(user); err != nil {
+		t.Errorf("Expected the session to match the user, but got %v", err)
+	}
+	user.SessionGeneration++
+	if err := got.CheckUser(user); !errors.Is(err, ErrStaleToken) {
+		t.Errorf("Expected ErrStaleToken once the generation is bumped, but got %v", err)
+	}
+
+	// Test case 2: Tokens signed with another key, or tampered with, are rejected
+	other, _ := NewSessions([]byte("another-key-another-key-another-"), time.Hour)
+	if _, err := other.Verify(session.Token); !errors.Is(err, ErrInvalidToken) {
+		t.Errorf("Expected ErrInvalidToken for a foreign key, but got %v", err)
+	}
+	for _, token := range []string{"", "a.b", session.Token[:len(session.Token)-2], "eyJhbGciOiJub25lIn0." + strings.SplitN(session.Token, ".", 3)[1] + "."} {
+		if _, err := sessions.Verify(token); !errors.Is(err, ErrInvalidToken) {
+			t.Errorf("Expected ErrInvalidToken for %q, but got %v", token, err)
+		}
+	}
+	if _, err := NewSessions([]byte("short"), time.Hour); err == nil {
+		t.Error("Expected a short key to be refused")
+	}
+
+	// Test case 3: Refreshing revokes the old token, and the new one has the user's current role
+	next, err := sessions.Refresh(got, Common.User{ID: "u1", Role: Common.RoleAdmin})
+	if err != nil {
+		t.Fatalf("Refresh failed: %v", err)
+	}
+	if _, err := sessions.Verify(session.Token); !errors.Is(err, ErrRevokedToken) {
+		t.Errorf("Expected ErrRevokedToken after a refresh, but got %v", err)
+	}
+	if refreshed, err := sessions.Verify(next.Token); err != nil || refreshed.Role != Common.RoleAdmin {
+		t.Errorf("Expected the new token to verify with the admin role, but got %+v, %v", refreshed, err)
+	}
+
+	// Test case 4: Tokens expire, and expired revocations are forgotten
+	now = now.Add(time.Hour)
+	if _, err := sessions.Verify(next.Token); !errors.Is(err, ErrExpiredToken) {
+		t.Errorf("Expected ErrExpiredToken, but got %v", err)
+	}
+	sessions.Revoke(next)
+	if len(sessions.revoked) != 1 {
+		t.Errorf("Expected only the last revocation to be kept, but got %d", len(sessions.revoked))
+	}
+}
+
+// TestMemoryLimiter tests the token bucket, lockouts and their backoff.
+func TestMemoryLimiter(t *testing.T) {
+	now := time.Date(2024, 1, 1, 12, 0, 0, 0, time.UTC)
+	l := NewMemoryLimiter(Policy{Rate: 1, Burst: 3, FreeFailures: 2, BaseLockout: time.Second, MaxLockout: 4 * time.Second, FailureWindow: time.Minute})
+	l.now = func() time.Time { return now }
+
+	// Test case 1: The burst is allowed, then attempts are refilled at the rate
+	for i := 0; i < 3; i++ {
+		if _, ok := l.Allow("a"); !ok {
+			t.Fatalf("Expected attempt %d to be allowed", i+1)
+		}
+	}
+	if wait, ok := l.Allow("a"); ok || wait != time.Second {
+		t.Errorf("Expected to wait 1s after the burst, but got %v (allowed: %v)", wait, ok)
+	}
+	if _, ok := l.Allow("b"); !ok {
+		t.Error("Expected other keys not to be affected")
+	}
+	now = now.Add(time.Second)
+	if _, ok := l.Allow("a"); !ok {
+		t.Error("Expected an attempt to be refilled after 1s")
+	}
+
+	// Test case 2: Failures beyond the free ones lock out for 1s, 2s, 4s, 4s
+	l.Fail("c")
+	l.Fail("c")
+	if _, ok := l.Allow("c"); !ok {
+		t.Error("Expected the free failures not to lock out")
+	}
+	for _, want := range []time.Duration{time.Second, 2 * time.Second, 4 * time.Second, 4 * time.Second} {
+		l.Fail("c")
+		if wait, ok := l.Allow("c"); ok || wait != want {
+			t.Errorf("Expected a %v lockout, but got %v (allowed: %v)", want, wait, ok)
+		}
+	}
+
+	// Test case 3: Lockouts stop at the maximum, however long the base and many the failures
+	long := NewMemoryLimiter(Policy{Rate: 1, Burst: 3, BaseLockout: 30 * time.Second, MaxLockout: 15 * time.Minute, FailureWindow: time.Hour})
+	long.now = l.now
+	for i := 1; i <= 70; i++ {
+		long.Fail("e")
+		if wait, ok := long.Allow("e"); ok || wait <= 0 || wait > 15*time.Minute || (i >= 6 && wait != 15*time.Minute) {
+			t.Fatalf("Expected failure %d to lock out for at most 15m, but got %v (allowed: %v)", i, wait, ok)
+		}
+	}
+
+	// Test case 4: Failures are forgotten after the window
+	now = now.Add(2 * time.Minute)
+	l.Fail("c")
+	if _, ok := l.Allow("c"); !ok {
+		t.Error("Expected old failures to be forgotten")
+	}
+
+	// Test case 5: Refunded attempts are given back, up to the burst
+	l.Allow("f")
+	l.Allow("f")
+	l.Refund("f")
+	l.Refund("f")
+	l.Refund("f")
+	if tokens := l.entries["f"].tokens; tokens != 3 {
+		t.Errorf("Expected the bucket to be full again, but got %v attempts", tokens)
+	}
+
+	// Test case 6: Keys back to a fresh state are pruned
+	now = now.Add(2 * time.Minute)
+	l.Allow("d")
+	if _, ok := l.entries["a"]; ok || len(l.entries) != 1 {
+		t.Errorf("Expected only the new key to be kept, but got %d entries", len(l.entries))
+	}
+}
+
+// TestRateLimitInterceptor tests which calls are limited and how failures count.
+func TestRateLimitInterceptor(t *testing.T) {
+	perClient := NewMemoryLimiter(Policy{Rate: 0.001, Burst: 100, FreeFailures: 2, BaseLockout: time.Minute, MaxLockout: time.Hour, FailureWindow: time.Hour})
+	global := NewMemoryLimiter(Policy{Rate: 0.001, Burst: 6})
+	interceptor := RateLimitInterceptor(perClient, global)
+
+	var handlerErr error
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) { return "ok", handlerErr }
+	call := func(addr, method string, req interface{}, md metadata.MD) error {
+		ctx := peer.NewContext(context.Background(), &peer.Peer{Addr: &net.TCPAddr{IP: net.ParseIP(addr), Port: 4242}})
+		ctx = metadata.NewIncomingContext(ctx, md)
+		_, err := interceptor(ctx, req, &grpc.UnaryServerInfo{FullMethod: servicePrefix + method}, handler)
+		return err
+	}
+
+	// Test case 1: Calls without a secret code are never limited
+	for i := 0; i < 20; i++ {
+		if err := call("10.0.0.1", "Register", &pb.RegisterRequest{}, nil); err != nil {
+			t.Fatalf("Expected Register not to be limited, but got %v", err)
+		}
+		bearer := metadata.Pairs(Common.AuthorizationMetadata, "Bearer x")
+		if err := call("10.0.0.1", "SubmitComplaint", &pb.SubmitComplaintRequest{SecretCode: "x"}, bearer); err != nil {
+			t.Fatalf("Expected session calls not to be limited, but got %v", err)
+		}
+	}
+
+	// Test case 2: Wrong codes lock the client out after the free failures
+	handlerErr = status.Error(codes.NotFound, "wrong")
+	for i := 0; i < 3; i++ {
+		if err := call("10.0.0.2", "Login", &pb.LoginRequest{SecretCode: "guess"}, nil); status.Code(err) != codes.NotFound {
+			t.Fatalf("Expected guess %d to reach the handler, but got %v", i+1, err)
+		}
+	}
+	err := call("10.0.0.2", "Login", &pb.LoginRequest{SecretCode: "guess"}, nil)
+	if status.Code(err) != codes.ResourceExhausted {
+		t.Fatalf("Expected ResourceExhausted after a lockout, but got %v", err)
+	}
+	var retry *errdetails.RetryInfo
+	for _, d := range status.Convert(err).Details() {
+		if r, ok := d.(*errdetails.RetryInfo); ok {
+			retry = r
+		}
+	}
+	if retry == nil || retry.GetRetryDelay().AsDuration() != time.Minute {
+		t.Errorf("Expected a RetryInfo of 1m, but got %v", retry)
+	}
+
+	// Test case 3: Secret codes sent to other methods are limited too, and only
+	// Unauthenticated counts as a wrong code there
+	handlerErr = status.Error(codes.NotFound, "no such complaint")
+	for i := 0; i < 3; i++ {
+		if err := call("10.0.0.3", "ViewComplaint", &pb.ViewComplaintRequest{SecretCode: "code"}, nil); status.Code(err) != codes.NotFound {
+			t.Fatalf("Expected call %d to reach the handler, but got %v", i+1, err)
+		}
+	}
+
+	// Test case 4: The global limit applies across clients
+	handlerErr = nil
+	err = call("10.0.0.4", "Login", &pb.LoginRequest{SecretCode: "code"}, nil)
+	if status.Code(err) != codes.ResourceExhausted {
+		t.Errorf("Expected the global limit to be reached, but got %v", err)
+	}
+
+	// Test case 5: Calls the global limit refuses do not spend the client's attempts
+	for i := 0; i < 5; i++ {
+		call("10.0.0.4", "Login", &pb.LoginRequest{SecretCode: "code"}, nil)
+	}
+	if tokens := perClient.entries["10.0.0.4"].tokens; tokens != 100 {
+		t.Errorf("Expected the client's attempts to be given back, but %v are left", tokens)
+	}
+}
+
+// TestRateLimitStreamInterceptor tests that wrong codes sent to a stream count against the client.
+func TestRateLimitStreamInterceptor(t *testing.T) {
+	perClient := NewMemoryLimiter(Policy{Rate: 0.001, Burst: 100, FreeFailures: 1, BaseLockout: time.Minute, MaxLockout: time.Hour, FailureWindow: time.Hour})
+	global := NewMemoryLimiter(Policy{Rate: 0.001, Burst: 100})
+	interceptor := RateLimitStreamInterceptor(perClient, global)
+
+	handler := func(srv interface{}, stream grpc.ServerStream) error {
+		if err := stream.RecvMsg(&pb.WatchComplaintsRequest{}); err != nil {
+			return err
+		}
+		return status.Error(codes.Unauthenticated, "wrong")
+	}
+	call := func(req proto.Message, md metadata.MD) error {
+		ctx := peer.NewContext(context.Background(), &peer.Peer{Addr: &net.TCPAddr{IP: net.ParseIP("10.0.0.5"), Port: 4242}})
+		stream := &fakeStream{ctx: metadata.NewIncomingContext(ctx, md), req: req}
+		return interceptor(nil, stream, &grpc.StreamServerInfo{FullMethod: servicePrefix + "WatchComplaints"}, handler)
+	}
+
+	// Test case 1: Failures with a session token are not counted
+	bearer := metadata.Pairs(Common.AuthorizationMetadata, "Bearer x")
+	for i := 0; i < 5; i++ {
+		if err := call(&pb.WatchComplaintsRequest{}, bearer); status.Code(err) != codes.Unauthenticated {
+			t.Fatalf("Expected session call %d to reach the handler, but got %v", i+1, err)
+		}
+	}
+
+	// Test case 2: Wrong secret codes lock the client out
+	for i := 0; i < 2; i++ {
+		if err := call(&pb.WatchComplaintsRequest{SecretCode: "guess"}, nil); status.Code(err) != codes.Unauthenticated {
+			t.Fatalf("Expected guess %d to reach the handler, but got %v", i+1, err)
+		}
+	}
+	if err := call(&pb.WatchComplaintsRequest{SecretCode: "guess"}, nil); status.Code(err) != codes.ResourceExhausted {
+		t.Errorf("Expected ResourceExhausted after a lockout, but got %v", err)
+	}
+}
+
+// gatewayAddr is the address of connections from the REST gateway.
+type gatewayAddr struct{}
+
+func (gatewayAddr) Network() string { return Common.GatewayNetwork }
+func (gatewayAddr) String() string  { return Common.GatewayNetwork }
+
+// TestClientAddr tests that only the gateway can name the client of a call.
+func TestClientAddr(t *testing.T) {
+	forwarded := metadata.Pairs(Common.ClientAddrMetadata, "203.0.113.9")
+	call := func(addr net.Addr, md metadata.MD) string {
+		ctx := peer.NewContext(context.Background(), &peer.Peer{Addr: addr})
+		return ClientAddr(metadata.NewIncomingContext(ctx, md))
+	}
+
+	// Test case 1: A TCP caller is identified by its IP, whatever its metadata says
+	if got := call(&net.TCPAddr{IP: net.ParseIP("10.0.0.1"), Port: 4242}, forwarded); got != "10.0.0.1" {
+		t.Errorf("Expected 10.0.0.1, but got %q", got)
+	}
+
+	// Test case 2: Calls relayed by the gateway are attributed to its HTTP client
+	if got := call(gatewayAddr{}, forwarded); got != "203.0.113.9" {
+		t.Errorf("Expected 203.0.113.9, but got %q", got)
+	}
+}
@@ -0,0 +1,291 @@
+// Auth/RateLimit.go
+package Auth
+
+import (
+	"complaint-portal/Common"
+	"context"
+	"math"
+	"net"
+	"sync"
+	"time"
+
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/peer"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/durationpb"
+)
+
+// Limiter throttles authentication attempts by key, such as a client address.
+// The in-memory implementation is MemoryLimiter; a shared one can replace it
+// when several instances serve the same users.
+type Limiter interface {
+	// Allow takes an attempt for key. If the key is rate limited or locked out,
+	// it returns false and how long to wait before trying again.
+	Allow(key string) (time.Duration, bool)
+	// Refund gives back an attempt taken by Allow that was not made after all.
+	Refund(key string)
+	// Fail records a failed attempt for key, which may lock it out.
+	Fail(key string)
+}
+
+// Policy configures a MemoryLimiter.
+type Policy struct {
+	// Rate is how many attempts per second are refilled, up to Burst.
+	Rate  float64
+	Burst int
+	// FreeFailures is how many failures are tolerated before lockouts start.
+	// Each failure after that locks the key out for twice as long as the one
+	// before, starting at BaseLockout and capped at MaxLockout. A zero
+	// BaseLockout disables lockouts.
+	FreeFailures int
+	BaseLockout  time.Duration
+	MaxLockout   time.Duration
+	// FailureWindow is how long failures are remembered after the last one.
+	FailureWindow time.Duration
+}
+
+// DefaultClientPolicy applies to each client address: a few typos are fine,
+// sustained guessing is slowed down to hours per thousand guesses.
+var DefaultClientPolicy = Policy{
+	Rate:          10.0 / 60,
+	Burst:         10,
+	FreeFailures:  5,
+	BaseLockout:   time.Second,
+	MaxLockout:    15 * time.Minute,
+	FailureWindow: time.Hour,
+}
+
+// DefaultGlobalPolicy caps the attempts of all clients together, against
+// guessing spread over many addresses. It never locks out.
+var DefaultGlobalPolicy = Policy{
+	Rate:  100,
+	Burst: 200,
+}
+
+// MemoryLimiter is a Limiter keeping a token bucket and a failure count per key
+// in memory.
+type MemoryLimiter struct {
+	policy Policy
+	now    func() time.Time
+
+	mu        sync.Mutex
+	entries   map[string]*limiterEntry
+	lastPrune time.Time
+}
+
+type limiterEntry struct {
+	tokens      float64
+	refilledAt  time.Time
+	failures    int
+	failedAt    time.Time
+	lockedUntil time.Time
+}
+
+// NewMemoryLimiter returns an empty MemoryLimiter applying policy.
+func NewMemoryLimiter(policy Policy) *MemoryLimiter {
+	return &MemoryLimiter{policy: policy, now: time.Now, entries: make(map[string]*limiterEntry)}
+}
+
+func (l *MemoryLimiter) Allow(key string) (time.Duration, bool) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	now := l.now()
+	l.prune(now)
+	e := l.entry(key, now)
+	if now.Before(e.lockedUntil) {
+		return e.lockedUntil.Sub(now), false
+	}
+
+	e.tokens = math.Min(float64(l.policy.Burst), e.tokens+now.Sub(e.refilledAt).Seconds()*l.policy.Rate)
+	e.refilledAt = now
+	if e.tokens < 1 {
+		return time.Duration((1 - e.tokens) / l.policy.Rate * float64(time.Second)), false
+	}
+	e.tokens--
+	return 0, true
+}
+
+func (l *MemoryLimiter) Refund(key string) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	e := l.entry(key, l.now())
+	e.tokens = math.Min(float64(l.policy.Burst), e.tokens+1)
+}
+
+func (l *MemoryLimiter) Fail(key string) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	now := l.now()
+	e := l.entry(key, now)
+	if now.Sub(e.failedAt) > l.policy.FailureWindow {
+		e.failures = 0
+	}
+	e.failures++
+	e.failedAt = now
+
+	extra := e.failures - l.policy.FreeFailures
+	if l.policy.BaseLockout <= 0 || extra <= 0 {
+		return
+	}
+	e.lockedUntil = now.Add(l.lockout(extra))
+}
+
+// lockout returns how long the nth failure beyond the free ones locks out for.
+// It doubles step by step, so that it stops at MaxLockout instead of overflowing.
+func (l *MemoryLimiter) lockout(n int) time.Duration {
+	lockout := l.policy.BaseLockout
+	for i := 1; i < n; i++ {
+		if lockout >= l.policy.MaxLockout {
+			break
+		}
+		lockout *= 2
+	}
+	return min(lockout, l.policy.MaxLockout)
+}
+
+// entry returns the state of key, creating it with a full bucket.
+func (l *MemoryLimiter) entry(key string, now time.Time) *limiterEntry {
+	e, ok := l.entries[key]
+	if !ok {
+		e = &limiterEntry{tokens: float64(l.policy.Burst), refilledAt: now}
+		l.entries[key] = e
+	}
+	return e
+}
+
+// prune forgets, at most once a minute, the keys that are back to a fresh state:
+// bucket full, not locked out and no failure remembered.
+func (l *MemoryLimiter) prune(now time.Time) {
+	if now.Sub(l.lastPrune) < time.Minute {
+		return
+	}
+	l.lastPrune = now
+	full := time.Duration(float64(l.policy.Burst) / l.policy.Rate * float64(time.Second))
+	for key, e := range l.entries {
+		if now.Sub(e.refilledAt) >= full && !now.Before(e.lockedUntil) && now.Sub(e.failedAt) > l.policy.FailureWindow {
+			delete(l.entries, key)
+		}
+	}
+}
+
+// globalKey is the single key of the limiter shared by all clients.
+const globalKey = "*"
+
+// credentialMethods lists the public methods that check a credential themselves,
+// with the status code they return when it is wrong. Every other method is
+// limited when it is called with a secret code rather than a session token.
+var credentialMethods = map[string]codes.Code{
+	servicePrefix + "Login":              codes.NotFound,
+	servicePrefix + "RedeemRecoveryCode": codes.Unauthenticated,
+}
+
+// RateLimitInterceptor throttles every call that presents a secret or recovery
+// code, per client address and across all clients, and counts wrong codes as
+// failures of the client, which lead to temporary lockouts. It must run before
+// UnaryServerInterceptor so that failures there are counted. Throttled calls get
+// ResourceExhausted with a RetryInfo detail saying when to try again.
+func RateLimitInterceptor(perClient, global Limiter) grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+		failure, ok := credentialMethods[info.FullMethod]
+		if !ok {
+			if !usesSecretCode(ctx, req) {
+				return handler(ctx, req)
+			}
+			failure = codes.Unauthenticated
+		}
+
+		client := ClientAddr(ctx)
+		if err := allow(perClient, global, client); err != nil {
+			return nil, err
+		}
+
+		res, err := handler(ctx, req)
+		if status.Code(err) == failure {
+			perClient.Fail(client)
+		}
+		return res, err
+	}
+}
+
+// RateLimitStreamInterceptor is RateLimitInterceptor for streaming RPCs, which
+// are throttled when their first message carries a secret code.
+func RateLimitStreamInterceptor(perClient, global Limiter) grpc.StreamServerInterceptor {
+	return func(srv interface{}, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+		client := ClientAddr(ss.Context())
+		limited := false
+		err := handler(srv, newFirstMessageStream(ss, func(ctx context.Context, req interface{}) (context.Context, error) {
+			if !usesSecretCode(ctx, req) {
+				return ctx, nil
+			}
+			limited = true
+			return ctx, allow(perClient, global, client)
+		}))
+		if limited && status.Code(err) == codes.Unauthenticated {
+			perClient.Fail(client)
+		}
+		return err
+	}
+}
+
+// allow takes an attempt for client from both limiters. Calls the global limit
+// refuses give the client's attempt back, so that honest clients do not run out
+// while others exhaust it; calls refused per client do not take a global one.
+func allow(perClient, global Limiter, client string) error {
+	if wait, ok := perClient.Allow(client); !ok {
+		return rateLimited(wait)
+	}
+	if wait, ok := global.Allow(globalKey); !ok {
+		perClient.Refund(client)
+		return rateLimited(wait)
+	}
+	return nil
+}
+
+// usesSecretCode reports whether the call authenticates with a secret code in
+// the request rather than with a session token.
+func usesSecretCode(ctx context.Context, req interface{}) bool {
+	if md, _ := metadata.FromIncomingContext(ctx); len(md.Get(Common.AuthorizationMetadata)) > 0 {
+		return false
+	}
+	r, ok := req.(secretCodeRequest)
+	return ok && r.GetSecretCode() != ""
+}
+
+// ClientAddr returns the IP address of the caller, without the port. Calls
+// relayed by the REST gateway are attributed to its HTTP client.
+func ClientAddr(ctx context.Context) string {
+	p, ok := peer.FromContext(ctx)
+	if !ok || p.Addr == nil {
+		return "unknown"
+	}
+	if p.Addr.Network() == Common.GatewayNetwork {
+		md, _ := metadata.FromIncomingContext(ctx)
+		if values := md.Get(Common.ClientAddrMetadata); len(values) > 0 {
+			return values[0]
+		}
+	}
+	host, _, err := net.SplitHostPort(p.Addr.String())
+	if err != nil {
+		return p.Addr.String()
+	}
+	return host
+}
+
+// rateLimited returns the ResourceExhausted error telling the client to wait.
+func rateLimited(wait time.Duration) error {
+	wait = wait.Round(time.Second)
+	if wait < time.Second {
+		wait = time.Second
+	}
+	st := status.Newf(codes.ResourceExhausted, Common.ErrRateLimited, wait)
+	if detailed, err := st.WithDetails(&errdetails.RetryInfo{RetryDelay: durationpb.New(wait)}); err == nil {
+		st = detailed
+	}
+	return st.Err()
+}
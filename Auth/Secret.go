@@ -0,0 +1,98 @@
+// Auth/Secret.go
+package Auth
+
+import (
+	"complaint-portal/Common"
+	"complaint-portal/Storage"
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"strings"
+)
+
+// MinPepperLen is the shortest pepper accepted for hashing secret codes.
+const MinPepperLen = 16
+
+// secretHashPrefix marks stored values that are hashes rather than legacy plaintext codes.
+const secretHashPrefix = "hmac-sha256:"
+
+// SecretHasher turns secret codes into the keyed hashes kept in the store.
+// The hash is deterministic, so users can still be looked up by it, but it is
+// keyed with a server-side pepper so a leaked database cannot be brute-forced
+// without it.
+type SecretHasher struct {
+	pepper []byte
+}
+
+// NewSecretHasher returns a SecretHasher keyed with pepper.
+func NewSecretHasher(pepper []byte) (*SecretHasher, error) {
+	if len(pepper) < MinPepperLen {
+		return nil, fmt.Errorf("secret code pepper must be at least %d bytes", MinPepperLen)
+	}
+	return &SecretHasher{pepper: pepper}, nil
+}
+
+// Hash returns the stored form of a secret code.
+func (h *SecretHasher) Hash(secretCode string) string {
+	mac := hmac.New(sha256.New, h.pepper)
+	mac.Write([]byte(secretCode))
+	return secretHashPrefix + hex.EncodeToString(mac.Sum(nil))
+}
+
+// Verify reports, in constant time, whether secretCode hashes to secretHash.
+func (h *SecretHasher) Verify(secretCode, secretHash string) bool {
+	return hmac.Equal([]byte(h.Hash(secretCode)), []byte(secretHash))
+}
+
+// Unusable returns a hash that no secret code will match in practice: the hash
+// of a random 256-bit value. It disables a user's code without leaving the field
+// empty.
+func (h *SecretHasher) Unusable() string {
+	return h.Hash(hex.EncodeToString(RandomKey()))
+}
+
+// FindUser returns the user owning secretCode, or Storage.ErrNotFound.
+func (h *SecretHasher) FindUser(ctx context.Context, store Storage.Store, secretCode string) (Common.User, error) {
+	if secretCode == "" {
+		return Common.User{}, Storage.ErrNotFound
+	}
+	user, err := store.FindUserBySecretHash(ctx, h.Hash(secretCode))
+	if err != nil {
+		return Common.User{}, err
+	}
+	// The lookup already matched the hash; checking again in constant time keeps
+	// the comparison independent of how the backend matched it.
+	if !h.Verify(secretCode, user.SecretHash) {
+		return Common.User{}, Storage.ErrNotFound
+	}
+	return user, nil
+}
+
+// IsSecretHash reports whether a stored value is already a hash. Users created
+// before codes were hashed hold their plaintext code instead.
+func IsSecretHash(stored string) bool {
+	return strings.HasPrefix(stored, secretHashPrefix)
+}
+
+// RehashSecretCodes replaces every plaintext secret code left in the store by its
+// hash, and returns how many users were updated. Users already hashed are skipped,
+// so it is safe to run on every start.
+func RehashSecretCodes(ctx context.Context, store Storage.Store, hasher *SecretHasher) (int, error) {
+	users, err := store.ListUsers(ctx)
+	if err != nil {
+		return 0, err
+	}
+	updated := 0
+	for _, user := range users {
+		if user.SecretHash == "" || IsSecretHash(user.SecretHash) {
+			continue
+		}
+		if err := store.SetUserSecretHash(ctx, user.ID, hasher.Hash(user.SecretHash)); err != nil {
+			return updated, fmt.Errorf("rehashing user %s: %w", user.ID, err)
+		}
+		updated++
+	}
+	return updated, nil
+}
@@ -0,0 +1,198 @@
+// Auth/Session.go
+package Auth
+
+import (
+	"complaint-portal/Common"
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+)
+
+// MinSessionKeyLen is the shortest signing key accepted for session tokens.
+const MinSessionKeyLen = 32
+
+var (
+	// ErrInvalidToken is returned for tokens that are malformed or not signed with our key.
+	ErrInvalidToken = errors.New("invalid session token")
+	// ErrExpiredToken is returned for tokens past their expiry time.
+	ErrExpiredToken = errors.New("session token expired")
+	// ErrRevokedToken is returned for tokens ended by Logout or replaced by a refresh.
+	ErrRevokedToken = errors.New("session token revoked")
+	// ErrStaleToken is returned for tokens issued before the user's secret code
+	// was last rotated, reset or recovered.
+	ErrStaleToken = errors.New("session token outdated")
+)
+
+// Session is an authenticated session, as carried by a session token.
+type Session struct {
+	Token  string
+	ID     string
+	UserID string
+	Role   Common.Role
+	// Generation is the user's SessionGeneration when the session was issued.
+	Generation int
+	ExpiresAt  time.Time
+}
+
+// CheckUser returns ErrStaleToken unless the session was issued for user's
+// current session generation. user is the session's user, as now stored.
+func (s Session) CheckUser(user Common.User) error {
+	if s.Generation != user.SessionGeneration {
+		return ErrStaleToken
+	}
+	return nil
+}
+
+// Sessions issues and verifies session tokens. Tokens are JWTs signed with
+// HMAC-SHA256, carrying the user's session generation for CheckUser. Revoked
+// tokens are remembered in memory until they expire, so a logout only holds on
+// the instance that received it and until a restart.
+type Sessions struct {
+	key []byte
+	ttl time.Duration
+	now func() time.Time
+
+	mu      sync.Mutex
+	revoked map[string]time.Time // token ID -> expiry
+}
+
+// NewSessions returns a Sessions signing with key and issuing tokens valid for ttl.
+func NewSessions(key []byte, ttl time.Duration) (*Sessions, error) {
+	if len(key) < MinSessionKeyLen {
+		return nil, fmt.Errorf("session signing key must be at least %d bytes", MinSessionKeyLen)
+	}
+	if ttl <= 0 {
+		return nil, errors.New("session lifetime must be positive")
+	}
+	return &Sessions{key: key, ttl: ttl, now: time.Now, revoked: make(map[string]time.Time)}, nil
+}
+
+// RandomKey returns a fresh random key, for servers started without a configured
+// one. Anything keyed with it stops working when the process exits.
+func RandomKey() []byte {
+	key := make([]byte, MinSessionKeyLen)
+	if _, err := rand.Read(key); err != nil {
+		panic(err)
+	}
+	return key
+}
+
+// newTokenID returns a random, unguessable token ID.
+func newTokenID() string {
+	b := make([]byte, 16)
+	rand.Read(b)
+	return hex.EncodeToString(b)
+}
+
+// tokenHeader is the only JWT header we issue and accept.
+var tokenHeader = base64.RawURLEncoding.EncodeToString([]byte(`{"alg":"HS256","typ":"JWT"}`))
+
+// claims is the payload of a session token.
+type claims struct {
+	Subject  string      `json:"sub"`
+	Role     Common.Role `json:"role"`
+	Gen      int         `json:"gen"`
+	ID       string      `json:"jti"`
+	IssuedAt int64       `json:"iat"`
+	Expires  int64       `json:"exp"`
+}
+
+// Issue returns a new session for the user.
+func (s *Sessions) Issue(user Common.User) (Session, error) {
+	now := s.now()
+	c := claims{
+		Subject:  user.ID,
+		Role:     user.EffectiveRole(),
+		Gen:      user.SessionGeneration,
+		ID:       newTokenID(),
+		IssuedAt: now.Unix(),
+		Expires:  now.Add(s.ttl).Unix(),
+	}
+	payload, err := json.Marshal(c)
+	if err != nil {
+		return Session{}, err
+	}
+	unsigned := tokenHeader + "." + base64.RawURLEncoding.EncodeToString(payload)
+	return Session{
+		Token:      unsigned + "." + s.sign(unsigned),
+		ID:         c.ID,
+		UserID:     c.Subject,
+		Role:       c.Role,
+		Generation: c.Gen,
+		ExpiresAt:  time.Unix(c.Expires, 0).UTC(),
+	}, nil
+}
+
+// Verify checks a token's signature, expiry and revocation and returns its session.
+func (s *Sessions) Verify(token string) (Session, error) {
+	parts := strings.Split(token, ".")
+	if len(parts) != 3 || parts[0] != tokenHeader {
+		return Session{}, ErrInvalidToken
+	}
+	if !hmac.Equal([]byte(parts[2]), []byte(s.sign(parts[0]+"."+parts[1]))) {
+		return Session{}, ErrInvalidToken
+	}
+	payload, err := base64.RawURLEncoding.DecodeString(parts[1])
+	if err != nil {
+		return Session{}, ErrInvalidToken
+	}
+	var c claims
+	if err := json.Unmarshal(payload, &c); err != nil || c.Subject == "" || c.ID == "" {
+		return Session{}, ErrInvalidToken
+	}
+
+	expiresAt := time.Unix(c.Expires, 0).UTC()
+	if !s.now().Before(expiresAt) {
+		return Session{}, ErrExpiredToken
+	}
+
+	s.mu.Lock()
+	_, revoked := s.revoked[c.ID]
+	s.mu.Unlock()
+	if revoked {
+		return Session{}, ErrRevokedToken
+	}
+
+	return Session{Token: token, ID: c.ID, UserID: c.Subject, Role: c.Role, Generation: c.Gen, ExpiresAt: expiresAt}, nil
+}
+
+// Refresh issues a new session for user and revokes the old one. The user is
+// the session's, as now stored, so that the new token carries the current role.
+func (s *Sessions) Refresh(old Session, user Common.User) (Session, error) {
+	next, err := s.Issue(user)
+	if err != nil {
+		return Session{}, err
+	}
+	s.Revoke(old)
+	return next, nil
+}
+
+// Revoke ends a session before its expiry.
+func (s *Sessions) Revoke(session Session) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	// Expired tokens are rejected anyway, so there is no need to remember them.
+	now := s.now()
+	for id, exp := range s.revoked {
+		if !now.Before(exp) {
+			delete(s.revoked, id)
+		}
+	}
+	s.revoked[session.ID] = session.ExpiresAt
+}
+
+// sign returns the encoded HMAC-SHA256 signature of the token's header and payload.
+func (s *Sessions) sign(unsigned string) string {
+	mac := hmac.New(sha256.New, s.key)
+	mac.Write([]byte(unsigned))
+	return base64.RawURLEncoding.EncodeToString(mac.Sum(nil))
+}
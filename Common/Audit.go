@@ -0,0 +1,50 @@
+package Common
+
+import "time"
+
+// AuditEntry records a change made through the API: who changed what, from
+// where and when, and the fields that changed.
+type AuditEntry struct {
+	ID string
+	At time.Time
+	// ActorID is the user who made the call. Self-service calls made without
+	// credentials, such as Register, are attributed to their target.
+	ActorID string
+	Action  string
+	// TargetID is the user, complaint or comment the call changed.
+	TargetID string
+	// Method is the full gRPC method name of the call.
+	Method string
+	// ClientAddr is the IP address the call came from.
+	ClientAddr string
+	// Changes lists the fields of the target that the call changed. Objects
+	// created by the call have every field changed from "".
+	Changes []AuditChange
+}
+
+// AuditChange is one field of an audited target, before and after a call.
+// Secrets are recorded as RedactedValue, so only the fact that they changed is kept.
+type AuditChange struct {
+	Field  string
+	Before string
+	After  string
+}
+
+// RedactedValue stands in for a secret in an AuditChange.
+const RedactedValue = "[redacted]"
+
+// Actions recorded in the audit log.
+const (
+	AuditUserRegistered     = "user.registered"
+	AuditStaffCreated       = "user.staff_created"
+	AuditComplaintSubmitted = "complaint.submitted"
+	AuditComplaintResolved  = "complaint.resolved"
+	AuditComplaintStatus    = "complaint.status_changed"
+	AuditCommentAdded       = "comment.added"
+	AuditSecretRotated      = "secret_code.rotated"
+	AuditSecretReset        = "secret_code.reset"
+	AuditSecretRecovered    = "secret_code.recovered"
+	AuditWebhookCreated     = "webhook.created"
+	AuditWebhookDeleted     = "webhook.deleted"
+	AuditWebhookRedelivered = "webhook.redelivered"
+)
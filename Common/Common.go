@@ -3,15 +3,55 @@ package Common
 import (
 	"crypto/rand"
 	"encoding/hex"
+	"time"
 )
 
-
 type User struct {
-	ID         string
-	SecretCode string
+	ID string
+	// SecretHash is the keyed hash of the user's secret code; the code itself is
+	// never stored. The document field keeps its old name so existing documents
+	// can be rehashed in place.
+	SecretHash string `firestore:"SecretCode"`
 	Name       string
 	Email      string
 	Complaints []string
+	Role       Role
+	// RecoveryHash is the hash of the one-time recovery code issued by an admin
+	// reset, valid until RecoveryExpiresAt. Both are empty when none is pending.
+	RecoveryHash      string
+	RecoveryExpiresAt time.Time
+	// SessionGeneration is carried by the user's session tokens. Rotating,
+	// resetting or recovering the secret code bumps it, which ends every session
+	// issued before.
+	SessionGeneration int
+	// CreatedAt and UpdatedAt are set by the server when the account is created
+	// and whenever it is changed through the API.
+	CreatedAt time.Time
+	UpdatedAt time.Time
+}
+
+// Role decides which RPCs a user may call.
+type Role string
+
+const (
+	RoleCustomer Role = "customer"
+	RoleAgent    Role = "agent"
+	RoleAdmin    Role = "admin"
+)
+
+// EffectiveRole returns the user's role. Users stored before roles existed
+// have none and are customers.
+func (u User) EffectiveRole() Role {
+	if u.Role == "" {
+		return RoleCustomer
+	}
+	return u.Role
+}
+
+// IsStaff reports whether the user works on complaints rather than submitting them.
+func (u User) IsStaff() bool {
+	role := u.EffectiveRole()
+	return role == RoleAgent || role == RoleAdmin
 }
 
 type Complaint struct {
@@ -19,10 +59,37 @@ type Complaint struct {
 	Title    string
 	Summary  string
 	Severity int
-	Resolved bool
-	UserID   string
+	// Resolved is true once the complaint is Resolved or Closed. It predates Status
+	// and is kept in sync with it for older documents and clients.
+	Resolved      bool
+	UserID        string
+	Status        ComplaintStatus
+	StatusHistory []StatusChange
+	// CreatedAt and UpdatedAt are set by the server when the complaint is
+	// submitted and whenever it changes. ResolvedAt is when it was last resolved
+	// or closed, and is zero while Resolved is false.
+	CreatedAt  time.Time
+	UpdatedAt  time.Time
+	ResolvedAt time.Time
 }
 
+// CommentRole tells whether a comment was written by the complaint's owner or by staff.
+type CommentRole string
+
+const (
+	CommentByCustomer CommentRole = "customer"
+	CommentByStaff    CommentRole = "staff"
+)
+
+// Comment is one message in the conversation thread of a complaint.
+type Comment struct {
+	ID          string
+	ComplaintID string
+	AuthorID    string
+	Role        CommentRole
+	Body        string
+	CreatedAt   time.Time
+}
 
 func GenerateID() string {
 	b := make([]byte, 4)
@@ -3,6 +3,7 @@ package Common
 
 import (
 	"testing"
+	"time"
 )
 
 // TestGenerateID ensures the GenerateID function works as expected.
@@ -37,4 +38,83 @@ func TestGenerateSecretCode(t *testing.T) {
 	if len(secret) != expectedLength {
 		t.Errorf("Expected secret code to have length %d, but got %d", expectedLength, len(secret))
 	}
-}
\ No newline at end of file
+}
+
+// TestCanTransition checks a few allowed and forbidden status changes.
+func TestCanTransition(t *testing.T) {
+	cases := []struct {
+		from, to ComplaintStatus
+		want     bool
+	}{
+		{StatusOpen, StatusAcknowledged, true},
+		{StatusAwaitingCustomer, StatusInProgress, true},
+		{StatusResolved, StatusReopened, true},
+		{StatusOpen, StatusReopened, false},
+		{StatusClosed, StatusReopened, false},
+		{StatusResolved, StatusInProgress, false},
+	}
+	for _, c := range cases {
+		if got := CanTransition(c.from, c.to); got != c.want {
+			t.Errorf("Expected CanTransition(%s, %s) to be %v, but got %v", c.from, c.to, c.want, got)
+		}
+	}
+
+	// Customers may reopen a resolved complaint, answer a question and close,
+	// but not resolve or start work on a complaint themselves.
+	customerCases := []struct {
+		from, to ComplaintStatus
+		want     bool
+	}{
+		{StatusResolved, StatusReopened, true},
+		{StatusAwaitingCustomer, StatusInProgress, true},
+		{StatusOpen, StatusClosed, true},
+		{StatusInProgress, StatusResolved, false},
+		{StatusOpen, StatusInProgress, false},
+		{StatusAcknowledged, StatusInProgress, false},
+		{StatusReopened, StatusInProgress, false},
+		{StatusOpen, StatusReopened, false},
+	}
+	for _, c := range customerCases {
+		if got := CustomerCanTransition(c.from, c.to); got != c.want {
+			t.Errorf("Expected CustomerCanTransition(%s, %s) to be %v, but got %v", c.from, c.to, c.want, got)
+		}
+	}
+}
+
+// TestCurrentStatus ensures documents that only carry the Resolved flag are read correctly.
+func TestCurrentStatus(t *testing.T) {
+	if got := (Complaint{Resolved: true}).CurrentStatus(); got != StatusResolved {
+		t.Errorf("Expected a legacy resolved complaint to be %s, but got %s", StatusResolved, got)
+	}
+	if got := (Complaint{}).CurrentStatus(); got != StatusOpen {
+		t.Errorf("Expected a legacy unresolved complaint to be %s, but got %s", StatusOpen, got)
+	}
+
+	c := Complaint{Resolved: true}
+	c.SetStatus(StatusReopened, "u1", "", time.Now())
+	if c.Resolved || c.StatusHistory[0].From != StatusResolved {
+		t.Errorf("Expected reopening to clear Resolved and record the legacy status, but got %+v", c)
+	}
+}
+
+// TestSetStatusTimestamps checks that ResolvedAt follows the Resolved flag.
+func TestSetStatusTimestamps(t *testing.T) {
+	at := time.Date(2024, 5, 1, 10, 0, 0, 0, time.UTC)
+	var c Complaint
+	c.SetStatus(StatusOpen, "u1", "", at)
+	if !c.UpdatedAt.Equal(at) || !c.ResolvedAt.IsZero() {
+		t.Errorf("Expected an open complaint updated at %v and not resolved, but got %+v", at, c)
+	}
+
+	c.SetStatus(StatusResolved, "a1", "", at.Add(time.Hour))
+	c.SetStatus(StatusClosed, "u1", "", at.Add(2*time.Hour))
+	if !c.ResolvedAt.Equal(at.Add(time.Hour)) || !c.UpdatedAt.Equal(at.Add(2*time.Hour)) {
+		t.Errorf("Expected closing to keep the time it was resolved, but got %+v", c)
+	}
+
+	c = Complaint{Status: StatusResolved, Resolved: true, ResolvedAt: at}
+	c.SetStatus(StatusReopened, "u1", "", at.Add(time.Hour))
+	if !c.ResolvedAt.IsZero() {
+		t.Errorf("Expected reopening to clear ResolvedAt, but got %v", c.ResolvedAt)
+	}
+}
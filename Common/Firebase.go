@@ -4,7 +4,8 @@ package Common
 import (
 	"context"
 	"encoding/json"
-	"log"
+	"fmt"
+	"log/slog"
 	"os" // Use os package to read the file
 
 	"cloud.google.com/go/firestore"
@@ -16,44 +17,82 @@ import (
 // It is capitalized to be exported.
 var FirestoreClient *firestore.Client
 
+// FirebaseOptions select the Firestore project InitFirebase connects to.
+type FirebaseOptions struct {
+	// CredentialsFile is the service account key file. It is not read when
+	// EmulatorHost is set.
+	CredentialsFile string
+	// ProjectID is the Firestore project. If empty, it is read from the
+	// credentials file, or is DefaultEmulatorProject with the emulator.
+	ProjectID string
+	// EmulatorHost is the host:port of a Firestore emulator to use instead of
+	// Google Cloud.
+	EmulatorHost string
+}
+
 // InitFirebase initializes the Firebase app and the Firestore client.
 // It is capitalized to be exported.
-func InitFirebase() {
+func InitFirebase(opts FirebaseOptions) {
 	ctx := context.Background()
 
-	// Read the credentials file to get the project ID
-	credBytes, err := os.ReadFile("credentials.json")
-	if err != nil {
-		log.Fatalf("Error reading credentials.json file: %v\nMake sure the file is in the project root directory.", err)
+	// The emulator needs neither credentials nor a real project; the Firestore
+	// client connects to it when its environment variable is set.
+	if opts.EmulatorHost != "" {
+		if opts.ProjectID == "" {
+			opts.ProjectID = DefaultEmulatorProject
+		}
+		os.Setenv(EnvFirestoreEmulatorHost, opts.EmulatorHost)
+		client, err := firestore.NewClient(ctx, opts.ProjectID)
+		if err != nil {
+			fatal("error getting Firestore client", err)
+		}
+		FirestoreClient = client
+		slog.Info("Connected to the Firestore emulator", LogKeyAddr, opts.EmulatorHost)
+		return
 	}
 
-	// A simple struct to unmarshal just the project_id from the credentials
-	var creds struct {
-		ProjectID string `json:"project_id"`
-	}
-	if err := json.Unmarshal(credBytes, &creds); err != nil {
-		log.Fatalf("Error unmarshalling credentials: %v\n", err)
-	}
+	if opts.ProjectID == "" {
+		// Read the credentials file to get the project ID
+		credBytes, err := os.ReadFile(opts.CredentialsFile)
+		if err != nil {
+			fatal("Error reading the credentials file: make sure it exists or set its path", err)
+		}
+
+		// A simple struct to unmarshal just the project_id from the credentials
+		var creds struct {
+			ProjectID string `json:"project_id"`
+		}
+		if err := json.Unmarshal(credBytes, &creds); err != nil {
+			fatal("Error unmarshalling credentials", err)
+		}
 
-	if creds.ProjectID == "" {
-		log.Fatalf("ProjectID not found in credentials.json. The credentials file seems to be invalid.")
+		if creds.ProjectID == "" {
+			fatal("ProjectID not found. The credentials file seems to be invalid.", fmt.Errorf("%s has no project_id", opts.CredentialsFile))
+		}
+		opts.ProjectID = creds.ProjectID
 	}
 
 	// Now, initialize the app with the explicit ProjectID
 	config := &firebase.Config{
-		ProjectID: creds.ProjectID,
+		ProjectID: opts.ProjectID,
 	}
-	sa := option.WithCredentialsFile("credentials.json")
+	sa := option.WithCredentialsFile(opts.CredentialsFile)
 	app, err := firebase.NewApp(ctx, config, sa)
 	if err != nil {
-		log.Fatalf("error initializing app: %v\n", err)
+		fatal("error initializing app", err)
 	}
 
 	client, err := app.Firestore(ctx)
 	if err != nil {
-		log.Fatalf("error getting Firestore client: %v\n", err)
+		fatal("error getting Firestore client", err)
 	}
 
 	FirestoreClient = client
-	log.Println("Successfully connected to Firebase Firestore.")
+	slog.Info("Successfully connected to Firebase Firestore.")
+}
+
+// fatal logs msg with err as an error and exits.
+func fatal(msg string, err error) {
+	slog.Error(msg, LogKeyError, err)
+	os.Exit(1)
 }
@@ -0,0 +1,104 @@
+package Common
+
+import (
+	"slices"
+	"time"
+)
+
+// ComplaintStatus is the stage a complaint is at in its lifecycle.
+type ComplaintStatus string
+
+const (
+	StatusOpen             ComplaintStatus = "Open"
+	StatusAcknowledged     ComplaintStatus = "Acknowledged"
+	StatusInProgress       ComplaintStatus = "InProgress"
+	StatusAwaitingCustomer ComplaintStatus = "AwaitingCustomer"
+	StatusResolved         ComplaintStatus = "Resolved"
+	StatusReopened         ComplaintStatus = "Reopened"
+	StatusClosed           ComplaintStatus = "Closed"
+)
+
+// StatusChange is one entry of a complaint's status history.
+type StatusChange struct {
+	From      ComplaintStatus
+	To        ComplaintStatus
+	ChangedBy string
+	ChangedAt time.Time
+	Note      string
+}
+
+// transitions lists, for every status, the statuses a complaint may move to next.
+// Closed is final.
+var transitions = map[ComplaintStatus][]ComplaintStatus{
+	StatusOpen:             {StatusAcknowledged, StatusInProgress, StatusAwaitingCustomer, StatusResolved, StatusClosed},
+	StatusAcknowledged:     {StatusInProgress, StatusAwaitingCustomer, StatusResolved, StatusClosed},
+	StatusInProgress:       {StatusAwaitingCustomer, StatusResolved, StatusClosed},
+	StatusAwaitingCustomer: {StatusInProgress, StatusResolved, StatusClosed},
+	StatusResolved:         {StatusReopened, StatusClosed},
+	StatusReopened:         {StatusAcknowledged, StatusInProgress, StatusAwaitingCustomer, StatusResolved, StatusClosed},
+	StatusClosed:           {},
+}
+
+// customerTransitions lists, for every status, the only moves a complaint's
+// owner may make themselves: answering a question, reopening a resolved
+// complaint, or closing it.
+var customerTransitions = map[ComplaintStatus][]ComplaintStatus{
+	StatusOpen:             {StatusClosed},
+	StatusAcknowledged:     {StatusClosed},
+	StatusInProgress:       {StatusClosed},
+	StatusAwaitingCustomer: {StatusInProgress, StatusClosed},
+	StatusResolved:         {StatusReopened, StatusClosed},
+	StatusReopened:         {StatusClosed},
+	StatusClosed:           {},
+}
+
+// IsValid reports whether s is one of the known statuses.
+func (s ComplaintStatus) IsValid() bool {
+	_, ok := transitions[s]
+	return ok
+}
+
+// CanTransition reports whether a complaint may move from one status to another.
+func CanTransition(from, to ComplaintStatus) bool {
+	return slices.Contains(transitions[from], to)
+}
+
+// CustomerCanTransition reports whether a complaint's owner may make the move
+// themselves. Every other valid move is reserved for staff.
+func CustomerCanTransition(from, to ComplaintStatus) bool {
+	return slices.Contains(customerTransitions[from], to) && CanTransition(from, to)
+}
+
+// CurrentStatus returns the complaint's status. Documents written before the
+// status lifecycle existed only carry the Resolved flag, so it is used as a fallback.
+func (c Complaint) CurrentStatus() ComplaintStatus {
+	if c.Status != "" {
+		return c.Status
+	}
+	if c.Resolved {
+		return StatusResolved
+	}
+	return StatusOpen
+}
+
+// SetStatus moves the complaint to a new status and records the change in its history.
+// Resolved is kept in sync for clients and queries that only know the old flag, and
+// ResolvedAt with it: closing a resolved complaint keeps the time it was resolved.
+// The caller is responsible for checking that the transition is allowed.
+func (c *Complaint) SetStatus(to ComplaintStatus, changedBy, note string, at time.Time) {
+	c.StatusHistory = append(c.StatusHistory, StatusChange{
+		From:      c.CurrentStatus(),
+		To:        to,
+		ChangedBy: changedBy,
+		ChangedAt: at,
+		Note:      note,
+	})
+	c.Status = to
+	c.Resolved = to == StatusResolved || to == StatusClosed
+	c.UpdatedAt = at
+	if !c.Resolved {
+		c.ResolvedAt = time.Time{}
+	} else if c.ResolvedAt.IsZero() {
+		c.ResolvedAt = at
+	}
+}
@@ -1,16 +1,40 @@
 package Common
 
+import "time"
+
 const (
-	LogStartingServer      = "Starting gRPC server on port "
-	LogFailedToListen      = "failed to listen: %v"
-	LogFailedToServe       = "failed to serve: %v"
-	LogReceivedRegister    = "Received Register request for Name: %v"
-	LogReceivedLogin       = "Received Login request with secret code"
-	LogReceivedSubmit      = "Received SubmitComplaint request"
-	LogReceivedGetUser     = "Received GetUserComplaints request"
-	LogReceivedGetAdmin    = "Received GetAdminComplaints request"
-	LogReceivedView        = "Received ViewComplaint request"
-	LogReceivedResolve     = "Received ResolveComplaint request"
+	LogStartingServer        = "Starting gRPC server"
+	LogFailedToListen        = "failed to listen"
+	LogFailedToServe         = "failed to serve"
+	LogReceivedRegister      = "Received Register request"
+	LogReceivedLogin         = "Received Login request with secret code"
+	LogReceivedSubmit        = "Received SubmitComplaint request"
+	LogReceivedGetUser       = "Received GetUserComplaints request"
+	LogReceivedGetAdmin      = "Received GetAdminComplaints request"
+	LogReceivedView          = "Received ViewComplaint request"
+	LogReceivedResolve       = "Received ResolveComplaint request"
+	LogReceivedTransition    = "Received TransitionComplaint request"
+	LogReceivedAddComment    = "Received AddComment request"
+	LogReceivedListComments  = "Received ListComments request"
+	LogReceivedCreateStaff   = "Received CreateStaffUser request"
+	LogReceivedLogout        = "Received Logout request"
+	LogReceivedRefresh       = "Received RefreshSession request"
+	LogReceivedRotate        = "Received RotateSecretCode request"
+	LogReceivedReset         = "Received ResetSecretCode request"
+	LogReceivedRedeem        = "Received RedeemRecoveryCode request"
+	LogReceivedQueryAudit    = "Received QueryAuditLog request"
+	LogFailedToAudit         = "failed to write audit entry"
+	LogFailedToSnapshot      = "failed to read the target of an audit entry"
+	LogOrphanComplaint       = "Complaint belongs to a user who does not exist"
+	LogReceivedSearch        = "Received SearchComplaints request"
+	LogReceivedWatch         = "Received WatchComplaints request"
+	LogReceivedStats         = "Received GetComplaintStats request"
+	LogReceivedExport        = "Received ExportComplaints request"
+	LogReceivedCreateWebhook = "Received CreateWebhook request"
+	LogReceivedListWebhooks  = "Received ListWebhooks request"
+	LogReceivedDeleteWebhook = "Received DeleteWebhook request"
+	LogReceivedDeliveries    = "Received ListWebhookDeliveries request"
+	LogReceivedRedeliver     = "Received RedeliverWebhook request"
 )
 
 const (
@@ -20,13 +44,199 @@ const (
 	ErrUnauthorized         = "Unauthorized: Invalid secret code"
 	ErrComplaintNotFound    = "Complaint not found"
 	ErrComplaintAccess      = "Complaint not found or you are not the owner"
+	ErrInvalidStatus        = "Unknown complaint status"
+	ErrInvalidTransition    = "Cannot change complaint status from %s to %s"
+	ErrTransitionForbidden  = "Only staff can change complaint status from %s to %s"
+	ErrCommentBodyRequired  = "Comment body is required"
+	ErrMissingCredentials   = "Unauthenticated: missing secret code"
+	ErrPermissionDenied     = "Permission denied for this method"
+	ErrInvalidStaffRole     = "Role must be agent or admin"
+	ErrInvalidSession       = "Unauthenticated: invalid or expired session token"
+	ErrSessionRequired      = "This method requires a session token"
+	ErrUserNotFound         = "User not found"
+	ErrInvalidRecoveryCode  = "Invalid or expired recovery code"
+	ErrRateLimited          = "Too many attempts, retry in %s"
+	ErrInvalidTimeRange     = "until must be after since"
+	ErrInvalidSort          = "Unknown sort order"
+	ErrInvalidPageSize      = "page_size must not be negative"
+	ErrInvalidPageToken     = "Invalid page token"
+	ErrInvalidSeverityRange = "min_severity must not be above max_severity"
+	ErrSearchQueryRequired  = "Search query is required"
+	ErrInvalidSearchLimit   = "limit must not be negative"
+	ErrInvalidResumeToken   = "Invalid resume token"
+	ErrResumeExpired        = "Resume token expired: reload the complaints and watch again without it"
+	ErrWatchLagging         = "Watcher fell behind: resume from the last event received"
+	ErrShuttingDown         = "Server is shutting down: reconnect to watch again"
+	ErrInvalidBucketing     = "Unknown bucketing"
+	ErrInvalidTopSubmitters = "top_submitters must not be negative"
+	ErrTooManyBuckets       = "The time window spans more than %d buckets"
+	ErrInvalidExportFormat  = "Unknown export format"
+	ErrWebhookNotFound      = "Webhook not found"
+	ErrDeliveryNotFound     = "Webhook delivery not found"
+	ErrInvalidDeliveryLimit = "limit must not be negative"
+	ErrInvalidDeliveryState = "Unknown delivery status"
+	ErrWebhooksDisabled     = "Webhook deliveries are not enabled on this server"
 )
 
 const (
 	MsgComplaintResolved = "Complaint marked as resolved"
 )
 
+const (
+	// DefaultPageSize and MaxPageSize bound the pages of complaint listings.
+	DefaultPageSize = 50
+	MaxPageSize     = 500
+
+	// DefaultSearchLimit and MaxSearchLimit bound the results of a search.
+	DefaultSearchLimit = 20
+	MaxSearchLimit     = 100
+
+	// DefaultStatsWindow is how far back complaint statistics go by default.
+	DefaultStatsWindow = 30 * 24 * time.Hour
+	// DefaultTopSubmitters and MaxTopSubmitters bound the top submitters listed.
+	DefaultTopSubmitters = 5
+	MaxTopSubmitters     = 100
+
+	// ExportChunkSize is the size of the chunks ExportComplaints streams.
+	ExportChunkSize = 64 * 1024
+
+	// DefaultDeliveryLimit and MaxDeliveryLimit bound the webhook deliveries listed.
+	DefaultDeliveryLimit = 50
+	MaxDeliveryLimit     = 500
+)
+
 const (
 	GRPC_Port = ":50051"
 	TCP       = "tcp"
+	// HTTP_Port serves the REST gateway.
+	HTTP_Port = ":8080"
+	// GatewayNetwork is the network of the in-process connections from the REST
+	// gateway to the gRPC server. Calls on them carry the HTTP client's address
+	// in the ClientAddrMetadata key.
+	GatewayNetwork     = "gateway"
+	ClientAddrMetadata = "x-gateway-client-addr"
+	// SecretCodeHeader is the HTTP header REST clients may send their secret
+	// code in instead of the request body or query.
+	SecretCodeHeader        = "X-Secret-Code"
+	LogStartingGateway      = "Serving the REST gateway"
+	DefaultShutdownTimeout  = 30 * time.Second
+	LogStoreHealthy         = "Storage is reachable: serving"
+	LogStoreUnhealthy       = "Storage is unreachable: not serving"
+	LogReflectionEnabled    = "Serving gRPC reflection"
+	LogShuttingDown         = "Shutting down, waiting for in-flight requests"
+	LogShutdownTimedOut     = "Requests still running after the shutdown timeout were cancelled"
+	LogFailedToDrain        = "background jobs were still pending at shutdown"
+	LogFailedToCloseStore   = "failed to close storage"
+	LogStopped              = "Server stopped"
+	LogFailedToServeGateway = "REST gateway stopped"
+	ErrInvalidJSON          = "Invalid JSON body: %v"
+	ErrInvalidParameter     = "Invalid %s parameter: %v"
+	ErrSecretCodeInURL      = "Send the secret code in the %s header, not the URL"
+)
+
+const (
+	UsersCollection      = "users"
+	ComplaintsCollection = "complaints"
+	// CommentsCollection is a subcollection of each complaint document.
+	CommentsCollection = "comments"
+	AuditCollection    = "audit_log"
+	WebhooksCollection = "webhooks"
+	// DeliveriesCollection holds the deliveries of every webhook, which outlive it.
+	DeliveriesCollection = "webhook_deliveries"
+)
+
+const (
+	EnvStorageBackend      = "STORAGE_BACKEND"
+	DefaultSQLitePath      = "complaints.db"
+	DefaultCredentialsFile = "credentials.json"
+	// EnvFirestoreEmulatorHost is read by the Firestore client itself.
+	EnvFirestoreEmulatorHost = "FIRESTORE_EMULATOR_HOST"
+	DefaultEmulatorProject   = "demo-complaint-portal"
+	LogUsingStorage          = "Using storage backend"
+	LogFailedToOpenStore     = "failed to open storage"
+	LogFailedToBackfill      = "failed to backfill timestamps"
+	LogBackfilledTimestamps  = "Backfilled document timestamps"
+	LogFailedToFillStatuses  = "failed to backfill complaint statuses"
+	LogBackfilledStatuses    = "Backfilled complaint statuses"
+	LogFailedToIndex         = "failed to build the search index"
+	LogIndexedComplaints     = "Indexed complaints for search"
+	LogWatchingFirestore     = "Watching Firestore for complaint changes"
+	LogFirestoreWatchFailed  = "Firestore complaint listener stopped"
+)
+
+const (
+	// AuthorizationMetadata is the gRPC metadata key carrying "Bearer <session token>".
+	AuthorizationMetadata  = "authorization"
+	BearerPrefix           = "Bearer "
+	DefaultSessionTTL      = time.Hour
+	RecoveryCodeTTL        = 24 * time.Hour
+	LogEphemeralSession    = "No session signing key set: using a random key, sessions will not survive a restart"
+	LogInvalidAuthSettings = "invalid authentication settings"
+	LogFailedToRehash      = "failed to rehash secret codes"
+	LogRehashedSecrets     = "Rehashed secret codes"
+)
+
+const (
+	LogNotifying           = "Emailing complaint notifications"
+	LogFailedToLoadNotify  = "failed to load notification templates"
+	LogNotificationDropped = "Notification queue full: dropped notification"
+	LogNotificationFailed  = "failed to prepare notification"
+	LogNotificationGaveUp  = "failed to send notification, giving up"
+)
+
+const (
+	LogResumedDeliveries     = "Resumed pending webhook deliveries"
+	LogFailedToResume        = "failed to resume pending webhook deliveries"
+	LogWebhookEventsLost     = "Webhook listener fell too far behind, events were not delivered"
+	LogWebhookDispatchFailed = "failed to dispatch webhooks"
+	LogWebhookAttemptFailed  = "failed to attempt webhook delivery"
+)
+
+const (
+	// EnvConfigFile names the configuration file when the -config flag does not.
+	EnvConfigFile      = "CONFIG_FILE"
+	RedactedSecret     = "<redacted>"
+	LogInvalidConfig   = "invalid configuration"
+	ErrReadConfig      = "reading %s: %w"
+	ErrInvalidSetting  = "%s: %v"
+	ErrSettingRequired = "%s must be set"
+	ErrSettingPositive = "%s must be positive"
+	ErrSettingAbove    = "%s must not be above %s"
+	ErrInvalidAddress  = "%s: invalid address %q: %v"
+	ErrUnknownBackend  = "%s: unknown storage backend %q"
+	ErrPepperRequired  = "%s must be set unless the storage backend is memory: stored secret codes are hashed with it"
+)
+
+const (
+	// RequestIDMetadata is the gRPC metadata key, and HTTP header, carrying the
+	// ID that correlates the log records of a request.
+	RequestIDMetadata   = "x-request-id"
+	RedactedEmail       = "***"
+	LogFinishedCall     = "Finished call"
+	LogFailedToLog      = "failed to set up logging"
+	ErrUnknownLogFormat = "unknown log format %q"
+)
+
+// Keys of the attributes of log records.
+const (
+	LogKeyRequestID  = "request_id"
+	LogKeyUserID     = "user_id"
+	LogKeyMethod     = "method"
+	LogKeyCode       = "code"
+	LogKeyLatency    = "latency_ms"
+	LogKeyError      = "error"
+	LogKeyAddr       = "addr"
+	LogKeyBackend    = "backend"
+	LogKeyCount      = "count"
+	LogKeyComplaint  = "complaint_id"
+	LogKeyOwner      = "owner_id"
+	LogKeyDelivery   = "delivery_id"
+	LogKeyKind       = "kind"
+	LogKeyAction     = "action"
+	LogKeyTarget     = "target_id"
+	LogKeyAttempts   = "attempts"
+	LogKeySignal     = "signal"
+	LogKeyTimeout    = "timeout"
+	LogKeyRole       = "role"
+	LogKeyTargetUser = "target_user_id"
 )
@@ -0,0 +1,44 @@
+package Common
+
+import "time"
+
+// Webhook is an endpoint registered by an admin to receive complaint events.
+type Webhook struct {
+	ID  string
+	URL string
+	// Events are the event types delivered to the endpoint, e.g. "complaint.created".
+	Events []string
+	// Secret is the key signing the deliveries. Only the admin creating the
+	// webhook is shown it.
+	Secret    string
+	CreatedBy string
+	CreatedAt time.Time
+}
+
+// DeliveryStatus is the state of a webhook delivery.
+type DeliveryStatus string
+
+const (
+	// DeliveryPending deliveries have not succeeded yet and will be tried again.
+	DeliveryPending   DeliveryStatus = "pending"
+	DeliverySucceeded DeliveryStatus = "succeeded"
+	// DeliveryFailed deliveries were given up on after their last attempt.
+	DeliveryFailed DeliveryStatus = "failed"
+)
+
+// WebhookDelivery is an event sent, or to be sent, to a webhook.
+type WebhookDelivery struct {
+	ID        string
+	WebhookID string
+	Event     string
+	// Payload is the JSON body posted to the webhook.
+	Payload  string
+	Status   DeliveryStatus
+	Attempts int
+	// ResponseCode is the HTTP status returned to the last attempt, or 0 if
+	// there was no response; Error tells why the last attempt failed.
+	ResponseCode  int
+	Error         string
+	CreatedAt     time.Time
+	LastAttemptAt time.Time
+}
@@ -6,4 +6,14 @@ var Users = make(map[string]User)
 
 var Complaints = make(map[string]Complaint)
 
+// Comments holds the comment thread of each complaint, keyed by complaint ID.
+var Comments = make(map[string][]Comment)
+
+// AuditLog holds the audit entries, oldest first.
+var AuditLog []AuditEntry
+
+var Webhooks = make(map[string]Webhook)
+
+var WebhookDeliveries = make(map[string]WebhookDelivery)
+
 var Mu sync.Mutex
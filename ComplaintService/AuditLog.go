@@ -0,0 +1,45 @@
+// ComplaintService/AuditLog.go
+package ComplaintService
+
+import (
+	"complaint-portal/Common"
+	pb "complaint-portal/Generated/ComplaintService"
+	"complaint-portal/Storage"
+	"context"
+	"log/slog"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// QueryAuditLog implements the QueryAuditLog RPC method.
+// It returns the audit entries matching every filter set in the request, oldest
+// first. The interceptor only lets admins call it.
+func (s *Server) QueryAuditLog(ctx context.Context, req *pb.QueryAuditLogRequest) (*pb.QueryAuditLogResponse, error) {
+	slog.DebugContext(ctx, Common.LogReceivedQueryAudit)
+
+	if _, err := s.authenticate(ctx, req.GetSecretCode()); err != nil {
+		return nil, err
+	}
+
+	filter := Storage.AuditFilter{ActorID: req.GetActorId(), TargetID: req.GetTargetId()}
+	if req.GetSince() != nil {
+		filter.Since = req.GetSince().AsTime()
+	}
+	if req.GetUntil() != nil {
+		filter.Until = req.GetUntil().AsTime()
+	}
+	if !filter.Since.IsZero() && !filter.Until.IsZero() && !filter.Until.After(filter.Since) {
+		return nil, status.Errorf(codes.InvalidArgument, Common.ErrInvalidTimeRange)
+	}
+
+	entries, err := s.Store.ListAuditEntries(ctx, filter)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "Failed to query audit log: %v", err)
+	}
+	res := &pb.QueryAuditLogResponse{}
+	for _, entry := range entries {
+		res.Entries = append(res.Entries, toPBAuditEntry(entry))
+	}
+	return res, nil
+}
@@ -2,13 +2,22 @@
 package ComplaintService
 
 import (
+	"complaint-portal/Auth"
 	"complaint-portal/Common"
+	"complaint-portal/Events"
 	pb "complaint-portal/Generated/ComplaintService"
+	"complaint-portal/Logging"
+	"complaint-portal/Notify"
+	"complaint-portal/Search"
+	"complaint-portal/Storage"
+	"complaint-portal/Webhook"
 	"context"
-	"log"
+	"errors"
+	"log/slog"
+	"strings"
+	"sync"
+	"time"
 
-	"cloud.google.com/go/firestore"
-	"google.golang.org/api/iterator"
 	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/status"
 )
@@ -16,265 +25,508 @@ import (
 // Server is used to implement the ComplaintServiceServer interface.
 type Server struct {
 	pb.UnimplementedComplaintServiceServer
+	Store    Storage.Store
+	Hasher   *Auth.SecretHasher
+	Sessions *Auth.Sessions
+	// Index is the search index, kept up to date with the complaints written
+	// through Store. It starts empty; see Search.Index.Build.
+	Index *Search.Index
+	// Events receives an event for every complaint written through Store.
+	Events *Events.Bus
+	// Notifier emails users about their complaints. It is nil unless
+	// EnableNotifications was called.
+	Notifier *Notify.Notifier
+	// Webhooks delivers complaint events to the registered webhooks. It is nil
+	// unless EnableWebhooks was called.
+	Webhooks *Webhook.Dispatcher
+	// Limits bound the sizes requests may ask for. NewServer sets them to
+	// DefaultLimits.
+	Limits Limits
+
+	// stopping is closed by Shutdown.
+	stopping chan struct{}
+	stopOnce sync.Once
 }
 
-const (
-	usersCollection      = "users"
-	complaintsCollection = "complaints"
-)
+// NewServer returns a Server that persists its data in the given store, hashes
+// secret codes with hasher and issues session tokens from sessions.
+func NewServer(store Storage.Store, hasher *Auth.SecretHasher, sessions *Auth.Sessions) *Server {
+	index := Search.NewIndex()
+	bus := Events.NewBus(Events.DefaultHistory)
+	store = Events.NewPublishingStore(Search.NewIndexedStore(store, index), bus)
+	return &Server{Store: store, Hasher: hasher, Sessions: sessions, Index: index, Events: bus, Limits: DefaultLimits, stopping: make(chan struct{})}
+}
 
-// Register implements the Register RPC method using Firestore.
+// Shutdown ends the WatchComplaints streams with Unavailable, and refuses new
+// ones, so that a graceful stop need not wait for watchers to hang up. Clients
+// reconnect, to another server during a rolling restart.
+func (s *Server) Shutdown() {
+	s.stopOnce.Do(func() { close(s.stopping) })
+}
+
+// EnableNotifications has n email the owners of the complaints submitted,
+// moved to another status or commented on by staff through the server.
+func (s *Server) EnableNotifications(n *Notify.Notifier) {
+	s.Notifier = n
+	s.Store = Notify.NewNotifyingStore(s.Store, n)
+}
+
+// EnableWebhooks has d deliver the events of the complaints written through the
+// server to the webhooks subscribed to them, until d is closed.
+func (s *Server) EnableWebhooks(d *Webhook.Dispatcher) {
+	s.Webhooks = d
+	d.Listen(s.Events)
+}
+
+// Register implements the Register RPC method.
+// The response is the only time the new user's secret code is ever returned.
 func (s *Server) Register(ctx context.Context, req *pb.RegisterRequest) (*pb.User, error) {
-	log.Printf(Common.LogReceivedRegister, req.GetName())
+	slog.DebugContext(ctx, Common.LogReceivedRegister)
+
+	user, secretCode, err := s.CreateAccount(ctx, req.GetName(), req.GetEmail(), Common.RoleCustomer)
+	if err != nil {
+		return nil, err
+	}
+
+	res := toPBUser(user)
+	res.SecretCode = secretCode
+	return res, nil
+}
+
+// CreateStaffUser implements the CreateStaffUser RPC method.
+// The interceptor only lets admins call it.
+func (s *Server) CreateStaffUser(ctx context.Context, req *pb.CreateStaffUserRequest) (*pb.User, error) {
+	slog.DebugContext(ctx, Common.LogReceivedCreateStaff, Common.LogKeyRole, req.GetRole().String())
 
-	if req.GetName() == "" || req.GetEmail() == "" {
-		return nil, status.Errorf(codes.InvalidArgument, Common.ErrNameAndEmailRequired)
+	role := fromPBRole(req.GetRole())
+	if role != Common.RoleAgent && role != Common.RoleAdmin {
+		return nil, status.Errorf(codes.InvalidArgument, Common.ErrInvalidStaffRole)
 	}
 
-	// Check if email already exists by querying Firestore
-	iter := Common.FirestoreClient.Collection(usersCollection).Where("Email", "==", req.GetEmail()).Limit(1).Documents(ctx)
-	if _, err := iter.Next(); err != iterator.Done {
-		if err != nil {
-			return nil, status.Errorf(codes.Internal, "Failed to query database: %v", err)
-		}
-		return nil, status.Errorf(codes.AlreadyExists, Common.ErrEmailAlreadyExists)
+	user, secretCode, err := s.CreateAccount(ctx, req.GetName(), req.GetEmail(), role)
+	if err != nil {
+		return nil, err
 	}
 
+	res := toPBUser(user)
+	res.SecretCode = secretCode
+	return res, nil
+}
+
+// CreateAccount registers a new user with the given role and a fresh secret code,
+// and returns the user with the code. Only the code's hash is stored.
+// It backs Register and CreateStaffUser, and the create-admin command used to
+// bootstrap the first admin.
+func (s *Server) CreateAccount(ctx context.Context, name, email string, role Common.Role) (Common.User, string, error) {
+	if name == "" || email == "" {
+		return Common.User{}, "", status.Errorf(codes.InvalidArgument, Common.ErrNameAndEmailRequired)
+	}
+
+	// Check if email already exists
+	_, err := s.Store.FindUserByEmail(ctx, email)
+	if err == nil {
+		return Common.User{}, "", status.Errorf(codes.AlreadyExists, Common.ErrEmailAlreadyExists)
+	}
+	if !errors.Is(err, Storage.ErrNotFound) {
+		return Common.User{}, "", status.Errorf(codes.Internal, "Failed to query database: %v", err)
+	}
+
+	secretCode := Common.GenerateSecretCode()
+	now := time.Now().UTC()
 	user := Common.User{
 		ID:         Common.GenerateID(),
-		SecretCode: Common.GenerateSecretCode(),
-		Name:       req.GetName(),
-		Email:      req.GetEmail(),
+		SecretHash: s.Hasher.Hash(secretCode),
+		Name:       name,
+		Email:      email,
 		Complaints: []string{},
+		Role:       role,
+		CreatedAt:  now,
+		UpdatedAt:  now,
 	}
 
-	// Use the user's ID as the document ID in Firestore
-	_, err := Common.FirestoreClient.Collection(usersCollection).Doc(user.ID).Set(ctx, user)
-	if err != nil {
-		return nil, status.Errorf(codes.Internal, "Failed to create user: %v", err)
+	if err := s.Store.CreateUser(ctx, user); err != nil {
+		return Common.User{}, "", status.Errorf(codes.Internal, "Failed to create user: %v", err)
 	}
-
-	return &pb.User{
-		Id:           user.ID,
-		SecretCode:   user.SecretCode,
-		Name:         user.Name,
-		Email:        user.Email,
-		ComplaintIds: user.Complaints,
-	}, nil
+	return user, secretCode, nil
 }
 
-// Login implements the Login RPC method using Firestore.
+// Login implements the Login RPC method.
+// Along with the user it returns a session token to authenticate later calls.
 func (s *Server) Login(ctx context.Context, req *pb.LoginRequest) (*pb.User, error) {
-	log.Println(Common.LogReceivedLogin)
+	slog.DebugContext(ctx, Common.LogReceivedLogin)
 
-	iter := Common.FirestoreClient.Collection(usersCollection).Where("SecretCode", "==", req.GetSecretCode()).Limit(1).Documents(ctx)
-	doc, err := iter.Next()
-	if err == iterator.Done {
+	user, err := s.Hasher.FindUser(ctx, s.Store, req.GetSecretCode())
+	if errors.Is(err, Storage.ErrNotFound) {
 		return nil, status.Errorf(codes.NotFound, Common.ErrInvalidSecretCode)
 	}
 	if err != nil {
 		return nil, status.Errorf(codes.Internal, "Failed to query database: %v", err)
 	}
+	Logging.SetUserID(ctx, user.ID)
 
-	var user Common.User
-	doc.DataTo(&user)
+	session, err := s.Sessions.Issue(user)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "Failed to create session: %v", err)
+	}
 
-	return &pb.User{
-		Id:           user.ID,
-		SecretCode:   user.SecretCode,
-		Name:         user.Name,
-		Email:        user.Email,
-		ComplaintIds: user.Complaints,
-	}, nil
+	res := toPBUser(user)
+	res.Session = toPBSession(session)
+	return res, nil
 }
 
-// SubmitComplaint implements the SubmitComplaint RPC method using Firestore.
-func (s *Server) SubmitComplaint(ctx context.Context, req *pb.SubmitComplaintRequest) (*pb.Complaint, error) {
-	log.Println(Common.LogReceivedSubmit)
+// Logout implements the Logout RPC method.
+// It revokes the session token the call was made with.
+func (s *Server) Logout(ctx context.Context, req *pb.LogoutRequest) (*pb.LogoutResponse, error) {
+	slog.DebugContext(ctx, Common.LogReceivedLogout)
+
+	session, ok := Auth.SessionFromContext(ctx)
+	if !ok {
+		return nil, status.Errorf(codes.Unauthenticated, Common.ErrSessionRequired)
+	}
+	s.Sessions.Revoke(session)
+	return &pb.LogoutResponse{}, nil
+}
 
-	// Find user by secret code
-	iter := Common.FirestoreClient.Collection(usersCollection).Where("SecretCode", "==", req.GetSecretCode()).Limit(1).Documents(ctx)
-	userDoc, err := iter.Next()
-	if err == iterator.Done {
-		return nil, status.Errorf(codes.Unauthenticated, Common.ErrUnauthorized)
+// RefreshSession implements the RefreshSession RPC method.
+// It replaces the session token the call was made with by a new one, issued
+// from the stored user so that it carries the current role. Sessions of users
+// who no longer exist, or ended by a secret code change, are not renewed.
+func (s *Server) RefreshSession(ctx context.Context, req *pb.RefreshSessionRequest) (*pb.Session, error) {
+	slog.DebugContext(ctx, Common.LogReceivedRefresh)
+
+	session, ok := Auth.SessionFromContext(ctx)
+	if !ok {
+		return nil, status.Errorf(codes.Unauthenticated, Common.ErrSessionRequired)
+	}
+	user, err := s.Store.GetUser(ctx, session.UserID)
+	if errors.Is(err, Storage.ErrNotFound) || (err == nil && session.CheckUser(user) != nil) {
+		s.Sessions.Revoke(session)
+		return nil, status.Errorf(codes.Unauthenticated, Common.ErrInvalidSession)
+	}
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "Failed to get user: %v", err)
 	}
+	next, err := s.Sessions.Refresh(session, user)
 	if err != nil {
-		return nil, status.Errorf(codes.Internal, "Failed to query user: %v", err)
+		return nil, status.Errorf(codes.Internal, "Failed to create session: %v", err)
 	}
-	var user Common.User
-	userDoc.DataTo(&user)
+	return toPBSession(next), nil
+}
+
+// SubmitComplaint implements the SubmitComplaint RPC method.
+func (s *Server) SubmitComplaint(ctx context.Context, req *pb.SubmitComplaintRequest) (*pb.Complaint, error) {
+	slog.DebugContext(ctx, Common.LogReceivedSubmit)
 
-	// Create new complaint
+	user, err := s.authenticate(ctx, req.GetSecretCode())
+	if err != nil {
+		return nil, err
+	}
+
+	// Create new complaint; SetStatus also sets UpdatedAt
+	now := time.Now().UTC()
 	complaint := Common.Complaint{
-		ID:       Common.GenerateID(),
-		Title:    req.GetTitle(),
-		Summary:  req.GetSummary(),
-		Severity: int(req.GetSeverity()),
-		UserID:   user.ID,
-		Resolved: false,
+		ID:        Common.GenerateID(),
+		Title:     req.GetTitle(),
+		Summary:   req.GetSummary(),
+		Severity:  int(req.GetSeverity()),
+		UserID:    user.ID,
+		Resolved:  false,
+		CreatedAt: now,
 	}
+	complaint.SetStatus(Common.StatusOpen, user.ID, "", now)
 
-	// Save complaint to Firestore
-	_, err = Common.FirestoreClient.Collection(complaintsCollection).Doc(complaint.ID).Set(ctx, complaint)
-	if err != nil {
+	// Save the complaint and add it to the user's complaints list in one atomic step
+	if err := s.Store.CreateComplaint(ctx, complaint); err != nil {
 		return nil, status.Errorf(codes.Internal, "Failed to create complaint: %v", err)
 	}
 
-	// Update user's complaints list
-	_, err = Common.FirestoreClient.Collection(usersCollection).Doc(user.ID).Update(ctx, []firestore.Update{
-		{Path: "Complaints", Value: firestore.ArrayUnion(complaint.ID)},
-	})
-	if err != nil {
-		// Attempt to roll back or log error
-		return nil, status.Errorf(codes.Internal, "Failed to update user with new complaint: %v", err)
-	}
-
-	return &pb.Complaint{
-		Id:       complaint.ID,
-		Title:    complaint.Title,
-		Summary:  complaint.Summary,
-		Severity: int32(complaint.Severity),
-		UserId:   complaint.UserID,
-		Resolved: complaint.Resolved,
-	}, nil
+	return toPBComplaint(complaint), nil
 }
 
-// GetUserComplaints implements the GetUserComplaints RPC method using Firestore.
+// GetUserComplaints implements the GetUserComplaints RPC method.
+// It returns one page of the caller's complaints, filtered and sorted as requested.
 func (s *Server) GetUserComplaints(ctx context.Context, req *pb.GetUserComplaintsRequest) (*pb.GetUserComplaintsResponse, error) {
-	log.Println(Common.LogReceivedGetUser)
+	slog.DebugContext(ctx, Common.LogReceivedGetUser)
 
-	// Find user by secret code
-	iter := Common.FirestoreClient.Collection(usersCollection).Where("SecretCode", "==", req.GetSecretCode()).Limit(1).Documents(ctx)
-	userDoc, err := iter.Next()
-	if err == iterator.Done {
-		return nil, status.Errorf(codes.Unauthenticated, Common.ErrUnauthorized)
+	user, err := s.authenticate(ctx, req.GetSecretCode())
+	if err != nil {
+		return nil, err
+	}
+
+	query, err := complaintQuery(req, s.Limits)
+	if err != nil {
+		return nil, err
 	}
+	query.UserID = user.ID
+
+	complaints, err := s.Store.QueryComplaints(ctx, query)
 	if err != nil {
-		return nil, status.Errorf(codes.Internal, "Failed to query user: %v", err)
+		return nil, status.Errorf(codes.Internal, "Failed to retrieve complaints: %v", err)
 	}
-	var user Common.User
-	userDoc.DataTo(&user)
+	complaints, nextToken := nextPage(complaints, query)
 
 	var result []*pb.Complaint
-	// Find all complaints for that user
-	complaintsIter := Common.FirestoreClient.Collection(complaintsCollection).Where("UserID", "==", user.ID).Documents(ctx)
-	for {
-		complaintDoc, err := complaintsIter.Next()
-		if err == iterator.Done {
-			break
-		}
-		if err != nil {
-			return nil, status.Errorf(codes.Internal, "Failed to retrieve complaints: %v", err)
-		}
-		var c Common.Complaint
-		complaintDoc.DataTo(&c)
-		result = append(result, &pb.Complaint{
-			Id:       c.ID,
-			Title:    c.Title,
-			Summary:  c.Summary,
-			Severity: int32(c.Severity),
-			UserId:   c.UserID,
-			Resolved: c.Resolved,
-		})
-	}
-
-	return &pb.GetUserComplaintsResponse{Complaints: result}, nil
+	for _, c := range complaints {
+		result = append(result, toPBComplaint(c))
+	}
+
+	return &pb.GetUserComplaintsResponse{Complaints: result, NextPageToken: nextToken}, nil
 }
 
-// GetAdminComplaints implements the GetAdminComplaints RPC method using Firestore.
+// GetAdminComplaints implements the GetAdminComplaints RPC method.
+// It returns one page of every user's complaints, or of one user's, filtered and
+// sorted as requested, each with its user's name and email. Complaints whose user
+// no longer exists are flagged rather than left out.
 func (s *Server) GetAdminComplaints(ctx context.Context, req *pb.GetAdminComplaintsRequest) (*pb.GetAdminComplaintsResponse, error) {
-	log.Println(Common.LogReceivedGetAdmin)
+	slog.DebugContext(ctx, Common.LogReceivedGetAdmin)
+
+	query, err := complaintQuery(req, s.Limits)
+	if err != nil {
+		return nil, err
+	}
+	query.UserID = req.GetUserId()
+
+	complaints, err := s.Store.QueryComplaints(ctx, query)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "Failed to retrieve complaints: %v", err)
+	}
+	complaints, nextToken := nextPage(complaints, query)
+
+	users := Storage.NewUserLookup(s.Store)
+	ids := make([]string, len(complaints))
+	for i, c := range complaints {
+		ids[i] = c.UserID
+	}
+	if err := users.Load(ctx, ids); err != nil {
+		return nil, status.Errorf(codes.Internal, "Failed to retrieve users: %v", err)
+	}
 
 	var result []*pb.AdminComplaintDetails
-	complaintsIter := Common.FirestoreClient.Collection(complaintsCollection).Documents(ctx)
-	for {
-		complaintDoc, err := complaintsIter.Next()
-		if err == iterator.Done {
-			break
-		}
-		if err != nil {
-			return nil, status.Errorf(codes.Internal, "Failed to retrieve complaints: %v", err)
+	for _, c := range complaints {
+		details := &pb.AdminComplaintDetails{Title: c.Title, Complaint: toPBComplaint(c)}
+		if u, ok := users.Get(c.UserID); ok {
+			details.UserName = u.Name
+			details.UserEmail = u.Email
+		} else {
+			slog.WarnContext(ctx, Common.LogOrphanComplaint, Common.LogKeyComplaint, c.ID, Common.LogKeyOwner, c.UserID)
+			details.UserMissing = true
 		}
+		result = append(result, details)
+	}
 
-		var c Common.Complaint
-		complaintDoc.DataTo(&c)
+	return &pb.GetAdminComplaintsResponse{Complaints: result, NextPageToken: nextToken}, nil
+}
 
-		// Get the user for this complaint
-		userDoc, err := Common.FirestoreClient.Collection(usersCollection).Doc(c.UserID).Get(ctx)
-		if err != nil {
-			// Log the error but continue, maybe the user was deleted
-			log.Printf("Could not find user %s for complaint %s: %v", c.UserID, c.ID, err)
-			continue
-		}
-		var u Common.User
-		userDoc.DataTo(&u)
+// ViewComplaint implements the ViewComplaint RPC method.
+func (s *Server) ViewComplaint(ctx context.Context, req *pb.ViewComplaintRequest) (*pb.Complaint, error) {
+	slog.DebugContext(ctx, Common.LogReceivedView)
 
-		result = append(result, &pb.AdminComplaintDetails{
-			Title:    c.Title,
-			UserName: u.Name,
-		})
+	complaint, _, err := s.accessComplaint(ctx, req.GetSecretCode(), req.GetComplaintId())
+	if err != nil {
+		return nil, err
 	}
 
-	return &pb.GetAdminComplaintsResponse{Complaints: result}, nil
+	// If all checks pass, return the complaint data.
+	return toPBComplaint(complaint), nil
 }
 
-// ViewComplaint implements the ViewComplaint RPC method using Firestore with corrected logic.
-func (s *Server) ViewComplaint(ctx context.Context, req *pb.ViewComplaintRequest) (*pb.Complaint, error) {
-	log.Println(Common.LogReceivedView)
+// AddComment implements the AddComment RPC method.
+// Only the complaint's owner and staff can post, like ViewComplaint.
+func (s *Server) AddComment(ctx context.Context, req *pb.AddCommentRequest) (*pb.Comment, error) {
+	slog.DebugContext(ctx, Common.LogReceivedAddComment)
 
-	// Step 1: Get the requested complaint first.
-	complaintDoc, err := Common.FirestoreClient.Collection(complaintsCollection).Doc(req.GetComplaintId()).Get(ctx)
+	if strings.TrimSpace(req.GetBody()) == "" {
+		return nil, status.Errorf(codes.InvalidArgument, Common.ErrCommentBodyRequired)
+	}
+
+	complaint, user, err := s.accessComplaint(ctx, req.GetSecretCode(), req.GetComplaintId())
 	if err != nil {
-		// If the complaint doesn't exist at all, return NotFound. This is correct.
+		return nil, err
+	}
+
+	comment := Common.Comment{
+		ID:          Common.GenerateID(),
+		ComplaintID: complaint.ID,
+		AuthorID:    user.ID,
+		Role:        commentRole(user, complaint),
+		Body:        req.GetBody(),
+		CreatedAt:   time.Now().UTC(),
+	}
+	err = s.Store.AddComment(ctx, comment)
+	if errors.Is(err, Storage.ErrNotFound) {
+		// The complaint was deleted after it was read.
 		return nil, status.Errorf(codes.NotFound, Common.ErrComplaintNotFound)
 	}
-	var complaint Common.Complaint
-	complaintDoc.DataTo(&complaint)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "Failed to add comment: %v", err)
+	}
 
-	// Step 2: Now, authenticate the user making the request.
-	iter := Common.FirestoreClient.Collection(usersCollection).Where("SecretCode", "==", req.GetSecretCode()).Limit(1).Documents(ctx)
-	userDoc, err := iter.Next()
-	if err == iterator.Done {
-		// If the secret code is invalid, the user is unauthenticated.
-		return nil, status.Errorf(codes.Unauthenticated, Common.ErrUnauthorized)
+	return toPBComment(comment), nil
+}
+
+// ListComments implements the ListComments RPC method.
+// Only the complaint's owner and staff can read the thread, like ViewComplaint.
+func (s *Server) ListComments(ctx context.Context, req *pb.ListCommentsRequest) (*pb.ListCommentsResponse, error) {
+	slog.DebugContext(ctx, Common.LogReceivedListComments)
+
+	complaint, _, err := s.accessComplaint(ctx, req.GetSecretCode(), req.GetComplaintId())
+	if err != nil {
+		return nil, err
+	}
+
+	comments, err := s.Store.ListComments(ctx, complaint.ID)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "Failed to retrieve comments: %v", err)
 	}
+
+	var result []*pb.Comment
+	for _, c := range comments {
+		result = append(result, toPBComment(c))
+	}
+	return &pb.ListCommentsResponse{Comments: result}, nil
+}
+
+// accessComplaint loads a complaint on behalf of the user owning the secret code
+// and checks that they may see it: staff see every complaint, customers their own.
+func (s *Server) accessComplaint(ctx context.Context, secretCode, complaintID string) (Common.Complaint, Common.User, error) {
+	// Step 1: Get the requested complaint first.
+	// If the complaint doesn't exist at all, this returns NotFound.
+	complaint, err := s.getComplaint(ctx, complaintID)
+	if err != nil {
+		return complaint, Common.User{}, err
+	}
+
+	// Step 2: Now, authenticate the user making the request.
+	user, err := s.authenticate(ctx, secretCode)
 	if err != nil {
-		return nil, status.Errorf(codes.Internal, "Failed to query user: %v", err)
+		return complaint, user, err
 	}
-	var user Common.User
-	userDoc.DataTo(&user)
 
 	// Step 3: Finally, check for ownership. This is the authorization step.
-	if complaint.UserID != user.ID {
+	if complaint.UserID != user.ID && !user.IsStaff() {
 		// The user is authenticated, but not authorized to see this specific complaint.
-		return nil, status.Errorf(codes.PermissionDenied, Common.ErrComplaintAccess)
+		return complaint, user, status.Errorf(codes.PermissionDenied, Common.ErrComplaintAccess)
 	}
-
-	// If all checks pass, return the complaint data.
-	return &pb.Complaint{
-		Id:       complaint.ID,
-		Title:    complaint.Title,
-		Summary:  complaint.Summary,
-		Severity: int32(complaint.Severity),
-		UserId:   complaint.UserID,
-		Resolved: complaint.Resolved,
-	}, nil
+	return complaint, user, nil
 }
 
+// commentRole returns the role a user comments with on a complaint.
+func commentRole(user Common.User, complaint Common.Complaint) Common.CommentRole {
+	if user.IsStaff() && complaint.UserID != user.ID {
+		return Common.CommentByStaff
+	}
+	return Common.CommentByCustomer
+}
 
-// ResolveComplaint implements the ResolveComplaint RPC method using Firestore.
+// ResolveComplaint implements the ResolveComplaint RPC method.
+// It is a staff shortcut for TransitionComplaint to RESOLVED; resolving twice is a no-op.
 func (s *Server) ResolveComplaint(ctx context.Context, req *pb.ResolveComplaintRequest) (*pb.ResolveComplaintResponse, error) {
-	log.Println(Common.LogReceivedResolve)
+	slog.DebugContext(ctx, Common.LogReceivedResolve)
 
-	// Update the complaint document
-	_, err := Common.FirestoreClient.Collection(complaintsCollection).Doc(req.GetComplaintId()).Update(ctx, []firestore.Update{
-		{Path: "Resolved", Value: true},
-	})
+	caller, err := s.authenticate(ctx, req.GetSecretCode())
+	if err != nil {
+		return nil, err
+	}
 
+	_, err = s.transition(ctx, req.GetComplaintId(), Common.StatusResolved, caller, "")
 	if err != nil {
-		// This will return a NotFound error if the document ID doesn't exist.
-		return nil, status.Errorf(codes.Internal, "Failed to update complaint: %v", err)
+		return nil, err
 	}
 
 	return &pb.ResolveComplaintResponse{Message: Common.MsgComplaintResolved}, nil
 }
+
+// TransitionComplaint implements the TransitionComplaint RPC method.
+// Staff may make any valid change; a complaint's owner only the few changes
+// reserved to customers.
+func (s *Server) TransitionComplaint(ctx context.Context, req *pb.TransitionComplaintRequest) (*pb.Complaint, error) {
+	slog.DebugContext(ctx, Common.LogReceivedTransition)
+
+	to, ok := fromPBStatus(req.GetStatus())
+	if !ok {
+		return nil, status.Errorf(codes.InvalidArgument, Common.ErrInvalidStatus)
+	}
+
+	caller, err := s.authenticate(ctx, req.GetSecretCode())
+	if err != nil {
+		return nil, err
+	}
+
+	complaint, err := s.transition(ctx, req.GetComplaintId(), to, caller, req.GetNote())
+	if err != nil {
+		return nil, err
+	}
+	return toPBComplaint(complaint), nil
+}
+
+// transition moves a complaint to a new status on behalf of the caller and records
+// who did it. Customers may only touch their own complaints, and only make the
+// customer transitions. Moving a complaint to the status it already has changes nothing.
+func (s *Server) transition(ctx context.Context, complaintID string, to Common.ComplaintStatus, caller Common.User, note string) (Common.Complaint, error) {
+	customer := !caller.IsStaff()
+
+	complaint, err := s.Store.UpdateComplaint(ctx, complaintID, func(c *Common.Complaint) error {
+		if customer && c.UserID != caller.ID {
+			return status.Errorf(codes.PermissionDenied, Common.ErrComplaintAccess)
+		}
+		from := c.CurrentStatus()
+		if from == to {
+			return errNoChange
+		}
+		if !Common.CanTransition(from, to) {
+			return status.Errorf(codes.FailedPrecondition, Common.ErrInvalidTransition, from, to)
+		}
+		if customer && !Common.CustomerCanTransition(from, to) {
+			return status.Errorf(codes.PermissionDenied, Common.ErrTransitionForbidden, from, to)
+		}
+		c.SetStatus(to, caller.ID, note, time.Now().UTC())
+		return nil
+	})
+	if errors.Is(err, errNoChange) {
+		return s.getComplaint(ctx, complaintID)
+	}
+	if errors.Is(err, Storage.ErrNotFound) {
+		return complaint, status.Errorf(codes.NotFound, Common.ErrComplaintNotFound)
+	}
+	if err != nil {
+		// Errors raised by the update itself already carry a gRPC status.
+		if _, ok := status.FromError(err); !ok {
+			err = status.Errorf(codes.Internal, "Failed to update complaint: %v", err)
+		}
+		return complaint, err
+	}
+	return complaint, nil
+}
+
+// errNoChange aborts an update that would leave the complaint as it is.
+var errNoChange = errors.New("no change")
+
+// getComplaint loads a complaint, translating storage errors into gRPC statuses.
+func (s *Server) getComplaint(ctx context.Context, id string) (Common.Complaint, error) {
+	complaint, err := s.Store.GetComplaint(ctx, id)
+	if errors.Is(err, Storage.ErrNotFound) {
+		return complaint, status.Errorf(codes.NotFound, Common.ErrComplaintNotFound)
+	}
+	if err != nil {
+		return complaint, status.Errorf(codes.Internal, "Failed to retrieve complaint: %v", err)
+	}
+	return complaint, nil
+}
+
+// authenticate returns the caller. When the request went through Auth's interceptor
+// the user is already in the context, from a session token or a secret code;
+// otherwise it is looked up by secret code.
+// It returns Unauthenticated if no user has that code.
+func (s *Server) authenticate(ctx context.Context, secretCode string) (Common.User, error) {
+	if user, ok := Auth.UserFromContext(ctx); ok {
+		return user, nil
+	}
+	user, err := s.Hasher.FindUser(ctx, s.Store, secretCode)
+	if errors.Is(err, Storage.ErrNotFound) {
+		return user, status.Errorf(codes.Unauthenticated, Common.ErrUnauthorized)
+	}
+	if err != nil {
+		return user, status.Errorf(codes.Internal, "Failed to query user: %v", err)
+	}
+	return user, nil
+}
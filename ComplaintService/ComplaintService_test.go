@@ -2,53 +2,102 @@
 package ComplaintService
 
 import (
+	"complaint-portal/Auth"
 	"complaint-portal/Common"
 	pb "complaint-portal/Generated/ComplaintService"
+	"complaint-portal/Notify"
+	"complaint-portal/Storage"
+	"complaint-portal/Webhook"
 	"context"
+	"fmt"
+	"io"
 	"log"
+	"net/http"
+	"net/http/httptest"
 	"os"
+	"strconv"
+	"strings"
 	"testing"
+	"time"
 
 	"cloud.google.com/go/firestore"
 	"google.golang.org/api/option"
 	"google.golang.org/grpc"
 	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/metadata"
 	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/timestamppb"
 )
 
-// NOTE: The collection name constants have been removed from this file
-// as they are already defined in ComplaintService.go and are accessible
-// within the same package.
-
+// firestoreClient is only set when the tests run against the Firestore emulator.
 var firestoreClient *firestore.Client
 
-// TestMain sets up the connection to the Firestore emulator before running tests
-// and closes the connection after all tests have run.
+// testStore is the store every test server is built on.
+var testStore Storage.Store
+
+// testHasher hashes the secret codes of every test server.
+var testHasher *Auth.SecretHasher
+
+// testSessions issues the session tokens of every test server.
+var testSessions *Auth.Sessions
+
+// TestMain picks the storage backend before running tests.
+// By default the tests use the in-memory store. If FIRESTORE_EMULATOR_HOST is set
+// (for example to localhost:8081), they run against the Firestore emulator instead,
+// and STORAGE_BACKEND=sqlite runs them against an in-memory SQLite database.
 func TestMain(m *testing.M) {
-	// Set the FIRESTORE_EMULATOR_HOST environment variable to point to the emulator.
-	os.Setenv("FIRESTORE_EMULATOR_HOST", "localhost:8081")
+	if os.Getenv("FIRESTORE_EMULATOR_HOST") != "" {
+		ctx := context.Background()
+		// The project ID for the emulator can be any string.
+		client, err := firestore.NewClient(ctx, "test-project", option.WithGRPCDialOption(grpc.WithTransportCredentials(insecure.NewCredentials())))
+		if err != nil {
+			log.Fatalf("Failed to create Firestore client for emulator: %v", err)
+		}
+		firestoreClient = client
+		testStore = Storage.NewFirestoreStore(client, Storage.DefaultCollections)
+	} else if os.Getenv(Common.EnvStorageBackend) == Storage.BackendSQLite {
+		store, err := Storage.OpenSQLite(":memory:")
+		if err != nil {
+			log.Fatalf("Failed to open SQLite store: %v", err)
+		}
+		testStore = store
+	} else {
+		testStore = Storage.NewMemoryStore()
+	}
 
-	ctx := context.Background()
-	// The project ID for the emulator can be any string.
-	client, err := firestore.NewClient(ctx, "test-project", option.WithGRPCDialOption(grpc.WithTransportCredentials(insecure.NewCredentials())))
+	hasher, err := Auth.NewSecretHasher(Auth.RandomKey())
 	if err != nil {
-		log.Fatalf("Failed to create Firestore client for emulator: %v", err)
+		log.Fatalf("Failed to create secret hasher: %v", err)
 	}
-	firestoreClient = client
-	Common.FirestoreClient = client // Set the global client for the service to use
+	testHasher = hasher
+	sessions, err := Auth.NewSessions(Auth.RandomKey(), time.Hour)
+	if err != nil {
+		log.Fatalf("Failed to create sessions: %v", err)
+	}
+	testSessions = sessions
 
 	// Run the tests
 	exitCode := m.Run()
 
 	// Clean up and exit
-	firestoreClient.Close()
+	testStore.Close()
 	os.Exit(exitCode)
 }
 
-// clearFirestore deletes all documents from the specified collections.
-func clearFirestore(ctx context.Context, t *testing.T) {
-	collections := []string{usersCollection, complaintsCollection}
+// clearStore deletes all users and complaints from the test store.
+func clearStore(ctx context.Context, t *testing.T) {
+	switch store := testStore.(type) {
+	case *Storage.MemoryStore:
+		store.Reset()
+		return
+	case *Storage.SQLStore:
+		if err := store.Reset(); err != nil {
+			t.Fatalf("Failed to reset the SQL store: %v", err)
+		}
+		return
+	}
+	collections := []string{Common.UsersCollection, Common.ComplaintsCollection, Common.AuditCollection, Common.WebhooksCollection, Common.DeliveriesCollection}
 	for _, coll := range collections {
 		docs, err := firestoreClient.Collection(coll).Documents(ctx).GetAll()
 		if err != nil {
@@ -66,10 +115,10 @@ func clearFirestore(ctx context.Context, t *testing.T) {
 // TestRegister tests the Register RPC method.
 func TestRegister(t *testing.T) {
 	ctx := context.Background()
-	s := Server{}
+	s := NewServer(testStore, testHasher, testSessions)
 
 	// Clean the database before each test run
-	clearFirestore(ctx, t)
+	clearStore(ctx, t)
 
 	// Test case 1: Successful registration
 	req1 := &pb.RegisterRequest{Name: "Test User", Email: "test@example.com"}
@@ -83,6 +132,13 @@ func TestRegister(t *testing.T) {
 	if res1.GetId() == "" || res1.GetSecretCode() == "" {
 		t.Error("Expected user ID and secret code to be generated")
 	}
+	if res1.GetCreatedAt() == nil || !res1.GetCreatedAt().AsTime().Equal(res1.GetUpdatedAt().AsTime()) {
+		t.Errorf("Expected a new user to be created and updated at the same time, but got %v and %v", res1.GetCreatedAt(), res1.GetUpdatedAt())
+	}
+	stored, err := testStore.GetUser(ctx, res1.GetId())
+	if err != nil || stored.SecretHash == res1.GetSecretCode() || !testHasher.Verify(res1.GetSecretCode(), stored.SecretHash) {
+		t.Errorf("Expected only the hash of the secret code to be stored, but got %q (err: %v)", stored.SecretHash, err)
+	}
 
 	// Test case 2: Attempt to register with the same email
 	_, err = s.Register(ctx, req1)
@@ -104,8 +160,8 @@ func TestRegister(t *testing.T) {
 // TestLogin tests the Login RPC method.
 func TestLogin(t *testing.T) {
 	ctx := context.Background()
-	s := Server{}
-	clearFirestore(ctx, t)
+	s := NewServer(testStore, testHasher, testSessions)
+	clearStore(ctx, t)
 
 	// First, register a user to test login
 	regReq := &pb.RegisterRequest{Name: "Login User", Email: "login@example.com"}
@@ -120,6 +176,9 @@ func TestLogin(t *testing.T) {
 	if loginRes1.GetName() != regReq.Name {
 		t.Errorf("Expected user name to be '%s', got '%s'", regReq.Name, loginRes1.Name)
 	}
+	if loginRes1.GetSecretCode() != "" {
+		t.Error("Expected Login not to return the secret code")
+	}
 
 	// Test case 2: Failed login with incorrect secret code
 	loginReq2 := &pb.LoginRequest{SecretCode: "invalid-secret-code"}
@@ -129,11 +188,82 @@ func TestLogin(t *testing.T) {
 	}
 }
 
+// TestSessions tests the session token returned by Login, and the Logout and
+// RefreshSession RPC methods.
+func TestSessions(t *testing.T) {
+	ctx := context.Background()
+	s := NewServer(testStore, testHasher, testSessions)
+	clearStore(ctx, t)
+
+	regRes, _ := s.Register(ctx, &pb.RegisterRequest{Name: "Session User", Email: "session@example.com"})
+
+	// Test case 1: Login returns a session token for the user
+	loginRes, err := s.Login(ctx, &pb.LoginRequest{SecretCode: regRes.GetSecretCode()})
+	if err != nil {
+		t.Fatalf("Expected no error for Login, but got: %v", err)
+	}
+	session, err := testSessions.Verify(loginRes.GetSession().GetToken())
+	if err != nil {
+		t.Fatalf("Expected Login to return a valid session token, but got: %v", err)
+	}
+	if session.UserID != regRes.GetId() || !loginRes.GetSession().GetExpiresAt().AsTime().Equal(session.ExpiresAt) {
+		t.Errorf("Expected a session for %s, but got %+v", regRes.GetId(), session)
+	}
+
+	// Test case 2: Logout and RefreshSession need a session
+	if _, err := s.Logout(ctx, &pb.LogoutRequest{}); status.Code(err) != codes.Unauthenticated {
+		t.Errorf("Expected Unauthenticated for Logout without a session, but got %v", status.Code(err))
+	}
+	if _, err := s.RefreshSession(ctx, &pb.RefreshSessionRequest{}); status.Code(err) != codes.Unauthenticated {
+		t.Errorf("Expected Unauthenticated for RefreshSession without a session, but got %v", status.Code(err))
+	}
+
+	// Test case 3: Refreshing replaces the token
+	refreshed, err := s.RefreshSession(Auth.WithSession(ctx, session), &pb.RefreshSessionRequest{})
+	if err != nil {
+		t.Fatalf("Expected no error for RefreshSession, but got: %v", err)
+	}
+	if _, err := testSessions.Verify(loginRes.GetSession().GetToken()); err == nil {
+		t.Error("Expected the old token to be revoked after a refresh")
+	}
+	next, err := testSessions.Verify(refreshed.GetToken())
+	if err != nil {
+		t.Fatalf("Expected the refreshed token to be valid, but got: %v", err)
+	}
+
+	// Test case 4: Refreshing picks up role changes, and users who no longer exist are refused
+	if _, err := testStore.UpdateUser(ctx, regRes.GetId(), func(u *Common.User) error {
+		u.Role = Common.RoleAgent
+		return nil
+	}); err != nil {
+		t.Fatalf("Failed to update the user: %v", err)
+	}
+	refreshed, err = s.RefreshSession(Auth.WithSession(ctx, next), &pb.RefreshSessionRequest{})
+	if err != nil {
+		t.Fatalf("Expected no error for RefreshSession, but got: %v", err)
+	}
+	if next, err = testSessions.Verify(refreshed.GetToken()); err != nil || next.Role != Common.RoleAgent {
+		t.Errorf("Expected a token with the agent role, but got %+v, %v", next, err)
+	}
+	ghost, _ := testSessions.Issue(Common.User{ID: "ghost", Role: Common.RoleAdmin})
+	if _, err := s.RefreshSession(Auth.WithSession(ctx, ghost), &pb.RefreshSessionRequest{}); status.Code(err) != codes.Unauthenticated {
+		t.Errorf("Expected Unauthenticated for a user who no longer exists, but got %v", err)
+	}
+
+	// Test case 5: Logout revokes the token
+	if _, err := s.Logout(Auth.WithSession(ctx, next), &pb.LogoutRequest{}); err != nil {
+		t.Fatalf("Expected no error for Logout, but got: %v", err)
+	}
+	if _, err := testSessions.Verify(refreshed.GetToken()); err == nil {
+		t.Error("Expected the token to be revoked after Logout")
+	}
+}
+
 // TestSubmitComplaint tests the SubmitComplaint RPC method.
 func TestSubmitComplaint(t *testing.T) {
 	ctx := context.Background()
-	s := Server{}
-	clearFirestore(ctx, t)
+	s := NewServer(testStore, testHasher, testSessions)
+	clearStore(ctx, t)
 
 	// Register a user first
 	regRes, _ := s.Register(ctx, &pb.RegisterRequest{Name: "Complaint Filer", Email: "filer@example.com"})
@@ -152,6 +282,9 @@ func TestSubmitComplaint(t *testing.T) {
 	if submitRes1.GetTitle() != submitReq1.Title {
 		t.Errorf("Expected complaint title to be '%s', got '%s'", submitReq1.Title, submitRes1.Title)
 	}
+	if time.Since(submitRes1.GetCreatedAt().AsTime()) > time.Minute || submitRes1.GetUpdatedAt() == nil || submitRes1.GetResolvedAt() != nil {
+		t.Errorf("Expected a new complaint to be created now and not resolved, but got %v", submitRes1)
+	}
 
 	// Test case 2: Submission with invalid secret code
 	submitReq2 := &pb.SubmitComplaintRequest{
@@ -169,8 +302,8 @@ func TestSubmitComplaint(t *testing.T) {
 // TestGetUserComplaints tests the GetUserComplaints RPC method.
 func TestGetUserComplaints(t *testing.T) {
 	ctx := context.Background()
-	s := Server{}
-	clearFirestore(ctx, t)
+	s := NewServer(testStore, testHasher, testSessions)
+	clearStore(ctx, t)
 
 	// Setup: Register a user and submit two complaints
 	regRes, _ := s.Register(ctx, &pb.RegisterRequest{Name: "Multi Complaint User", Email: "multi@example.com"})
@@ -186,13 +319,109 @@ func TestGetUserComplaints(t *testing.T) {
 	if len(getRes.GetComplaints()) != 2 {
 		t.Errorf("Expected to get 2 complaints, but got %d", len(getRes.GetComplaints()))
 	}
+	if getRes.GetNextPageToken() != "" {
+		t.Errorf("Expected a single page, but got next page token %q", getRes.GetNextPageToken())
+	}
+}
+
+// TestComplaintPagination tests paging, filters and sorting of the complaint listings.
+func TestComplaintPagination(t *testing.T) {
+	ctx := context.Background()
+	s := NewServer(testStore, testHasher, testSessions)
+	clearStore(ctx, t)
+
+	user, _ := s.Register(ctx, &pb.RegisterRequest{Name: "Pager", Email: "pager@example.com"})
+	other, _ := s.Register(ctx, &pb.RegisterRequest{Name: "Other", Email: "other-pager@example.com"})
+	agent := createAgent(ctx, t, s)
+	for i, severity := range []int32{2, 5, 1, 4, 3} {
+		c, _ := s.SubmitComplaint(ctx, &pb.SubmitComplaintRequest{SecretCode: user.GetSecretCode(), Title: fmt.Sprintf("P%d", i), Severity: severity})
+		if severity == 5 {
+			s.ResolveComplaint(ctx, &pb.ResolveComplaintRequest{SecretCode: agent.GetSecretCode(), ComplaintId: c.GetId()})
+		}
+	}
+	s.SubmitComplaint(ctx, &pb.SubmitComplaintRequest{SecretCode: other.GetSecretCode(), Title: "Not mine", Severity: 5})
+	titles := func(complaints []*pb.Complaint) string {
+		var result []string
+		for _, c := range complaints {
+			result = append(result, c.GetTitle())
+		}
+		return strings.Join(result, ",")
+	}
+
+	// Test case 1: Paging by severity visits every complaint of the user once
+	var seen []string
+	req := &pb.GetUserComplaintsRequest{SecretCode: user.GetSecretCode(), Sort: pb.ComplaintSort_HIGHEST_SEVERITY_FIRST, PageSize: 2}
+	for pages := 0; ; pages++ {
+		res, err := s.GetUserComplaints(ctx, req)
+		if err != nil || pages > 3 {
+			t.Fatalf("Expected 3 pages without error, but got %d pages (err: %v)", pages+1, err)
+		}
+		seen = append(seen, titles(res.GetComplaints()))
+		if res.GetNextPageToken() == "" {
+			break
+		}
+		req.PageToken = res.GetNextPageToken()
+	}
+	if got := strings.Join(seen, "|"); got != "P1,P3|P4,P0|P2" {
+		t.Errorf("Expected pages P1,P3|P4,P0|P2, but got %s", got)
+	}
+
+	// Test case 2: Filters narrow the listing
+	resolved := false
+	res, _ := s.GetUserComplaints(ctx, &pb.GetUserComplaintsRequest{SecretCode: user.GetSecretCode(), Sort: pb.ComplaintSort_OLDEST_FIRST,
+		Filter: &pb.ComplaintFilter{MinSeverity: 2, MaxSeverity: 4, Resolved: &resolved}})
+	if got := titles(res.GetComplaints()); got != "P0,P3,P4" {
+		t.Errorf("Expected unresolved complaints of severity 2 to 4, but got %s", got)
+	}
+	res, _ = s.GetUserComplaints(ctx, &pb.GetUserComplaintsRequest{SecretCode: user.GetSecretCode(),
+		Filter: &pb.ComplaintFilter{Statuses: []pb.ComplaintStatus{pb.ComplaintStatus_RESOLVED}, CreatedUntil: timestamppb.New(time.Now().Add(time.Minute))}})
+	if got := titles(res.GetComplaints()); got != "P1" {
+		t.Errorf("Expected the resolved complaint, but got %s", got)
+	}
+
+	// Test case 3: Staff can list one user's complaints, newest first by default
+	adminRes, err := s.GetAdminComplaints(ctx, &pb.GetAdminComplaintsRequest{SecretCode: agent.GetSecretCode(), UserId: other.GetId()})
+	if err != nil || len(adminRes.GetComplaints()) != 1 || adminRes.GetComplaints()[0].GetTitle() != "Not mine" {
+		t.Errorf("Expected only the other user's complaint, but got %v (err: %v)", adminRes.GetComplaints(), err)
+	}
+	adminRes, _ = s.GetAdminComplaints(ctx, &pb.GetAdminComplaintsRequest{SecretCode: agent.GetSecretCode(), PageSize: 1})
+	if len(adminRes.GetComplaints()) != 1 || adminRes.GetComplaints()[0].GetTitle() != "Not mine" || adminRes.GetNextPageToken() == "" {
+		t.Errorf("Expected the newest complaint and a next page, but got %v", adminRes)
+	}
+
+	// Test case 4: Invalid requests are refused
+	for name, bad := range map[string]*pb.GetUserComplaintsRequest{
+		"negative page size":  {PageSize: -1},
+		"garbage token":       {PageToken: "not a token"},
+		"token of other sort": {PageToken: req.GetPageToken(), Sort: pb.ComplaintSort_OLDEST_FIRST},
+		"unknown sort":        {Sort: pb.ComplaintSort(42)},
+		"inverted severity":   {Filter: &pb.ComplaintFilter{MinSeverity: 4, MaxSeverity: 2}},
+		"unspecified status":  {Filter: &pb.ComplaintFilter{Statuses: []pb.ComplaintStatus{pb.ComplaintStatus_COMPLAINT_STATUS_UNSPECIFIED}}},
+		"empty created range": {Filter: &pb.ComplaintFilter{CreatedSince: timestamppb.Now(), CreatedUntil: timestamppb.New(time.Now().Add(-time.Hour))}},
+	} {
+		bad.SecretCode = user.GetSecretCode()
+		if _, err := s.GetUserComplaints(ctx, bad); status.Code(err) != codes.InvalidArgument {
+			t.Errorf("%s: expected InvalidArgument, but got %v", name, err)
+		}
+	}
+
+	// Test case 5: Page sizes follow the server's limits
+	s.Limits.DefaultPageSize, s.Limits.MaxPageSize = 2, 3
+	res, _ = s.GetUserComplaints(ctx, &pb.GetUserComplaintsRequest{SecretCode: user.GetSecretCode()})
+	if len(res.GetComplaints()) != 2 || res.GetNextPageToken() == "" {
+		t.Errorf("Expected a default page of 2 complaints, but got %v", res)
+	}
+	res, _ = s.GetUserComplaints(ctx, &pb.GetUserComplaintsRequest{SecretCode: user.GetSecretCode(), PageSize: 100})
+	if len(res.GetComplaints()) != 3 {
+		t.Errorf("Expected the page size to be capped at 3, but got %d complaints", len(res.GetComplaints()))
+	}
 }
 
 // TestViewComplaint tests the ViewComplaint RPC method.
 func TestViewComplaint(t *testing.T) {
 	ctx := context.Background()
-	s := Server{}
-	clearFirestore(ctx, t)
+	s := NewServer(testStore, testHasher, testSessions)
+	clearStore(ctx, t)
 
 	// Setup: Register two users, one submits a complaint
 	user1Res, _ := s.Register(ctx, &pb.RegisterRequest{Name: "User One", Email: "one@example.com"})
@@ -217,16 +446,23 @@ func TestViewComplaint(t *testing.T) {
 // TestResolveComplaint tests the ResolveComplaint RPC method.
 func TestResolveComplaint(t *testing.T) {
 	ctx := context.Background()
-	s := Server{}
-	clearFirestore(ctx, t)
+	s := NewServer(testStore, testHasher, testSessions)
+	clearStore(ctx, t)
 
 	// Setup: Register a user and submit a complaint
 	regRes, _ := s.Register(ctx, &pb.RegisterRequest{Name: "Resolver User", Email: "resolver@example.com"})
 	complaintRes, _ := s.SubmitComplaint(ctx, &pb.SubmitComplaintRequest{SecretCode: regRes.GetSecretCode(), Title: "To Be Resolved"})
+	agent := createAgent(ctx, t, s)
+
+	// Test case 1: The owner cannot resolve their own complaint
+	_, err := s.ResolveComplaint(ctx, &pb.ResolveComplaintRequest{SecretCode: regRes.GetSecretCode(), ComplaintId: complaintRes.GetId()})
+	if status.Code(err) != codes.PermissionDenied {
+		t.Errorf("Expected PermissionDenied for a customer resolving, but got %v", status.Code(err))
+	}
 
-	// Test case 1: Resolve the complaint
-	resolveReq := &pb.ResolveComplaintRequest{ComplaintId: complaintRes.GetId()}
-	_, err := s.ResolveComplaint(ctx, resolveReq)
+	// Test case 2: An agent resolves the complaint
+	resolveReq := &pb.ResolveComplaintRequest{SecretCode: agent.GetSecretCode(), ComplaintId: complaintRes.GetId()}
+	_, err = s.ResolveComplaint(ctx, resolveReq)
 	if err != nil {
 		t.Fatalf("Expected no error when resolving complaint, but got: %v", err)
 	}
@@ -237,22 +473,27 @@ func TestResolveComplaint(t *testing.T) {
 	if !viewRes.GetResolved() {
 		t.Error("Expected complaint to be marked as resolved, but it was not")
 	}
+	created, resolved := viewRes.GetCreatedAt().AsTime(), viewRes.GetResolvedAt().AsTime()
+	if viewRes.GetResolvedAt() == nil || resolved.Before(created) || !viewRes.GetUpdatedAt().AsTime().Equal(resolved) {
+		t.Errorf("Expected the complaint to be resolved and updated after it was created, but got %v", viewRes)
+	}
 }
 
 // TestGetAdminComplaints tests the GetAdminComplaints RPC method.
 func TestGetAdminComplaints(t *testing.T) {
 	ctx := context.Background()
-	s := Server{}
-	clearFirestore(ctx, t)
+	s := NewServer(testStore, testHasher, testSessions)
+	clearStore(ctx, t)
 
 	// Setup: Register two users and have them each submit a complaint.
 	user1Res, _ := s.Register(ctx, &pb.RegisterRequest{Name: "Admin Test User 1", Email: "admin1@example.com"})
 	user2Res, _ := s.Register(ctx, &pb.RegisterRequest{Name: "Admin Test User 2", Email: "admin2@example.com"})
 	_, _ = s.SubmitComplaint(ctx, &pb.SubmitComplaintRequest{SecretCode: user1Res.GetSecretCode(), Title: "Admin Complaint 1"})
 	_, _ = s.SubmitComplaint(ctx, &pb.SubmitComplaintRequest{SecretCode: user2Res.GetSecretCode(), Title: "Admin Complaint 2"})
+	agent := createAgent(ctx, t, s)
 
 	// Test case 1: Call the admin endpoint
-	adminReq := &pb.GetAdminComplaintsRequest{}
+	adminReq := &pb.GetAdminComplaintsRequest{SecretCode: agent.GetSecretCode()}
 	adminRes, err := s.GetAdminComplaints(ctx, adminReq)
 	if err != nil {
 		t.Fatalf("Expected no error for GetAdminComplaints, but got: %v", err)
@@ -262,4 +503,967 @@ func TestGetAdminComplaints(t *testing.T) {
 	if len(adminRes.GetComplaints()) != 2 {
 		t.Errorf("Expected admin to see 2 complaints, but got %d", len(adminRes.GetComplaints()))
 	}
+
+	// Test case 2: Each complaint comes in full, with its user's name and email
+	for _, details := range adminRes.GetComplaints() {
+		c := details.GetComplaint()
+		if c.GetId() == "" || c.GetTitle() != details.GetTitle() || c.GetStatus() != pb.ComplaintStatus_OPEN || c.GetCreatedAt() == nil {
+			t.Errorf("Expected the full complaint, but got %v", c)
+		}
+		want := user1Res
+		if c.GetUserId() == user2Res.GetId() {
+			want = user2Res
+		}
+		if details.GetUserName() != want.GetName() || details.GetUserEmail() != want.GetEmail() || details.GetUserMissing() {
+			t.Errorf("Expected user %s <%s>, but got %v", want.GetName(), want.GetEmail(), details)
+		}
+	}
+
+	// Test case 3: Users are read in one batch, and complaints of missing users are flagged, not dropped
+	_, _ = s.SubmitComplaint(ctx, &pb.SubmitComplaintRequest{SecretCode: user1Res.GetSecretCode(), Title: "Admin Complaint 3"})
+	store := &userCountingStore{Store: testStore, hidden: map[string]bool{user2Res.GetId(): true}}
+	adminRes, err = NewServer(store, testHasher, testSessions).GetAdminComplaints(ctx, adminReq)
+	if err != nil {
+		t.Fatalf("Expected no error for GetAdminComplaints, but got: %v", err)
+	}
+	if store.calls != 1 || len(adminRes.GetComplaints()) != 3 {
+		t.Errorf("Expected 3 complaints from 1 user read, but got %d from %d", len(adminRes.GetComplaints()), store.calls)
+	}
+	for _, details := range adminRes.GetComplaints() {
+		orphan := details.GetComplaint().GetUserId() == user2Res.GetId()
+		if details.GetUserMissing() != orphan || (orphan && details.GetUserName() != "") {
+			t.Errorf("Expected user_missing to be %v, but got %v", orphan, details)
+		}
+	}
+}
+
+// userCountingStore counts GetUsers calls and leaves out the hidden users, as if
+// they had been deleted.
+type userCountingStore struct {
+	Storage.Store
+	calls  int
+	hidden map[string]bool
+}
+
+func (u *userCountingStore) GetUsers(ctx context.Context, ids []string) (map[string]Common.User, error) {
+	u.calls++
+	users, err := u.Store.GetUsers(ctx, ids)
+	for id := range u.hidden {
+		delete(users, id)
+	}
+	return users, err
+}
+
+// TestTransitionComplaint tests the TransitionComplaint RPC method.
+func TestTransitionComplaint(t *testing.T) {
+	ctx := context.Background()
+	s := NewServer(testStore, testHasher, testSessions)
+	clearStore(ctx, t)
+
+	// Setup: Register two users and submit a complaint
+	ownerRes, _ := s.Register(ctx, &pb.RegisterRequest{Name: "Owner", Email: "owner@example.com"})
+	otherRes, _ := s.Register(ctx, &pb.RegisterRequest{Name: "Other", Email: "other@example.com"})
+	complaintRes, _ := s.SubmitComplaint(ctx, &pb.SubmitComplaintRequest{SecretCode: ownerRes.GetSecretCode(), Title: "Lifecycle"})
+	agent := createAgent(ctx, t, s)
+	if complaintRes.GetStatus() != pb.ComplaintStatus_OPEN {
+		t.Fatalf("Expected a new complaint to be OPEN, but got %v", complaintRes.GetStatus())
+	}
+
+	// Test case 1: Staff moves the complaint forward
+	res, err := s.TransitionComplaint(ctx, &pb.TransitionComplaintRequest{SecretCode: agent.GetSecretCode(), ComplaintId: complaintRes.GetId(), Status: pb.ComplaintStatus_AWAITING_CUSTOMER, Note: "Need details"})
+	if err != nil {
+		t.Fatalf("Expected no error for a staff transition, but got: %v", err)
+	}
+	if len(res.GetStatusHistory()) != 2 || res.GetStatusHistory()[1].GetChangedBy() != agent.GetId() {
+		t.Errorf("Expected the change to be recorded as made by the agent, but got %v", res.GetStatusHistory())
+	}
+
+	// Test case 2: The owner answers, which moves the complaint back to IN_PROGRESS
+	res, err = s.TransitionComplaint(ctx, &pb.TransitionComplaintRequest{SecretCode: ownerRes.GetSecretCode(), ComplaintId: complaintRes.GetId(), Status: pb.ComplaintStatus_IN_PROGRESS})
+	if err != nil {
+		t.Fatalf("Expected no error for an owner transition, but got: %v", err)
+	}
+	if res.GetStatusHistory()[2].GetChangedBy() != ownerRes.GetId() {
+		t.Errorf("Expected the change to be recorded as made by the owner, but got %v", res.GetStatusHistory()[2])
+	}
+
+	// Test case 3: The owner cannot resolve the complaint themselves, nor start work on an open or acknowledged one
+	_, err = s.TransitionComplaint(ctx, &pb.TransitionComplaintRequest{SecretCode: ownerRes.GetSecretCode(), ComplaintId: complaintRes.GetId(), Status: pb.ComplaintStatus_RESOLVED})
+	if status.Code(err) != codes.PermissionDenied {
+		t.Errorf("Expected PermissionDenied for an owner resolving, but got %v", status.Code(err))
+	}
+	fresh, _ := s.SubmitComplaint(ctx, &pb.SubmitComplaintRequest{SecretCode: ownerRes.GetSecretCode(), Title: "Not started"})
+	_, err = s.TransitionComplaint(ctx, &pb.TransitionComplaintRequest{SecretCode: ownerRes.GetSecretCode(), ComplaintId: fresh.GetId(), Status: pb.ComplaintStatus_IN_PROGRESS})
+	if status.Code(err) != codes.PermissionDenied {
+		t.Errorf("Expected PermissionDenied for an owner moving an open complaint to IN_PROGRESS, but got %v", status.Code(err))
+	}
+	if _, err := s.TransitionComplaint(ctx, &pb.TransitionComplaintRequest{SecretCode: agent.GetSecretCode(), ComplaintId: fresh.GetId(), Status: pb.ComplaintStatus_ACKNOWLEDGED}); err != nil {
+		t.Fatalf("Expected no error acknowledging the complaint, but got: %v", err)
+	}
+	_, err = s.TransitionComplaint(ctx, &pb.TransitionComplaintRequest{SecretCode: ownerRes.GetSecretCode(), ComplaintId: fresh.GetId(), Status: pb.ComplaintStatus_IN_PROGRESS})
+	if status.Code(err) != codes.PermissionDenied {
+		t.Errorf("Expected PermissionDenied for an owner moving an acknowledged complaint to IN_PROGRESS, but got %v", status.Code(err))
+	}
+
+	// Test case 4: Another user cannot touch the complaint
+	_, err = s.TransitionComplaint(ctx, &pb.TransitionComplaintRequest{SecretCode: otherRes.GetSecretCode(), ComplaintId: complaintRes.GetId(), Status: pb.ComplaintStatus_CLOSED})
+	if status.Code(err) != codes.PermissionDenied {
+		t.Errorf("Expected PermissionDenied for a non-owner, but got %v", status.Code(err))
+	}
+
+	// Test case 5: Invalid transitions are rejected
+	_, err = s.TransitionComplaint(ctx, &pb.TransitionComplaintRequest{SecretCode: agent.GetSecretCode(), ComplaintId: complaintRes.GetId(), Status: pb.ComplaintStatus_REOPENED})
+	if status.Code(err) != codes.FailedPrecondition {
+		t.Errorf("Expected FailedPrecondition for reopening an unresolved complaint, but got %v", status.Code(err))
+	}
+	_, err = s.TransitionComplaint(ctx, &pb.TransitionComplaintRequest{SecretCode: agent.GetSecretCode(), ComplaintId: complaintRes.GetId()})
+	if status.Code(err) != codes.InvalidArgument {
+		t.Errorf("Expected InvalidArgument for a missing status, but got %v", status.Code(err))
+	}
+
+	// Test case 6: Resolving keeps the legacy flag in sync, and can be repeated
+	for i := 0; i < 2; i++ {
+		if _, err := s.ResolveComplaint(ctx, &pb.ResolveComplaintRequest{SecretCode: agent.GetSecretCode(), ComplaintId: complaintRes.GetId()}); err != nil {
+			t.Fatalf("Expected no error resolving the complaint, but got: %v", err)
+		}
+	}
+	viewRes, _ := s.ViewComplaint(ctx, &pb.ViewComplaintRequest{SecretCode: ownerRes.GetSecretCode(), ComplaintId: complaintRes.GetId()})
+	if viewRes.GetStatus() != pb.ComplaintStatus_RESOLVED || !viewRes.GetResolved() || len(viewRes.GetStatusHistory()) != 4 {
+		t.Errorf("Expected one RESOLVED entry and the resolved flag set, but got %v", viewRes)
+	}
+}
+
+// TestComments tests the AddComment and ListComments RPC methods.
+func TestComments(t *testing.T) {
+	ctx := context.Background()
+	s := NewServer(testStore, testHasher, testSessions)
+	clearStore(ctx, t)
+
+	// Setup: Register two users, one submits a complaint
+	ownerRes, _ := s.Register(ctx, &pb.RegisterRequest{Name: "Commenter", Email: "commenter@example.com"})
+	otherRes, _ := s.Register(ctx, &pb.RegisterRequest{Name: "Stranger", Email: "stranger@example.com"})
+	complaintRes, _ := s.SubmitComplaint(ctx, &pb.SubmitComplaintRequest{SecretCode: ownerRes.GetSecretCode(), Title: "Discuss me"})
+
+	// Test case 1: The owner posts two comments
+	for _, body := range []string{"Any news?", "Still waiting."} {
+		res, err := s.AddComment(ctx, &pb.AddCommentRequest{SecretCode: ownerRes.GetSecretCode(), ComplaintId: complaintRes.GetId(), Body: body})
+		if err != nil {
+			t.Fatalf("Expected no error adding a comment, but got: %v", err)
+		}
+		if res.GetRole() != pb.CommentRole_CUSTOMER || res.GetAuthorId() != ownerRes.GetId() {
+			t.Errorf("Expected the comment to be authored by the customer, but got %v", res)
+		}
+	}
+
+	// Test case 2: The owner reads the thread in order
+	listRes, err := s.ListComments(ctx, &pb.ListCommentsRequest{SecretCode: ownerRes.GetSecretCode(), ComplaintId: complaintRes.GetId()})
+	if err != nil {
+		t.Fatalf("Expected no error listing comments, but got: %v", err)
+	}
+	if len(listRes.GetComments()) != 2 || listRes.GetComments()[0].GetBody() != "Any news?" {
+		t.Errorf("Expected 2 comments in posting order, but got %v", listRes.GetComments())
+	}
+
+	// Test case 3: Another user can neither read nor post
+	_, err = s.ListComments(ctx, &pb.ListCommentsRequest{SecretCode: otherRes.GetSecretCode(), ComplaintId: complaintRes.GetId()})
+	if status.Code(err) != codes.PermissionDenied {
+		t.Errorf("Expected PermissionDenied for a non-owner reading, but got %v", status.Code(err))
+	}
+	_, err = s.AddComment(ctx, &pb.AddCommentRequest{SecretCode: otherRes.GetSecretCode(), ComplaintId: complaintRes.GetId(), Body: "Hi"})
+	if status.Code(err) != codes.PermissionDenied {
+		t.Errorf("Expected PermissionDenied for a non-owner posting, but got %v", status.Code(err))
+	}
+
+	// Test case 4: Empty comments and unknown complaints are rejected
+	_, err = s.AddComment(ctx, &pb.AddCommentRequest{SecretCode: ownerRes.GetSecretCode(), ComplaintId: complaintRes.GetId(), Body: "  "})
+	if status.Code(err) != codes.InvalidArgument {
+		t.Errorf("Expected InvalidArgument for an empty comment, but got %v", status.Code(err))
+	}
+	_, err = s.ListComments(ctx, &pb.ListCommentsRequest{SecretCode: ownerRes.GetSecretCode(), ComplaintId: "missing"})
+	if status.Code(err) != codes.NotFound {
+		t.Errorf("Expected NotFound for an unknown complaint, but got %v", status.Code(err))
+	}
+
+	// Test case 5: Staff can read any thread and reply as staff
+	agent := createAgent(ctx, t, s)
+	res, err := s.AddComment(ctx, &pb.AddCommentRequest{SecretCode: agent.GetSecretCode(), ComplaintId: complaintRes.GetId(), Body: "Looking into it."})
+	if err != nil {
+		t.Fatalf("Expected no error for a staff comment, but got: %v", err)
+	}
+	if res.GetRole() != pb.CommentRole_STAFF {
+		t.Errorf("Expected the comment to be authored by staff, but got %v", res.GetRole())
+	}
+	listRes, err = s.ListComments(ctx, &pb.ListCommentsRequest{SecretCode: agent.GetSecretCode(), ComplaintId: complaintRes.GetId()})
+	if err != nil || len(listRes.GetComments()) != 3 {
+		t.Errorf("Expected staff to see all 3 comments, but got %v (%v)", listRes.GetComments(), err)
+	}
+}
+
+// TestCreateStaffUser tests the CreateStaffUser RPC method.
+func TestCreateStaffUser(t *testing.T) {
+	ctx := context.Background()
+	s := NewServer(testStore, testHasher, testSessions)
+	clearStore(ctx, t)
+
+	// Test case 1: Create an agent
+	res, err := s.CreateStaffUser(ctx, &pb.CreateStaffUserRequest{Name: "Agent", Email: "agent@example.com", Role: pb.Role_ROLE_AGENT})
+	if err != nil {
+		t.Fatalf("Expected no error creating an agent, but got: %v", err)
+	}
+	if res.GetRole() != pb.Role_ROLE_AGENT || res.GetSecretCode() == "" {
+		t.Errorf("Expected an agent with a secret code, but got %v", res)
+	}
+
+	// Test case 2: Customers cannot be created this way
+	_, err = s.CreateStaffUser(ctx, &pb.CreateStaffUserRequest{Name: "Cust", Email: "cust@example.com", Role: pb.Role_ROLE_CUSTOMER})
+	if status.Code(err) != codes.InvalidArgument {
+		t.Errorf("Expected InvalidArgument for a customer role, but got %v", status.Code(err))
+	}
+
+	// Test case 3: Registered users are customers
+	regRes, _ := s.Register(ctx, &pb.RegisterRequest{Name: "Plain", Email: "plain@example.com"})
+	if regRes.GetRole() != pb.Role_ROLE_CUSTOMER {
+		t.Errorf("Expected a registered user to be a customer, but got %v", regRes.GetRole())
+	}
+}
+
+// TestRotateSecretCode tests the RotateSecretCode RPC method.
+func TestRotateSecretCode(t *testing.T) {
+	ctx := context.Background()
+	s := NewServer(testStore, testHasher, testSessions)
+	clearStore(ctx, t)
+
+	regRes, _ := s.Register(ctx, &pb.RegisterRequest{Name: "Rotator", Email: "rotator@example.com"})
+	loginRes, _ := s.Login(ctx, &pb.LoginRequest{SecretCode: regRes.GetSecretCode()})
+	if err := authorizeSession(ctx, loginRes.GetSession().GetToken()); err != nil {
+		t.Fatalf("Expected the session to be valid before the rotation, but got: %v", err)
+	}
+
+	// Test case 1: The user gets a new code
+	res, err := s.RotateSecretCode(ctx, &pb.RotateSecretCodeRequest{SecretCode: regRes.GetSecretCode()})
+	if err != nil {
+		t.Fatalf("Expected no error rotating the secret code, but got: %v", err)
+	}
+	if res.GetSecretCode() == "" || res.GetSecretCode() == regRes.GetSecretCode() {
+		t.Fatalf("Expected a new secret code, but got %q", res.GetSecretCode())
+	}
+
+	// Test case 2: Only the new code works
+	if _, err := s.Login(ctx, &pb.LoginRequest{SecretCode: regRes.GetSecretCode()}); status.Code(err) != codes.NotFound {
+		t.Errorf("Expected the old code to be refused, but got %v", status.Code(err))
+	}
+	if _, err := s.Login(ctx, &pb.LoginRequest{SecretCode: res.GetSecretCode()}); err != nil {
+		t.Errorf("Expected the new code to work, but got: %v", err)
+	}
+
+	// Test case 3: Sessions issued before the rotation are refused, and cannot be refreshed
+	if err := authorizeSession(ctx, loginRes.GetSession().GetToken()); status.Code(err) != codes.Unauthenticated {
+		t.Errorf("Expected a session issued before the rotation to be refused, but got %v", err)
+	}
+	stale, _ := testSessions.Verify(loginRes.GetSession().GetToken())
+	if _, err := s.RefreshSession(Auth.WithSession(ctx, stale), &pb.RefreshSessionRequest{}); status.Code(err) != codes.Unauthenticated {
+		t.Errorf("Expected a session issued before the rotation not to be refreshed, but got %v", err)
+	}
+	loginRes, _ = s.Login(ctx, &pb.LoginRequest{SecretCode: res.GetSecretCode()})
+	if err := authorizeSession(ctx, loginRes.GetSession().GetToken()); err != nil {
+		t.Errorf("Expected a session issued after the rotation to be valid, but got: %v", err)
+	}
+}
+
+// authorizeSession runs a call made with the session token through the
+// authorization interceptor and returns its error.
+func authorizeSession(ctx context.Context, token string) error {
+	interceptor := Auth.UnaryServerInterceptor(testStore, testHasher, testSessions)
+	ctx = metadata.NewIncomingContext(ctx, metadata.Pairs(Common.AuthorizationMetadata, "Bearer "+token))
+	info := &grpc.UnaryServerInfo{FullMethod: "/complaint.ComplaintService/GetUserComplaints"}
+	_, err := interceptor(ctx, &pb.GetUserComplaintsRequest{}, info, func(ctx context.Context, req interface{}) (interface{}, error) {
+		return nil, nil
+	})
+	return err
+}
+
+// TestResetSecretCode tests the ResetSecretCode and RedeemRecoveryCode RPC methods.
+func TestResetSecretCode(t *testing.T) {
+	ctx := context.Background()
+	s := NewServer(testStore, testHasher, testSessions)
+	clearStore(ctx, t)
+
+	regRes, _ := s.Register(ctx, &pb.RegisterRequest{Name: "Forgetful", Email: "forgetful@example.com"})
+	admin, adminCode, _ := s.CreateAccount(ctx, "Admin", "admin@example.com", Common.RoleAdmin)
+
+	// Test case 1: Resetting an unknown user fails
+	_, err := s.ResetSecretCode(ctx, &pb.ResetSecretCodeRequest{SecretCode: adminCode, UserId: "missing"})
+	if status.Code(err) != codes.NotFound {
+		t.Errorf("Expected NotFound for an unknown user, but got %v", status.Code(err))
+	}
+
+	// Test case 2: The admin resets the code, which disables the old one and the user's sessions
+	loginRes, _ := s.Login(ctx, &pb.LoginRequest{SecretCode: regRes.GetSecretCode()})
+	reset, err := s.ResetSecretCode(ctx, &pb.ResetSecretCodeRequest{SecretCode: adminCode, UserId: regRes.GetId()})
+	if err != nil {
+		t.Fatalf("Expected no error resetting the secret code, but got: %v", err)
+	}
+	if reset.GetRecoveryCode() == "" || !reset.GetExpiresAt().AsTime().After(time.Now()) {
+		t.Fatalf("Expected a recovery code valid in the future, but got %v", reset)
+	}
+	if _, err := s.Login(ctx, &pb.LoginRequest{SecretCode: regRes.GetSecretCode()}); status.Code(err) != codes.NotFound {
+		t.Errorf("Expected the old code to be refused after a reset, but got %v", status.Code(err))
+	}
+	if _, err := s.Login(ctx, &pb.LoginRequest{SecretCode: reset.GetRecoveryCode()}); status.Code(err) != codes.NotFound {
+		t.Errorf("Expected the recovery code not to work as a secret code, but got %v", status.Code(err))
+	}
+	if err := authorizeSession(ctx, loginRes.GetSession().GetToken()); status.Code(err) != codes.Unauthenticated {
+		t.Errorf("Expected a session issued before the reset to be refused, but got %v", err)
+	}
+
+	// Test case 3: Wrong codes and users are refused
+	for _, req := range []*pb.RedeemRecoveryCodeRequest{
+		{UserId: regRes.GetId(), RecoveryCode: "wrong"},
+		{UserId: admin.ID, RecoveryCode: reset.GetRecoveryCode()},
+		{UserId: "missing", RecoveryCode: reset.GetRecoveryCode()},
+	} {
+		if _, err := s.RedeemRecoveryCode(ctx, req); status.Code(err) != codes.Unauthenticated {
+			t.Errorf("Expected Unauthenticated for %v, but got %v", req, status.Code(err))
+		}
+	}
+
+	// Test case 4: The recovery code is exchanged once for a working secret code
+	redeemed, err := s.RedeemRecoveryCode(ctx, &pb.RedeemRecoveryCodeRequest{UserId: regRes.GetId(), RecoveryCode: reset.GetRecoveryCode()})
+	if err != nil {
+		t.Fatalf("Expected no error redeeming the recovery code, but got: %v", err)
+	}
+	if _, err := s.Login(ctx, &pb.LoginRequest{SecretCode: redeemed.GetSecretCode()}); err != nil {
+		t.Errorf("Expected the new secret code to work, but got: %v", err)
+	}
+	_, err = s.RedeemRecoveryCode(ctx, &pb.RedeemRecoveryCodeRequest{UserId: regRes.GetId(), RecoveryCode: reset.GetRecoveryCode()})
+	if status.Code(err) != codes.Unauthenticated {
+		t.Errorf("Expected a used recovery code to be refused, but got %v", status.Code(err))
+	}
+
+	// Test case 5: Expired recovery codes are refused
+	reset, _ = s.ResetSecretCode(ctx, &pb.ResetSecretCodeRequest{SecretCode: adminCode, UserId: regRes.GetId()})
+	testStore.UpdateUser(ctx, regRes.GetId(), func(u *Common.User) error {
+		u.RecoveryExpiresAt = time.Now().Add(-time.Minute)
+		return nil
+	})
+	_, err = s.RedeemRecoveryCode(ctx, &pb.RedeemRecoveryCodeRequest{UserId: regRes.GetId(), RecoveryCode: reset.GetRecoveryCode()})
+	if status.Code(err) != codes.Unauthenticated {
+		t.Errorf("Expected an expired recovery code to be refused, but got %v", status.Code(err))
+	}
+
+}
+
+// TestSearchComplaints tests the SearchComplaints RPC method.
+func TestSearchComplaints(t *testing.T) {
+	ctx := context.Background()
+	clearStore(ctx, t)
+	s := NewServer(testStore, testHasher, testSessions)
+
+	// Setup: Two customers each submit a complaint about a refund
+	alice, _ := s.Register(ctx, &pb.RegisterRequest{Name: "Alice", Email: "alice@example.com"})
+	bob, _ := s.Register(ctx, &pb.RegisterRequest{Name: "Bob", Email: "bob@example.com"})
+	first, _ := s.SubmitComplaint(ctx, &pb.SubmitComplaintRequest{SecretCode: alice.GetSecretCode(), Title: "Refund missing", Summary: "Still waiting for my refund."})
+	_, _ = s.SubmitComplaint(ctx, &pb.SubmitComplaintRequest{SecretCode: bob.GetSecretCode(), Title: "Broken screen", Summary: "Refunded only half the price."})
+	agent := createAgent(ctx, t, s)
+
+	// Test case 1: Staff find every matching complaint, best match first
+	res, err := s.SearchComplaints(ctx, &pb.SearchComplaintsRequest{SecretCode: agent.GetSecretCode(), Query: "refunds"})
+	if err != nil {
+		t.Fatalf("Expected no error for SearchComplaints, but got: %v", err)
+	}
+	if len(res.GetResults()) != 2 || res.GetResults()[0].GetComplaint().GetId() != first.GetId() {
+		t.Fatalf("Expected 2 results with Alice's first, but got %v", res.GetResults())
+	}
+	if got := res.GetResults()[0].GetSnippet(); got != "Still waiting for my **refund**." {
+		t.Errorf("Expected a highlighted snippet, but got %q", got)
+	}
+
+	// Test case 2: Customers only find their own complaints
+	res, _ = s.SearchComplaints(ctx, &pb.SearchComplaintsRequest{SecretCode: bob.GetSecretCode(), Query: "refund"})
+	if len(res.GetResults()) != 1 || res.GetResults()[0].GetComplaint().GetUserId() != bob.GetId() {
+		t.Errorf("Expected only Bob's complaint, but got %v", res.GetResults())
+	}
+
+	// Test case 3: Status changes are reflected in the results
+	_, _ = s.ResolveComplaint(ctx, &pb.ResolveComplaintRequest{SecretCode: agent.GetSecretCode(), ComplaintId: first.GetId()})
+	res, _ = s.SearchComplaints(ctx, &pb.SearchComplaintsRequest{SecretCode: alice.GetSecretCode(), Query: "refund"})
+	if len(res.GetResults()) != 1 || res.GetResults()[0].GetComplaint().GetStatus() != pb.ComplaintStatus_RESOLVED {
+		t.Errorf("Expected the resolved complaint, but got %v", res.GetResults())
+	}
+
+	// Test case 4: A new server indexes existing complaints once built
+	rebuilt := NewServer(testStore, testHasher, testSessions)
+	if n, err := rebuilt.Index.Build(ctx, testStore); err != nil || n != 2 {
+		t.Fatalf("Expected to index 2 complaints, but got %d (err: %v)", n, err)
+	}
+	res, _ = rebuilt.SearchComplaints(ctx, &pb.SearchComplaintsRequest{SecretCode: agent.GetSecretCode(), Query: "screen"})
+	if len(res.GetResults()) != 1 {
+		t.Errorf("Expected 1 result after a rebuild, but got %v", res.GetResults())
+	}
+
+	// Test case 5: Empty queries and negative limits are rejected
+	for _, req := range []*pb.SearchComplaintsRequest{
+		{SecretCode: agent.GetSecretCode(), Query: "  "},
+		{SecretCode: agent.GetSecretCode(), Query: "refund", Limit: -1},
+	} {
+		if _, err := s.SearchComplaints(ctx, req); status.Code(err) != codes.InvalidArgument {
+			t.Errorf("Expected InvalidArgument for %v, but got %v", req, err)
+		}
+	}
+}
+
+// watchStream is a WatchComplaints stream that hands the events sent to a channel.
+type watchStream struct {
+	grpc.ServerStream
+	ctx    context.Context
+	events chan *pb.ComplaintEvent
+}
+
+func (w *watchStream) Context() context.Context { return w.ctx }
+
+func (w *watchStream) Send(e *pb.ComplaintEvent) error {
+	w.events <- e
+	return nil
+}
+
+// watch starts WatchComplaints in the background. The returned function stops
+// it and returns its error.
+func watch(ctx context.Context, s *Server, req *pb.WatchComplaintsRequest) (*watchStream, func() error) {
+	ctx, cancel := context.WithCancel(ctx)
+	stream := &watchStream{ctx: ctx, events: make(chan *pb.ComplaintEvent, 16)}
+	done := make(chan error, 1)
+	go func() { done <- s.WatchComplaints(req, stream) }()
+	return stream, func() error {
+		cancel()
+		return <-done
+	}
+}
+
+// nextEvent returns the next event sent on the stream.
+func nextEvent(t *testing.T, stream *watchStream) *pb.ComplaintEvent {
+	t.Helper()
+	select {
+	case e := <-stream.events:
+		return e
+	case <-time.After(time.Second):
+		t.Fatalf("Expected an event, but none came")
+		return nil
+	}
+}
+
+// TestWatchComplaints tests the WatchComplaints RPC method.
+func TestWatchComplaints(t *testing.T) {
+	ctx := context.Background()
+	clearStore(ctx, t)
+	s := NewServer(testStore, testHasher, testSessions)
+
+	alice, _ := s.Register(ctx, &pb.RegisterRequest{Name: "Alice", Email: "alice@example.com"})
+	bob, _ := s.Register(ctx, &pb.RegisterRequest{Name: "Bob", Email: "bob@example.com"})
+	agent := createAgent(ctx, t, s)
+
+	// Setup: Staff watch everything, Alice her own complaints. Wait for both to
+	// subscribe, as only later events are sent.
+	staffStream, stopStaff := watch(ctx, s, &pb.WatchComplaintsRequest{SecretCode: agent.GetSecretCode()})
+	aliceStream, stopAlice := watch(ctx, s, &pb.WatchComplaintsRequest{SecretCode: alice.GetSecretCode()})
+	for deadline := time.Now().Add(time.Second); s.Events.Subscribers() < 2 && time.Now().Before(deadline); {
+		time.Sleep(time.Millisecond)
+	}
+
+	bobs, _ := s.SubmitComplaint(ctx, &pb.SubmitComplaintRequest{SecretCode: bob.GetSecretCode(), Title: "Bob's"})
+	alices, _ := s.SubmitComplaint(ctx, &pb.SubmitComplaintRequest{SecretCode: alice.GetSecretCode(), Title: "Alice's"})
+	_, _ = s.ResolveComplaint(ctx, &pb.ResolveComplaintRequest{SecretCode: agent.GetSecretCode(), ComplaintId: alices.GetId()})
+
+	// Test case 1: Staff see every event, in order
+	first := nextEvent(t, staffStream)
+	if first.GetType() != pb.ComplaintEventType_COMPLAINT_CREATED || first.GetComplaint().GetId() != bobs.GetId() || first.GetResumeToken() == "" {
+		t.Errorf("Expected the creation of Bob's complaint, but got %v", first)
+	}
+	if e := nextEvent(t, staffStream); e.GetComplaint().GetId() != alices.GetId() {
+		t.Errorf("Expected the creation of Alice's complaint, but got %v", e)
+	}
+	if e := nextEvent(t, staffStream); e.GetType() != pb.ComplaintEventType_COMPLAINT_RESOLVED || e.GetComplaint().GetStatus() != pb.ComplaintStatus_RESOLVED {
+		t.Errorf("Expected Alice's complaint to be resolved, but got %v", e)
+	}
+
+	// Test case 2: Customers only see their own complaints
+	if e := nextEvent(t, aliceStream); e.GetComplaint().GetId() != alices.GetId() || e.GetType() != pb.ComplaintEventType_COMPLAINT_CREATED {
+		t.Errorf("Expected the creation of Alice's complaint, but got %v", e)
+	}
+	if e := nextEvent(t, aliceStream); e.GetType() != pb.ComplaintEventType_COMPLAINT_RESOLVED {
+		t.Errorf("Expected the resolution of Alice's complaint, but got %v", e)
+	}
+	if err := stopAlice(); status.Code(err) != codes.Canceled {
+		t.Errorf("Expected Canceled once the client is gone, but got %v", err)
+	}
+	stopStaff()
+
+	// Test case 3: A reconnecting client resumes after its last event
+	resumed, stopResumed := watch(ctx, s, &pb.WatchComplaintsRequest{SecretCode: agent.GetSecretCode(), ResumeToken: first.GetResumeToken()})
+	if e := nextEvent(t, resumed); e.GetComplaint().GetId() != alices.GetId() || e.GetType() != pb.ComplaintEventType_COMPLAINT_CREATED {
+		t.Errorf("Expected to resume at the creation of Alice's complaint, but got %v", e)
+	}
+	if e := nextEvent(t, resumed); e.GetType() != pb.ComplaintEventType_COMPLAINT_RESOLVED {
+		t.Errorf("Expected the resolution next, but got %v", e)
+	}
+	stopResumed()
+
+	// Test case 4: Bad tokens and other users' complaints are refused
+	tests := []struct {
+		req  *pb.WatchComplaintsRequest
+		want codes.Code
+	}{
+		{&pb.WatchComplaintsRequest{SecretCode: agent.GetSecretCode(), ResumeToken: "garbage"}, codes.InvalidArgument},
+		{&pb.WatchComplaintsRequest{SecretCode: agent.GetSecretCode(), ResumeToken: first.GetResumeToken()[:4]}, codes.InvalidArgument},
+		{&pb.WatchComplaintsRequest{SecretCode: bob.GetSecretCode(), UserId: alice.GetId()}, codes.PermissionDenied},
+	}
+	for _, tt := range tests {
+		if err := s.WatchComplaints(tt.req, &watchStream{ctx: ctx}); status.Code(err) != tt.want {
+			t.Errorf("Expected %v for %v, but got %v", tt.want, tt.req, err)
+		}
+	}
+
+	// Test case 5: Tokens of a previous server have expired
+	restarted := NewServer(testStore, testHasher, testSessions)
+	err := restarted.WatchComplaints(&pb.WatchComplaintsRequest{SecretCode: agent.GetSecretCode(), ResumeToken: first.GetResumeToken()}, &watchStream{ctx: ctx})
+	if status.Code(err) != codes.OutOfRange {
+		t.Errorf("Expected OutOfRange for a token of a previous server, but got %v", err)
+	}
+
+	// Test case 6: Shutting down ends the streams, and refuses new ones
+	_, stopWatching := watch(ctx, s, &pb.WatchComplaintsRequest{SecretCode: agent.GetSecretCode()})
+	for deadline := time.Now().Add(time.Second); s.Events.Subscribers() < 1 && time.Now().Before(deadline); {
+		time.Sleep(time.Millisecond)
+	}
+	s.Shutdown()
+	for deadline := time.Now().Add(time.Second); s.Events.Subscribers() > 0 && time.Now().Before(deadline); {
+		time.Sleep(time.Millisecond)
+	}
+	if err := stopWatching(); status.Code(err) != codes.Unavailable {
+		t.Errorf("Expected Unavailable once the server shuts down, but got %v", err)
+	}
+	err = s.WatchComplaints(&pb.WatchComplaintsRequest{SecretCode: agent.GetSecretCode()}, &watchStream{ctx: ctx})
+	if status.Code(err) != codes.Unavailable {
+		t.Errorf("Expected Unavailable for a new stream, but got %v", err)
+	}
+}
+
+// TestGetComplaintStats tests the GetComplaintStats RPC method.
+func TestGetComplaintStats(t *testing.T) {
+	ctx := context.Background()
+	clearStore(ctx, t)
+	s := NewServer(testStore, testHasher, testSessions)
+
+	// Setup: Alice submits two complaints, one of which is resolved, and Bob one
+	alice, _ := s.Register(ctx, &pb.RegisterRequest{Name: "Alice", Email: "alice@example.com"})
+	bob, _ := s.Register(ctx, &pb.RegisterRequest{Name: "Bob", Email: "bob@example.com"})
+	first, _ := s.SubmitComplaint(ctx, &pb.SubmitComplaintRequest{SecretCode: alice.GetSecretCode(), Title: "First", Severity: 2})
+	_, _ = s.SubmitComplaint(ctx, &pb.SubmitComplaintRequest{SecretCode: alice.GetSecretCode(), Title: "Second", Severity: 4})
+	_, _ = s.SubmitComplaint(ctx, &pb.SubmitComplaintRequest{SecretCode: bob.GetSecretCode(), Title: "Third", Severity: 4})
+	agent := createAgent(ctx, t, s)
+	_, _ = s.ResolveComplaint(ctx, &pb.ResolveComplaintRequest{SecretCode: agent.GetSecretCode(), ComplaintId: first.GetId()})
+	_, adminCode, err := s.CreateAccount(ctx, "Admin", "admin@example.com", Common.RoleAdmin)
+	if err != nil {
+		t.Fatalf("Failed to create admin: %v", err)
+	}
+
+	// Test case 1: By default, the last 30 days are counted in daily buckets
+	res, err := s.GetComplaintStats(ctx, &pb.GetComplaintStatsRequest{SecretCode: adminCode})
+	if err != nil {
+		t.Fatalf("Expected no error for GetComplaintStats, but got: %v", err)
+	}
+	if res.GetTotal() != 3 || res.GetOpen() != 2 || res.GetResolved() != 1 || res.GetMeanTimeToResolution() == nil {
+		t.Errorf("Expected 3 complaints with 1 resolved, but got %v", res)
+	}
+	if window := res.GetUntil().AsTime().Sub(res.GetSince().AsTime()); window != Common.DefaultStatsWindow || len(res.GetBuckets()) < 30 {
+		t.Errorf("Expected a 30 day window in daily buckets, but got %v in %d buckets", window, len(res.GetBuckets()))
+	}
+	if sev := res.GetBySeverity(); len(sev) != 2 || sev[0].GetSeverity() != 2 || sev[1].GetCount() != 2 {
+		t.Errorf("Expected severities [2:1 4:2], but got %v", sev)
+	}
+	if top := res.GetTopSubmitters(); len(top) != 2 || top[0].GetUserName() != "Alice" || top[0].GetCount() != 2 {
+		t.Errorf("Expected Alice to be the top submitter, but got %v", top)
+	}
+
+	// Test case 2: Weekly buckets and a window excluding every complaint
+	res, _ = s.GetComplaintStats(ctx, &pb.GetComplaintStatsRequest{
+		SecretCode: adminCode,
+		Bucketing:  pb.StatsBucketing_WEEKLY,
+		Until:      timestamppb.New(time.Now().Add(-time.Hour)),
+	})
+	if res.GetTotal() != 0 || res.GetMeanTimeToResolution() != nil || len(res.GetBuckets()) > 6 {
+		t.Errorf("Expected no complaints in at most 6 weeks, but got %v", res)
+	}
+
+	// Test case 3: Invalid requests are refused
+	now := time.Now()
+	for _, req := range []*pb.GetComplaintStatsRequest{
+		{SecretCode: adminCode, Since: timestamppb.New(now), Until: timestamppb.New(now)},
+		{SecretCode: adminCode, Since: timestamppb.New(now.AddDate(-5, 0, 0))},
+		{SecretCode: adminCode, Bucketing: pb.StatsBucketing(7)},
+		{SecretCode: adminCode, TopSubmitters: -1},
+	} {
+		if _, err := s.GetComplaintStats(ctx, req); status.Code(err) != codes.InvalidArgument {
+			t.Errorf("Expected InvalidArgument for %v, but got %v", req, err)
+		}
+	}
+}
+
+// exportStream is an ExportComplaints stream that collects the chunks sent.
+type exportStream struct {
+	grpc.ServerStream
+	ctx    context.Context
+	chunks [][]byte
+}
+
+func (e *exportStream) Context() context.Context { return e.ctx }
+
+func (e *exportStream) Send(c *pb.ExportChunk) error {
+	e.chunks = append(e.chunks, c.GetData())
+	return nil
+}
+
+// TestExportComplaints tests the ExportComplaints RPC method.
+func TestExportComplaints(t *testing.T) {
+	ctx := context.Background()
+	clearStore(ctx, t)
+	s := NewServer(testStore, testHasher, testSessions)
+
+	// Setup: Alice submits two complaints and Bob one
+	alice, _ := s.Register(ctx, &pb.RegisterRequest{Name: "Alice", Email: "alice@example.com"})
+	bob, _ := s.Register(ctx, &pb.RegisterRequest{Name: "Bob", Email: "bob@example.com"})
+	_, _ = s.SubmitComplaint(ctx, &pb.SubmitComplaintRequest{SecretCode: alice.GetSecretCode(), Title: "First", Severity: 2})
+	_, _ = s.SubmitComplaint(ctx, &pb.SubmitComplaintRequest{SecretCode: alice.GetSecretCode(), Title: "Second", Severity: 4})
+	_, _ = s.SubmitComplaint(ctx, &pb.SubmitComplaintRequest{SecretCode: bob.GetSecretCode(), Title: "Third", Severity: 5})
+	_, adminCode, err := s.CreateAccount(ctx, "Admin", "admin@example.com", Common.RoleAdmin)
+	if err != nil {
+		t.Fatalf("Failed to create admin: %v", err)
+	}
+	export := func(req *pb.ExportComplaintsRequest) (string, error) {
+		stream := &exportStream{ctx: ctx}
+		err := s.ExportComplaints(req, stream)
+		var out strings.Builder
+		for _, c := range stream.chunks {
+			out.Write(c)
+		}
+		return out.String(), err
+	}
+
+	// Test case 1: A CSV export of the selected columns, joined with the users
+	out, err := export(&pb.ExportComplaintsRequest{
+		SecretCode: adminCode,
+		Sort:       pb.ComplaintSort_OLDEST_FIRST,
+		Columns:    []string{"title", "user_name", "user_email"},
+	})
+	if err != nil {
+		t.Fatalf("Expected no error for ExportComplaints, but got: %v", err)
+	}
+	want := "title,user_name,user_email\nFirst,Alice,alice@example.com\nSecond,Alice,alice@example.com\nThird,Bob,bob@example.com\n"
+	if out != want {
+		t.Errorf("Expected %q, but got %q", want, out)
+	}
+
+	// Test case 2: Listing filters and the user apply, in NDJSON
+	out, err = export(&pb.ExportComplaintsRequest{
+		SecretCode: adminCode,
+		UserId:     alice.GetId(),
+		Filter:     &pb.ComplaintFilter{MinSeverity: 3},
+		Format:     pb.ExportFormat_NDJSON,
+		Columns:    []string{"title", "severity"},
+	})
+	if err != nil || out != "{\"title\":\"Second\",\"severity\":4}\n" {
+		t.Errorf("Expected Alice's second complaint, but got %q (err: %v)", out, err)
+	}
+
+	// Test case 3: Invalid requests are refused
+	for _, req := range []*pb.ExportComplaintsRequest{
+		{SecretCode: adminCode, Columns: []string{"secret_code"}},
+		{SecretCode: adminCode, Format: pb.ExportFormat(9)},
+		{SecretCode: adminCode, Filter: &pb.ComplaintFilter{MinSeverity: 4, MaxSeverity: 2}},
+	} {
+		if _, err := export(req); status.Code(err) != codes.InvalidArgument {
+			t.Errorf("Expected InvalidArgument for %v, but got %v", req, err)
+		}
+	}
+}
+
+// TestChunkWriter checks that exports are sent in chunks of at most
+// Common.ExportChunkSize bytes.
+func TestChunkWriter(t *testing.T) {
+	var chunks [][]byte
+	w := &chunkWriter{size: Common.ExportChunkSize, send: func(data []byte) error {
+		chunks = append(chunks, append([]byte(nil), data...))
+		return nil
+	}}
+	_, _ = w.Write([]byte(strings.Repeat("a", Common.ExportChunkSize-1)))
+	_, _ = w.Write([]byte(strings.Repeat("b", Common.ExportChunkSize+2)))
+	if err := w.flush(); err != nil {
+		t.Fatalf("Expected no error, but got %v", err)
+	}
+	if len(chunks) != 3 || len(chunks[0]) != Common.ExportChunkSize || len(chunks[1]) != Common.ExportChunkSize || len(chunks[2]) != 1 {
+		t.Errorf("Expected chunks of %d, %d and 1 bytes, but got %d chunks", Common.ExportChunkSize, Common.ExportChunkSize, len(chunks))
+	}
+	if chunks[0][len(chunks[0])-1] != 'b' || chunks[2][0] != 'b' {
+		t.Errorf("Expected the chunks to follow the written bytes")
+	}
+}
+
+// TestNotifications checks that complaint owners are emailed about their
+// complaints once notifications are enabled.
+func TestNotifications(t *testing.T) {
+	ctx := context.Background()
+	clearStore(ctx, t)
+	s := NewServer(testStore, testHasher, testSessions)
+	sender := &Notify.MemorySender{}
+	notifier := Notify.NewNotifier(testStore, sender, Notify.DefaultTemplates(), Notify.Options{Backoff: time.Millisecond})
+	defer notifier.Close()
+	s.EnableNotifications(notifier)
+
+	// Setup: Alice submits a complaint, which an agent comments on and resolves
+	alice, _ := s.Register(ctx, &pb.RegisterRequest{Name: "Alice", Email: "alice@example.com"})
+	agent := createAgent(ctx, t, s)
+	complaint, err := s.SubmitComplaint(ctx, &pb.SubmitComplaintRequest{SecretCode: alice.GetSecretCode(), Title: "No signal"})
+	if err != nil {
+		t.Fatalf("Expected no error for SubmitComplaint, but got: %v", err)
+	}
+	_, _ = s.AddComment(ctx, &pb.AddCommentRequest{SecretCode: alice.GetSecretCode(), ComplaintId: complaint.GetId(), Body: "Still nothing"})
+	_, _ = s.AddComment(ctx, &pb.AddCommentRequest{SecretCode: agent.GetSecretCode(), ComplaintId: complaint.GetId(), Body: "Checking the tower"})
+	_, _ = s.ResolveComplaint(ctx, &pb.ResolveComplaintRequest{SecretCode: agent.GetSecretCode(), ComplaintId: complaint.GetId()})
+	drainCtx, cancel := context.WithTimeout(ctx, 2*time.Second)
+	defer cancel()
+	if err := notifier.Drain(drainCtx); err != nil {
+		t.Fatalf("Expected the notifications to be sent, but got %v", err)
+	}
+
+	// Test case 1: Alice is told about the submission, the agent's comment and the resolution, not her own comment
+	msgs := sender.Messages()
+	if len(msgs) != 3 {
+		t.Fatalf("Expected 3 messages, but got %+v", msgs)
+	}
+	for _, m := range msgs {
+		if m.To != "alice@example.com" || !strings.Contains(m.Subject, "No signal") {
+			t.Errorf("Expected a message to Alice about her complaint, but got %+v", m)
+		}
+	}
+	if !strings.Contains(msgs[1].Body, "Checking the tower") || !strings.Contains(msgs[2].Subject, "Resolved") {
+		t.Errorf("Expected the comment then the resolution, but got %+v", msgs[1:])
+	}
+}
+
+// TestWebhooks tests the webhook RPC methods.
+func TestWebhooks(t *testing.T) {
+	ctx := context.Background()
+	clearStore(ctx, t)
+	s := NewServer(testStore, testHasher, testSessions)
+	dispatcher := Webhook.NewDispatcher(testStore, &http.Client{}, Webhook.Options{Backoff: time.Millisecond})
+	defer dispatcher.Close()
+	s.EnableWebhooks(dispatcher)
+
+	// Setup: a receiver recording the bodies it verified
+	var secret string
+	received := make(chan string, 10)
+	receiver := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+		if err := Webhook.Verify(secret, r.Header, body, time.Now(), time.Minute); err != nil {
+			http.Error(w, err.Error(), http.StatusUnauthorized)
+			return
+		}
+		received <- r.Header.Get(Webhook.HeaderEvent)
+	}))
+	defer receiver.Close()
+	_, adminCode, _ := s.CreateAccount(ctx, "Admin", "admin@example.com", Common.RoleAdmin)
+	alice, _ := s.Register(ctx, &pb.RegisterRequest{Name: "Alice", Email: "alice@example.com"})
+
+	// Test case 1: Creating a webhook returns its secret, which listing does not
+	webhook, err := s.CreateWebhook(ctx, &pb.CreateWebhookRequest{SecretCode: adminCode, Url: receiver.URL, Events: []string{Webhook.EventCreated, Webhook.EventCreated}})
+	if err != nil {
+		t.Fatalf("Expected no error for CreateWebhook, but got: %v", err)
+	}
+	if webhook.GetSecret() == "" || len(webhook.GetEvents()) != 1 {
+		t.Fatalf("Expected a secret and one event, but got %v", webhook)
+	}
+	secret = webhook.GetSecret()
+	list, _ := s.ListWebhooks(ctx, &pb.ListWebhooksRequest{SecretCode: adminCode})
+	if len(list.GetWebhooks()) != 1 || list.GetWebhooks()[0].GetSecret() != "" {
+		t.Errorf("Expected the webhook without its secret, but got %v", list.GetWebhooks())
+	}
+
+	// Test case 2: Invalid URLs and events are refused
+	for _, req := range []*pb.CreateWebhookRequest{
+		{SecretCode: adminCode, Url: "ftp://example.com", Events: []string{Webhook.EventCreated}},
+		{SecretCode: adminCode, Url: receiver.URL, Events: []string{"complaint.deleted"}},
+		{SecretCode: adminCode, Url: receiver.URL},
+	} {
+		if _, err := s.CreateWebhook(ctx, req); status.Code(err) != codes.InvalidArgument {
+			t.Errorf("Expected InvalidArgument for %v, but got %v", req, status.Code(err))
+		}
+	}
+
+	// Test case 3: A new complaint is delivered, signed, and logged as succeeded
+	if _, err := s.SubmitComplaint(ctx, &pb.SubmitComplaintRequest{SecretCode: alice.GetSecretCode(), Title: "No signal"}); err != nil {
+		t.Fatalf("Expected no error for SubmitComplaint, but got: %v", err)
+	}
+	select {
+	case event := <-received:
+		if event != Webhook.EventCreated {
+			t.Errorf("Expected a %s delivery, but got %s", Webhook.EventCreated, event)
+		}
+	case <-time.After(2 * time.Second):
+		t.Fatal("Expected the complaint to be delivered")
+	}
+	drainCtx, cancel := context.WithTimeout(ctx, 2*time.Second)
+	defer cancel()
+	if err := dispatcher.Drain(drainCtx); err != nil {
+		t.Fatalf("Expected the deliveries to finish, but got %v", err)
+	}
+	deliveries, err := s.ListWebhookDeliveries(ctx, &pb.ListWebhookDeliveriesRequest{SecretCode: adminCode, WebhookId: webhook.GetId()})
+	if err != nil {
+		t.Fatalf("Expected no error for ListWebhookDeliveries, but got: %v", err)
+	}
+	if len(deliveries.GetDeliveries()) != 1 {
+		t.Fatalf("Expected 1 delivery, but got %v", deliveries.GetDeliveries())
+	}
+	delivery := deliveries.GetDeliveries()[0]
+	if delivery.GetStatus() != pb.WebhookDeliveryStatus_DELIVERY_SUCCEEDED || delivery.GetResponseCode() != http.StatusOK || delivery.GetAttempts() != 1 {
+		t.Errorf("Expected a delivery succeeded on the first attempt, but got %v", delivery)
+	}
+
+	// Test case 4: A delivery can be sent again, as a new delivery of the same payload
+	again, err := s.RedeliverWebhook(ctx, &pb.RedeliverWebhookRequest{SecretCode: adminCode, DeliveryId: delivery.GetId()})
+	if err != nil {
+		t.Fatalf("Expected no error for RedeliverWebhook, but got: %v", err)
+	}
+	if again.GetId() == delivery.GetId() || again.GetPayload() != delivery.GetPayload() {
+		t.Errorf("Expected a new delivery of the same payload, but got %v", again)
+	}
+	select {
+	case <-received:
+	case <-time.After(2 * time.Second):
+		t.Fatal("Expected the delivery to be sent again")
+	}
+	dispatcher.Drain(drainCtx)
+	failed := pb.WebhookDeliveryStatus_DELIVERY_FAILED
+	deliveries, _ = s.ListWebhookDeliveries(ctx, &pb.ListWebhookDeliveriesRequest{SecretCode: adminCode, Status: &failed})
+	if len(deliveries.GetDeliveries()) != 0 {
+		t.Errorf("Expected no failed deliveries, but got %v", deliveries.GetDeliveries())
+	}
+	_, err = s.RedeliverWebhook(ctx, &pb.RedeliverWebhookRequest{SecretCode: adminCode, DeliveryId: "missing"})
+	if status.Code(err) != codes.NotFound {
+		t.Errorf("Expected NotFound for an unknown delivery, but got %v", status.Code(err))
+	}
+
+	// Test case 5: A negative limit is refused
+	_, err = s.ListWebhookDeliveries(ctx, &pb.ListWebhookDeliveriesRequest{SecretCode: adminCode, Limit: -1})
+	if status.Code(err) != codes.InvalidArgument {
+		t.Errorf("Expected InvalidArgument for a negative limit, but got %v", status.Code(err))
+	}
+
+	// Test case 6: Deleted webhooks are gone, and their deliveries cannot be sent again
+	if _, err := s.DeleteWebhook(ctx, &pb.DeleteWebhookRequest{SecretCode: adminCode, WebhookId: webhook.GetId()}); err != nil {
+		t.Fatalf("Expected no error for DeleteWebhook, but got: %v", err)
+	}
+	_, err = s.DeleteWebhook(ctx, &pb.DeleteWebhookRequest{SecretCode: adminCode, WebhookId: webhook.GetId()})
+	if status.Code(err) != codes.NotFound {
+		t.Errorf("Expected NotFound deleting the webhook twice, but got %v", status.Code(err))
+	}
+	_, err = s.RedeliverWebhook(ctx, &pb.RedeliverWebhookRequest{SecretCode: adminCode, DeliveryId: delivery.GetId()})
+	if status.Code(err) != codes.FailedPrecondition {
+		t.Errorf("Expected FailedPrecondition redelivering to a deleted webhook, but got %v", status.Code(err))
+	}
+}
+
+// TestQueryAuditLog tests the QueryAuditLog RPC method.
+func TestQueryAuditLog(t *testing.T) {
+	ctx := context.Background()
+	s := NewServer(testStore, testHasher, testSessions)
+	clearStore(ctx, t)
+
+	_, adminCode, _ := s.CreateAccount(ctx, "Auditor", "auditor@example.com", Common.RoleAdmin)
+	at := time.Date(2024, 3, 1, 9, 0, 0, 0, time.UTC)
+	for i, actor := range []string{"u1", "u2", "u1"} {
+		testStore.AddAuditEntry(ctx, Common.AuditEntry{
+			ID: "e" + strconv.Itoa(i), At: at.Add(time.Duration(i) * time.Hour), ActorID: actor, Action: Common.AuditComplaintStatus, TargetID: "c1",
+			Method: "/complaint.ComplaintService/TransitionComplaint", ClientAddr: "192.0.2.7",
+			Changes: []Common.AuditChange{{Field: "Status", Before: "Open", After: "InProgress"}},
+		})
+	}
+
+	// Test case 1: Every entry is returned, oldest first, with its changes
+	res, err := s.QueryAuditLog(ctx, &pb.QueryAuditLogRequest{SecretCode: adminCode})
+	if err != nil {
+		t.Fatalf("Expected no error querying the audit log, but got: %v", err)
+	}
+	if len(res.GetEntries()) != 3 || res.GetEntries()[0].GetId() != "e0" || !res.GetEntries()[2].GetAt().AsTime().Equal(at.Add(2*time.Hour)) {
+		t.Fatalf("Expected the 3 entries oldest first, but got %v", res.GetEntries())
+	}
+	first := res.GetEntries()[0]
+	if first.GetClientAddr() != "192.0.2.7" || len(first.GetChanges()) != 1 || first.GetChanges()[0].GetAfter() != "InProgress" {
+		t.Errorf("Expected the entry's address and changes, but got %v", first)
+	}
+
+	// Test case 2: Filters by actor, target and time range are combined
+	res, _ = s.QueryAuditLog(ctx, &pb.QueryAuditLogRequest{SecretCode: adminCode, ActorId: "u1", TargetId: "c1", Since: timestamppb.New(at.Add(time.Minute))})
+	if len(res.GetEntries()) != 1 || res.GetEntries()[0].GetId() != "e2" {
+		t.Errorf("Expected only e2, but got %v", res.GetEntries())
+	}
+	res, _ = s.QueryAuditLog(ctx, &pb.QueryAuditLogRequest{SecretCode: adminCode, Until: timestamppb.New(at.Add(time.Hour))})
+	if len(res.GetEntries()) != 1 || res.GetEntries()[0].GetId() != "e0" {
+		t.Errorf("Expected only e0, but got %v", res.GetEntries())
+	}
+
+	// Test case 3: An empty time range is refused
+	_, err = s.QueryAuditLog(ctx, &pb.QueryAuditLogRequest{SecretCode: adminCode, Since: timestamppb.New(at), Until: timestamppb.New(at)})
+	if status.Code(err) != codes.InvalidArgument {
+		t.Errorf("Expected InvalidArgument for an empty time range, but got %v", status.Code(err))
+	}
+}
+
+// createAgent creates a staff user for tests that act on behalf of support,
+// and returns it with its secret code.
+func createAgent(ctx context.Context, t *testing.T, s *Server) *pb.User {
+	t.Helper()
+	user, secretCode, err := s.CreateAccount(ctx, "Agent", "agent-"+Common.GenerateID()+"@example.com", Common.RoleAgent)
+	if err != nil {
+		t.Fatalf("Failed to create agent: %v", err)
+	}
+	agent := toPBUser(user)
+	agent.SecretCode = secretCode
+	return agent
+}
+
+// TestFirestoreBackfillStatuses checks that complaints written before the status
+// lifecycle are found by status filters once backfilled. It needs the emulator.
+func TestFirestoreBackfillStatuses(t *testing.T) {
+	fs, ok := testStore.(*Storage.FirestoreStore)
+	if !ok {
+		t.Skip("Only the Firestore backend stores complaints without a status")
+	}
+	ctx := context.Background()
+	clearStore(ctx, t)
+
+	// Setup: Two legacy documents that only carry the Resolved flag
+	for id, resolved := range map[string]bool{"legacy-open": false, "legacy-resolved": true} {
+		doc := map[string]interface{}{"ID": id, "UserID": "u1", "Title": id, "Severity": 1, "Resolved": resolved}
+		if _, err := firestoreClient.Collection(Common.ComplaintsCollection).Doc(id).Set(ctx, doc); err != nil {
+			t.Fatalf("Failed to write a legacy complaint: %v", err)
+		}
+	}
+	if _, err := fs.BackfillTimestamps(ctx); err != nil {
+		t.Fatalf("Expected no error backfilling timestamps, but got: %v", err)
+	}
+
+	// Test case 1: Each complaint gets the status it is read with
+	if n, err := fs.BackfillStatuses(ctx); err != nil || n != 2 {
+		t.Fatalf("Expected 2 complaints to be backfilled, but got %d (err: %v)", n, err)
+	}
+	for status, id := range map[Common.ComplaintStatus]string{Common.StatusOpen: "legacy-open", Common.StatusResolved: "legacy-resolved"} {
+		got, err := fs.QueryComplaints(ctx, Storage.ComplaintQuery{Statuses: []Common.ComplaintStatus{status}})
+		if err != nil || len(got) != 1 || got[0].ID != id {
+			t.Errorf("Expected %s to be found as %s, but got %v (err: %v)", id, status, got, err)
+		}
+	}
+
+	// Test case 2: Running it again changes nothing
+	if n, err := fs.BackfillStatuses(ctx); err != nil || n != 0 {
+		t.Errorf("Expected nothing left to backfill, but got %d (err: %v)", n, err)
+	}
 }
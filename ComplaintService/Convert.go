@@ -0,0 +1,213 @@
+// ComplaintService/Convert.go
+package ComplaintService
+
+import (
+	"complaint-portal/Auth"
+	"complaint-portal/Common"
+	"complaint-portal/Events"
+	pb "complaint-portal/Generated/ComplaintService"
+	"time"
+
+	"google.golang.org/protobuf/types/known/durationpb"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+// toPBUser converts a stored user into its protobuf representation.
+// Only the hash of the secret code is stored, so it is never part of it.
+func toPBUser(user Common.User) *pb.User {
+	return &pb.User{
+		Id:           user.ID,
+		Name:         user.Name,
+		Email:        user.Email,
+		ComplaintIds: user.Complaints,
+		Role:         toPBRole(user.EffectiveRole()),
+		CreatedAt:    toPBTimestamp(user.CreatedAt),
+		UpdatedAt:    toPBTimestamp(user.UpdatedAt),
+	}
+}
+
+// toPBSession converts a session into its protobuf representation.
+func toPBSession(session Auth.Session) *pb.Session {
+	return &pb.Session{
+		Token:     session.Token,
+		ExpiresAt: toPBTimestamp(session.ExpiresAt),
+	}
+}
+
+// toPBRole converts a stored role into its protobuf enum value.
+func toPBRole(role Common.Role) pb.Role {
+	switch role {
+	case Common.RoleAgent:
+		return pb.Role_ROLE_AGENT
+	case Common.RoleAdmin:
+		return pb.Role_ROLE_ADMIN
+	default:
+		return pb.Role_ROLE_CUSTOMER
+	}
+}
+
+// fromPBRole converts a protobuf role into the stored one, or "" if it is unspecified.
+func fromPBRole(role pb.Role) Common.Role {
+	switch role {
+	case pb.Role_ROLE_CUSTOMER:
+		return Common.RoleCustomer
+	case pb.Role_ROLE_AGENT:
+		return Common.RoleAgent
+	case pb.Role_ROLE_ADMIN:
+		return Common.RoleAdmin
+	default:
+		return ""
+	}
+}
+
+// toPBComplaint converts a stored complaint into its protobuf representation.
+func toPBComplaint(complaint Common.Complaint) *pb.Complaint {
+	c := &pb.Complaint{
+		Id:         complaint.ID,
+		Title:      complaint.Title,
+		Summary:    complaint.Summary,
+		Severity:   int32(complaint.Severity),
+		UserId:     complaint.UserID,
+		Resolved:   complaint.Resolved,
+		Status:     toPBStatus(complaint.CurrentStatus()),
+		CreatedAt:  toPBTimestamp(complaint.CreatedAt),
+		UpdatedAt:  toPBTimestamp(complaint.UpdatedAt),
+		ResolvedAt: toPBTimestamp(complaint.ResolvedAt),
+	}
+	for _, change := range complaint.StatusHistory {
+		c.StatusHistory = append(c.StatusHistory, &pb.StatusChange{
+			From:      toPBStatus(change.From),
+			To:        toPBStatus(change.To),
+			ChangedBy: change.ChangedBy,
+			ChangedAt: toPBTimestamp(change.ChangedAt),
+			Note:      change.Note,
+		})
+	}
+	return c
+}
+
+// toPBComment converts a stored comment into its protobuf representation.
+func toPBComment(comment Common.Comment) *pb.Comment {
+	role := pb.CommentRole_CUSTOMER
+	if comment.Role == Common.CommentByStaff {
+		role = pb.CommentRole_STAFF
+	}
+	return &pb.Comment{
+		Id:          comment.ID,
+		ComplaintId: comment.ComplaintID,
+		AuthorId:    comment.AuthorID,
+		Role:        role,
+		Body:        comment.Body,
+		CreatedAt:   toPBTimestamp(comment.CreatedAt),
+	}
+}
+
+// toPBAuditEntry converts a stored audit entry into its protobuf representation.
+func toPBAuditEntry(entry Common.AuditEntry) *pb.AuditEntry {
+	e := &pb.AuditEntry{
+		Id:         entry.ID,
+		At:         toPBTimestamp(entry.At),
+		ActorId:    entry.ActorID,
+		Action:     entry.Action,
+		TargetId:   entry.TargetID,
+		Method:     entry.Method,
+		ClientAddr: entry.ClientAddr,
+	}
+	for _, change := range entry.Changes {
+		e.Changes = append(e.Changes, &pb.AuditChange{Field: change.Field, Before: change.Before, After: change.After})
+	}
+	return e
+}
+
+// toPBWebhook converts a webhook, leaving its secret out.
+func toPBWebhook(webhook Common.Webhook) *pb.Webhook {
+	return &pb.Webhook{
+		Id:        webhook.ID,
+		Url:       webhook.URL,
+		Events:    webhook.Events,
+		CreatedBy: webhook.CreatedBy,
+		CreatedAt: toPBTimestamp(webhook.CreatedAt),
+	}
+}
+
+// deliveryStatusToPB maps delivery statuses to their protobuf enum values.
+var deliveryStatusToPB = map[Common.DeliveryStatus]pb.WebhookDeliveryStatus{
+	Common.DeliveryPending:   pb.WebhookDeliveryStatus_DELIVERY_PENDING,
+	Common.DeliverySucceeded: pb.WebhookDeliveryStatus_DELIVERY_SUCCEEDED,
+	Common.DeliveryFailed:    pb.WebhookDeliveryStatus_DELIVERY_FAILED,
+}
+
+func toPBDelivery(d Common.WebhookDelivery) *pb.WebhookDelivery {
+	return &pb.WebhookDelivery{
+		Id:            d.ID,
+		WebhookId:     d.WebhookID,
+		Event:         d.Event,
+		Payload:       d.Payload,
+		Status:        deliveryStatusToPB[d.Status],
+		Attempts:      int32(d.Attempts),
+		ResponseCode:  int32(d.ResponseCode),
+		Error:         d.Error,
+		CreatedAt:     toPBTimestamp(d.CreatedAt),
+		LastAttemptAt: toPBTimestamp(d.LastAttemptAt),
+	}
+}
+
+// eventTypeToPB maps event types to their protobuf enum values.
+var eventTypeToPB = map[Events.Type]pb.ComplaintEventType{
+	Events.Created:  pb.ComplaintEventType_COMPLAINT_CREATED,
+	Events.Updated:  pb.ComplaintEventType_COMPLAINT_UPDATED,
+	Events.Resolved: pb.ComplaintEventType_COMPLAINT_RESOLVED,
+}
+
+// toPBEvent converts a complaint event into its protobuf representation.
+func toPBEvent(e Events.Event) *pb.ComplaintEvent {
+	return &pb.ComplaintEvent{
+		Type:        eventTypeToPB[e.Type],
+		Complaint:   toPBComplaint(e.Complaint),
+		At:          toPBTimestamp(e.At),
+		ResumeToken: e.Token,
+	}
+}
+
+// statusToPB maps stored statuses to their protobuf enum values.
+var statusToPB = map[Common.ComplaintStatus]pb.ComplaintStatus{
+	Common.StatusOpen:             pb.ComplaintStatus_OPEN,
+	Common.StatusAcknowledged:     pb.ComplaintStatus_ACKNOWLEDGED,
+	Common.StatusInProgress:       pb.ComplaintStatus_IN_PROGRESS,
+	Common.StatusAwaitingCustomer: pb.ComplaintStatus_AWAITING_CUSTOMER,
+	Common.StatusResolved:         pb.ComplaintStatus_RESOLVED,
+	Common.StatusReopened:         pb.ComplaintStatus_REOPENED,
+	Common.StatusClosed:           pb.ComplaintStatus_CLOSED,
+}
+
+// toPBStatus converts a stored status into its protobuf enum value.
+func toPBStatus(s Common.ComplaintStatus) pb.ComplaintStatus {
+	return statusToPB[s]
+}
+
+// fromPBStatus converts a protobuf status into the stored one.
+// It returns false for UNSPECIFIED and unknown values.
+func fromPBStatus(s pb.ComplaintStatus) (Common.ComplaintStatus, bool) {
+	for status, value := range statusToPB {
+		if value == s {
+			return status, true
+		}
+	}
+	return "", false
+}
+
+// toPBTimestamp converts a time into a protobuf timestamp, leaving the zero time unset.
+func toPBTimestamp(t time.Time) *timestamppb.Timestamp {
+	if t.IsZero() {
+		return nil
+	}
+	return timestamppb.New(t)
+}
+
+// toPBDuration converts a duration into a protobuf one, leaving 0 unset.
+func toPBDuration(d time.Duration) *durationpb.Duration {
+	if d == 0 {
+		return nil
+	}
+	return durationpb.New(d)
+}
@@ -0,0 +1,83 @@
+// ComplaintService/Export.go
+package ComplaintService
+
+import (
+	"complaint-portal/Common"
+	"complaint-portal/Export"
+	pb "complaint-portal/Generated/ComplaintService"
+	"log/slog"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// formatFromPB maps protobuf export formats to the Export package's.
+var formatFromPB = map[pb.ExportFormat]Export.Format{
+	pb.ExportFormat_CSV:    Export.CSV,
+	pb.ExportFormat_NDJSON: Export.NDJSON,
+}
+
+// ExportComplaints implements the ExportComplaints RPC method.
+// It streams the selected complaints as a file, in chunks of at most
+// Limits.ExportChunkSize bytes, without building the file in memory. The
+// interceptor only lets admins call it.
+func (s *Server) ExportComplaints(req *pb.ExportComplaintsRequest, stream pb.ComplaintService_ExportComplaintsServer) error {
+	slog.DebugContext(stream.Context(), Common.LogReceivedExport)
+	ctx := stream.Context()
+
+	if _, err := s.authenticate(ctx, req.GetSecretCode()); err != nil {
+		return err
+	}
+
+	query, err := filterQuery(req.GetFilter(), req.GetSort())
+	if err != nil {
+		return err
+	}
+	query.UserID = req.GetUserId()
+	format, ok := formatFromPB[req.GetFormat()]
+	if !ok {
+		return status.Errorf(codes.InvalidArgument, Common.ErrInvalidExportFormat)
+	}
+	columns, err := Export.ParseColumns(req.GetColumns())
+	if err != nil {
+		return status.Errorf(codes.InvalidArgument, "%v", err)
+	}
+
+	w := &chunkWriter{size: s.Limits.ExportChunkSize, send: func(data []byte) error { return stream.Send(&pb.ExportChunk{Data: data}) }}
+	if _, err := Export.Write(ctx, s.Store, query, w, format, columns); err != nil {
+		// Sending only fails once the client is gone.
+		if ctx.Err() != nil {
+			return status.FromContextError(ctx.Err()).Err()
+		}
+		return status.Errorf(codes.Internal, "Failed to export complaints: %v", err)
+	}
+	return w.flush()
+}
+
+// chunkWriter sends what is written to it in chunks of size bytes, and the
+// rest on flush.
+type chunkWriter struct {
+	size int
+	send func([]byte) error
+	buf  []byte
+}
+
+func (w *chunkWriter) Write(p []byte) (int, error) {
+	w.buf = append(w.buf, p...)
+	for len(w.buf) >= w.size {
+		if err := w.send(w.buf[:w.size]); err != nil {
+			return 0, err
+		}
+		w.buf = append([]byte(nil), w.buf[w.size:]...)
+	}
+	return len(p), nil
+}
+
+func (w *chunkWriter) flush() error {
+	if len(w.buf) == 0 {
+		return nil
+	}
+	err := w.send(w.buf)
+	w.buf = nil
+	return err
+}
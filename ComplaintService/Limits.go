@@ -0,0 +1,48 @@
+// ComplaintService/Limits.go
+package ComplaintService
+
+import (
+	"complaint-portal/Common"
+	"time"
+)
+
+// Limits bound what a single request may ask for. Sizes the client leaves
+// unset get the default, and larger ones are cut down to the maximum.
+type Limits struct {
+	DefaultPageSize int
+	MaxPageSize     int
+
+	DefaultSearchLimit int
+	MaxSearchLimit     int
+
+	// DefaultStatsWindow is how far back complaint statistics go when the
+	// request sets no start.
+	DefaultStatsWindow   time.Duration
+	DefaultTopSubmitters int
+	MaxTopSubmitters     int
+
+	// ExportChunkSize is the size of the chunks ExportComplaints streams.
+	ExportChunkSize int
+
+	DefaultDeliveryLimit int
+	MaxDeliveryLimit     int
+
+	// RecoveryCodeTTL is how long the recovery codes issued by ResetSecretCode
+	// stay valid.
+	RecoveryCodeTTL time.Duration
+}
+
+// DefaultLimits are the limits of a new Server.
+var DefaultLimits = Limits{
+	DefaultPageSize:      Common.DefaultPageSize,
+	MaxPageSize:          Common.MaxPageSize,
+	DefaultSearchLimit:   Common.DefaultSearchLimit,
+	MaxSearchLimit:       Common.MaxSearchLimit,
+	DefaultStatsWindow:   Common.DefaultStatsWindow,
+	DefaultTopSubmitters: Common.DefaultTopSubmitters,
+	MaxTopSubmitters:     Common.MaxTopSubmitters,
+	ExportChunkSize:      Common.ExportChunkSize,
+	DefaultDeliveryLimit: Common.DefaultDeliveryLimit,
+	MaxDeliveryLimit:     Common.MaxDeliveryLimit,
+	RecoveryCodeTTL:      Common.RecoveryCodeTTL,
+}
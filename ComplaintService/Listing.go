@@ -0,0 +1,154 @@
+// ComplaintService/Listing.go
+package ComplaintService
+
+import (
+	"complaint-portal/Common"
+	pb "complaint-portal/Generated/ComplaintService"
+	"complaint-portal/Storage"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"time"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// listing is a page request shared by the complaint listings.
+type listing interface {
+	GetFilter() *pb.ComplaintFilter
+	GetSort() pb.ComplaintSort
+	GetPageSize() int32
+	GetPageToken() string
+}
+
+// sortFromPB maps protobuf sort orders to the store's.
+var sortFromPB = map[pb.ComplaintSort]Storage.ComplaintSort{
+	pb.ComplaintSort_NEWEST_FIRST:           Storage.NewestFirst,
+	pb.ComplaintSort_OLDEST_FIRST:           Storage.OldestFirst,
+	pb.ComplaintSort_HIGHEST_SEVERITY_FIRST: Storage.HighestSeverityFirst,
+	pb.ComplaintSort_LOWEST_SEVERITY_FIRST:  Storage.LowestSeverityFirst,
+}
+
+// complaintQuery validates a listing request and turns it into a store query.
+// The query asks for one complaint more than the page size, so that nextPage can
+// tell whether another page follows. Page sizes are bounded by limits.
+func complaintQuery(req listing, limits Limits) (Storage.ComplaintQuery, error) {
+	query, err := filterQuery(req.GetFilter(), req.GetSort())
+	if err != nil {
+		return query, err
+	}
+
+	size := int(req.GetPageSize())
+	switch {
+	case size < 0:
+		return query, status.Errorf(codes.InvalidArgument, Common.ErrInvalidPageSize)
+	case size == 0:
+		size = limits.DefaultPageSize
+	case size > limits.MaxPageSize:
+		size = limits.MaxPageSize
+	}
+	query.Limit = size + 1
+
+	if token := req.GetPageToken(); token != "" {
+		after, err := decodePageToken(token, query.Sort)
+		if err != nil {
+			return query, status.Errorf(codes.InvalidArgument, Common.ErrInvalidPageToken)
+		}
+		query.After = &after
+	}
+	return query, nil
+}
+
+// filterQuery validates a filter and sort order and turns them into a store
+// query without a limit.
+func filterQuery(filter *pb.ComplaintFilter, sortOrder pb.ComplaintSort) (Storage.ComplaintQuery, error) {
+	var query Storage.ComplaintQuery
+
+	sort, ok := sortFromPB[sortOrder]
+	if !ok {
+		return query, status.Errorf(codes.InvalidArgument, Common.ErrInvalidSort)
+	}
+	query.Sort = sort
+
+	query.MinSeverity = int(filter.GetMinSeverity())
+	query.MaxSeverity = int(filter.GetMaxSeverity())
+	if query.MinSeverity != 0 && query.MaxSeverity != 0 && query.MinSeverity > query.MaxSeverity {
+		return query, status.Errorf(codes.InvalidArgument, Common.ErrInvalidSeverityRange)
+	}
+	for _, s := range filter.GetStatuses() {
+		st, ok := fromPBStatus(s)
+		if !ok {
+			return query, status.Errorf(codes.InvalidArgument, Common.ErrInvalidStatus)
+		}
+		query.Statuses = append(query.Statuses, st)
+	}
+	if filter != nil && filter.Resolved != nil {
+		resolved := filter.GetResolved()
+		query.Resolved = &resolved
+	}
+	if filter.GetCreatedSince() != nil {
+		query.CreatedSince = filter.GetCreatedSince().AsTime()
+	}
+	if filter.GetCreatedUntil() != nil {
+		query.CreatedUntil = filter.GetCreatedUntil().AsTime()
+	}
+	if !query.CreatedSince.IsZero() && !query.CreatedUntil.IsZero() && !query.CreatedUntil.After(query.CreatedSince) {
+		return query, status.Errorf(codes.InvalidArgument, Common.ErrInvalidTimeRange)
+	}
+	return query, nil
+}
+
+// nextPage trims the extra complaint fetched by complaintQuery and returns the
+// token of the page after it, or "" if this is the last page.
+func nextPage(complaints []Common.Complaint, query Storage.ComplaintQuery) ([]Common.Complaint, string) {
+	size := query.Limit - 1
+	if len(complaints) <= size {
+		return complaints, ""
+	}
+	complaints = complaints[:size]
+	return complaints, encodePageToken(Storage.CursorOf(complaints[size-1]), query.Sort)
+}
+
+// pageToken is the content of a page token: the position of the last complaint
+// of the page, and the sort it was listed with.
+type pageToken struct {
+	Sort      Storage.ComplaintSort `json:"s"`
+	ID        string                `json:"i"`
+	CreatedAt int64                 `json:"c,omitempty"` // Unix nanoseconds
+	Severity  int                   `json:"v,omitempty"`
+}
+
+func encodePageToken(cursor Storage.ComplaintCursor, sort Storage.ComplaintSort) string {
+	token := pageToken{Sort: sort, ID: cursor.ID, Severity: cursor.Severity}
+	if !cursor.CreatedAt.IsZero() {
+		token.CreatedAt = cursor.CreatedAt.UnixNano()
+	}
+	data, _ := json.Marshal(token)
+	return base64.RawURLEncoding.EncodeToString(data)
+}
+
+// errPageToken rejects a page token that does not fit the request.
+var errPageToken = errors.New("page token does not match the request")
+
+// decodePageToken returns the position encoded in the token. A token made for
+// another sort order is refused, since the position means nothing in it.
+func decodePageToken(s string, sort Storage.ComplaintSort) (Storage.ComplaintCursor, error) {
+	var cursor Storage.ComplaintCursor
+	data, err := base64.RawURLEncoding.DecodeString(s)
+	if err != nil {
+		return cursor, err
+	}
+	var token pageToken
+	if err := json.Unmarshal(data, &token); err != nil {
+		return cursor, err
+	}
+	if token.Sort != sort || token.ID == "" {
+		return cursor, errPageToken
+	}
+	cursor = Storage.ComplaintCursor{ID: token.ID, Severity: token.Severity}
+	if token.CreatedAt != 0 {
+		cursor.CreatedAt = time.Unix(0, token.CreatedAt).UTC()
+	}
+	return cursor, nil
+}
@@ -0,0 +1,54 @@
+// ComplaintService/Search.go
+package ComplaintService
+
+import (
+	"complaint-portal/Common"
+	pb "complaint-portal/Generated/ComplaintService"
+	"complaint-portal/Search"
+	"context"
+	"log/slog"
+	"strings"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// SearchComplaints implements the SearchComplaints RPC method.
+// It returns the complaints whose title or summary match the query, best match
+// first. Staff search every complaint, customers only their own.
+func (s *Server) SearchComplaints(ctx context.Context, req *pb.SearchComplaintsRequest) (*pb.SearchComplaintsResponse, error) {
+	slog.DebugContext(ctx, Common.LogReceivedSearch)
+
+	user, err := s.authenticate(ctx, req.GetSecretCode())
+	if err != nil {
+		return nil, err
+	}
+
+	if strings.TrimSpace(req.GetQuery()) == "" {
+		return nil, status.Errorf(codes.InvalidArgument, Common.ErrSearchQueryRequired)
+	}
+	limit := int(req.GetLimit())
+	switch {
+	case limit < 0:
+		return nil, status.Errorf(codes.InvalidArgument, Common.ErrInvalidSearchLimit)
+	case limit == 0:
+		limit = s.Limits.DefaultSearchLimit
+	case limit > s.Limits.MaxSearchLimit:
+		limit = s.Limits.MaxSearchLimit
+	}
+
+	query := Search.Query{Text: req.GetQuery(), Limit: limit}
+	if !user.IsStaff() {
+		query.UserID = user.ID
+	}
+
+	res := &pb.SearchComplaintsResponse{}
+	for _, r := range s.Index.Search(query) {
+		res.Results = append(res.Results, &pb.SearchResult{
+			Complaint: toPBComplaint(r.Complaint),
+			Score:     r.Score,
+			Snippet:   r.Snippet,
+		})
+	}
+	return res, nil
+}
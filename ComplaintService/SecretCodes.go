@@ -0,0 +1,109 @@
+// ComplaintService/SecretCodes.go
+package ComplaintService
+
+import (
+	"complaint-portal/Common"
+	pb "complaint-portal/Generated/ComplaintService"
+	"complaint-portal/Storage"
+	"context"
+	"errors"
+	"log/slog"
+	"time"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// RotateSecretCode implements the RotateSecretCode RPC method.
+// The caller gets a new secret code and the old one stops working at once, as
+// do the sessions already issued, the caller's included.
+func (s *Server) RotateSecretCode(ctx context.Context, req *pb.RotateSecretCodeRequest) (*pb.SecretCodeResponse, error) {
+	slog.DebugContext(ctx, Common.LogReceivedRotate)
+
+	caller, err := s.authenticate(ctx, req.GetSecretCode())
+	if err != nil {
+		return nil, err
+	}
+
+	secretCode := Common.GenerateSecretCode()
+	_, err = s.Store.UpdateUser(ctx, caller.ID, func(u *Common.User) error {
+		u.SecretHash = s.Hasher.Hash(secretCode)
+		u.RecoveryHash = ""
+		u.RecoveryExpiresAt = time.Time{}
+		u.SessionGeneration++
+		u.UpdatedAt = time.Now().UTC()
+		return nil
+	})
+	if errors.Is(err, Storage.ErrNotFound) {
+		return nil, status.Errorf(codes.NotFound, Common.ErrUserNotFound)
+	}
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "Failed to update user: %v", err)
+	}
+
+	return &pb.SecretCodeResponse{SecretCode: secretCode}, nil
+}
+
+// ResetSecretCode implements the ResetSecretCode RPC method.
+// It disables the user's secret code and issues a one-time recovery code, valid
+// for Limits.RecoveryCodeTTL, that the user exchanges for a new secret code with
+// RedeemRecoveryCode. The user's sessions end. The interceptor only lets admins
+// call it.
+func (s *Server) ResetSecretCode(ctx context.Context, req *pb.ResetSecretCodeRequest) (*pb.ResetSecretCodeResponse, error) {
+	slog.DebugContext(ctx, Common.LogReceivedReset, Common.LogKeyTargetUser, req.GetUserId())
+
+	if _, err := s.authenticate(ctx, req.GetSecretCode()); err != nil {
+		return nil, err
+	}
+
+	recoveryCode := Common.GenerateSecretCode()
+	now := time.Now().UTC()
+	expiresAt := now.Add(s.Limits.RecoveryCodeTTL)
+	_, err := s.Store.UpdateUser(ctx, req.GetUserId(), func(u *Common.User) error {
+		u.SecretHash = s.Hasher.Unusable()
+		u.RecoveryHash = s.Hasher.Hash(recoveryCode)
+		u.RecoveryExpiresAt = expiresAt
+		u.SessionGeneration++
+		u.UpdatedAt = now
+		return nil
+	})
+	if errors.Is(err, Storage.ErrNotFound) {
+		return nil, status.Errorf(codes.NotFound, Common.ErrUserNotFound)
+	}
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "Failed to update user: %v", err)
+	}
+
+	return &pb.ResetSecretCodeResponse{RecoveryCode: recoveryCode, ExpiresAt: toPBTimestamp(expiresAt)}, nil
+}
+
+// RedeemRecoveryCode implements the RedeemRecoveryCode RPC method.
+// A valid recovery code is consumed and replaced by a new secret code, and the
+// sessions issued before end. Unknown users and wrong or expired codes all get
+// the same Unauthenticated error.
+func (s *Server) RedeemRecoveryCode(ctx context.Context, req *pb.RedeemRecoveryCodeRequest) (*pb.SecretCodeResponse, error) {
+	slog.DebugContext(ctx, Common.LogReceivedRedeem)
+
+	errInvalid := status.Errorf(codes.Unauthenticated, Common.ErrInvalidRecoveryCode)
+	secretCode := Common.GenerateSecretCode()
+	now := time.Now().UTC()
+	_, err := s.Store.UpdateUser(ctx, req.GetUserId(), func(u *Common.User) error {
+		if u.RecoveryHash == "" || !now.Before(u.RecoveryExpiresAt) || !s.Hasher.Verify(req.GetRecoveryCode(), u.RecoveryHash) {
+			return errInvalid
+		}
+		u.SecretHash = s.Hasher.Hash(secretCode)
+		u.RecoveryHash = ""
+		u.RecoveryExpiresAt = time.Time{}
+		u.SessionGeneration++
+		u.UpdatedAt = now
+		return nil
+	})
+	if errors.Is(err, Storage.ErrNotFound) || err == errInvalid {
+		return nil, errInvalid
+	}
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "Failed to update user: %v", err)
+	}
+
+	return &pb.SecretCodeResponse{SecretCode: secretCode}, nil
+}
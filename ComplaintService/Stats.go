@@ -0,0 +1,93 @@
+// ComplaintService/Stats.go
+package ComplaintService
+
+import (
+	"complaint-portal/Analytics"
+	"complaint-portal/Common"
+	pb "complaint-portal/Generated/ComplaintService"
+	"context"
+	"errors"
+	"log/slog"
+	"time"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// bucketingFromPB maps protobuf bucketings to the analytics ones.
+var bucketingFromPB = map[pb.StatsBucketing]Analytics.Bucketing{
+	pb.StatsBucketing_DAILY:  Analytics.Daily,
+	pb.StatsBucketing_WEEKLY: Analytics.Weekly,
+}
+
+// GetComplaintStats implements the GetComplaintStats RPC method.
+// It aggregates the complaints created in the requested window. The interceptor
+// only lets admins call it.
+func (s *Server) GetComplaintStats(ctx context.Context, req *pb.GetComplaintStatsRequest) (*pb.GetComplaintStatsResponse, error) {
+	slog.DebugContext(ctx, Common.LogReceivedStats)
+
+	if _, err := s.authenticate(ctx, req.GetSecretCode()); err != nil {
+		return nil, err
+	}
+
+	opts := Analytics.Options{Until: time.Now(), TopSubmitters: int(req.GetTopSubmitters())}
+	if req.GetUntil() != nil {
+		opts.Until = req.GetUntil().AsTime()
+	}
+	opts.Since = opts.Until.Add(-s.Limits.DefaultStatsWindow)
+	if req.GetSince() != nil {
+		opts.Since = req.GetSince().AsTime()
+	}
+	bucketing, ok := bucketingFromPB[req.GetBucketing()]
+	if !ok {
+		return nil, status.Errorf(codes.InvalidArgument, Common.ErrInvalidBucketing)
+	}
+	opts.Bucketing = bucketing
+	switch {
+	case opts.TopSubmitters < 0:
+		return nil, status.Errorf(codes.InvalidArgument, Common.ErrInvalidTopSubmitters)
+	case opts.TopSubmitters == 0:
+		opts.TopSubmitters = s.Limits.DefaultTopSubmitters
+	case opts.TopSubmitters > s.Limits.MaxTopSubmitters:
+		opts.TopSubmitters = s.Limits.MaxTopSubmitters
+	}
+
+	stats, err := Analytics.Compute(ctx, s.Store, opts)
+	switch {
+	case errors.Is(err, Analytics.ErrInvalidWindow):
+		return nil, status.Errorf(codes.InvalidArgument, Common.ErrInvalidTimeRange)
+	case errors.Is(err, Analytics.ErrTooManyBuckets):
+		return nil, status.Errorf(codes.InvalidArgument, Common.ErrTooManyBuckets, Analytics.MaxBuckets)
+	case err != nil:
+		return nil, status.Errorf(codes.Internal, "Failed to compute complaint statistics: %v", err)
+	}
+
+	res := &pb.GetComplaintStatsResponse{
+		Since:                toPBTimestamp(opts.Since),
+		Until:                toPBTimestamp(opts.Until),
+		Total:                int32(stats.Total),
+		Open:                 int32(stats.Open),
+		Resolved:             int32(stats.Resolved),
+		MeanTimeToResolution: toPBDuration(stats.MeanTimeToResolution),
+	}
+	for _, sc := range stats.BySeverity {
+		res.BySeverity = append(res.BySeverity, &pb.SeverityCount{Severity: int32(sc.Severity), Count: int32(sc.Count)})
+	}
+	for _, sub := range stats.TopSubmitters {
+		res.TopSubmitters = append(res.TopSubmitters, &pb.SubmitterCount{
+			UserId:    sub.UserID,
+			UserName:  sub.Name,
+			UserEmail: sub.Email,
+			Count:     int32(sub.Count),
+		})
+	}
+	for _, b := range stats.Buckets {
+		res.Buckets = append(res.Buckets, &pb.StatsBucket{
+			Start:                toPBTimestamp(b.Start),
+			Submitted:            int32(b.Submitted),
+			Resolved:             int32(b.Resolved),
+			MeanTimeToResolution: toPBDuration(b.MeanTimeToResolution),
+		})
+	}
+	return res, nil
+}
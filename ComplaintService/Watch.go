@@ -0,0 +1,71 @@
+// ComplaintService/Watch.go
+package ComplaintService
+
+import (
+	"complaint-portal/Common"
+	"complaint-portal/Events"
+	pb "complaint-portal/Generated/ComplaintService"
+	"context"
+	"errors"
+	"log/slog"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// WatchComplaints implements the WatchComplaints RPC method.
+// It streams an event for every complaint created or updated from now on, or
+// after the resume token, until the client goes away. Staff watch every
+// complaint or the requested user's; customers only their own.
+func (s *Server) WatchComplaints(req *pb.WatchComplaintsRequest, stream pb.ComplaintService_WatchComplaintsServer) error {
+	slog.DebugContext(stream.Context(), Common.LogReceivedWatch)
+	ctx, cancel := context.WithCancel(stream.Context())
+	defer cancel()
+	go func() {
+		select {
+		case <-s.stopping:
+			cancel()
+		case <-ctx.Done():
+		}
+	}()
+
+	user, err := s.authenticate(ctx, req.GetSecretCode())
+	if err != nil {
+		return err
+	}
+
+	filter := Events.Filter{UserID: req.GetUserId()}
+	if !user.IsStaff() {
+		if filter.UserID != "" && filter.UserID != user.ID {
+			return status.Errorf(codes.PermissionDenied, Common.ErrPermissionDenied)
+		}
+		filter.UserID = user.ID
+	}
+
+	sub, err := s.Events.Subscribe(filter, req.GetResumeToken())
+	switch {
+	case errors.Is(err, Events.ErrInvalidResumeToken):
+		return status.Errorf(codes.InvalidArgument, Common.ErrInvalidResumeToken)
+	case errors.Is(err, Events.ErrResumeExpired):
+		return status.Errorf(codes.OutOfRange, Common.ErrResumeExpired)
+	case err != nil:
+		return status.Errorf(codes.Internal, "Failed to watch complaints: %v", err)
+	}
+	defer sub.Close()
+
+	for {
+		e, err := sub.Next(ctx)
+		if errors.Is(err, Events.ErrSlowSubscriber) {
+			return status.Errorf(codes.Unavailable, Common.ErrWatchLagging)
+		}
+		if err != nil {
+			if stream.Context().Err() == nil {
+				return status.Errorf(codes.Unavailable, Common.ErrShuttingDown)
+			}
+			return status.FromContextError(err).Err()
+		}
+		if err := stream.Send(toPBEvent(e)); err != nil {
+			return err
+		}
+	}
+}
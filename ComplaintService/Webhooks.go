@@ -0,0 +1,157 @@
+// ComplaintService/Webhooks.go
+package ComplaintService
+
+import (
+	"complaint-portal/Common"
+	pb "complaint-portal/Generated/ComplaintService"
+	"complaint-portal/Storage"
+	"complaint-portal/Webhook"
+	"context"
+	"errors"
+	"log/slog"
+	"time"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// CreateWebhook implements the CreateWebhook RPC method.
+// The response is the only time the webhook's signing secret is returned. The
+// interceptor only lets admins call it.
+func (s *Server) CreateWebhook(ctx context.Context, req *pb.CreateWebhookRequest) (*pb.Webhook, error) {
+	slog.DebugContext(ctx, Common.LogReceivedCreateWebhook)
+
+	user, err := s.authenticate(ctx, req.GetSecretCode())
+	if err != nil {
+		return nil, err
+	}
+
+	webhook := Common.Webhook{
+		ID:        Common.GenerateID(),
+		URL:       req.GetUrl(),
+		Events:    req.GetEvents(),
+		Secret:    Webhook.GenerateSecret(),
+		CreatedBy: user.ID,
+		CreatedAt: time.Now().UTC(),
+	}
+	if err := Webhook.Validate(&webhook); err != nil {
+		return nil, status.Errorf(codes.InvalidArgument, "%v", err)
+	}
+	if err := s.Store.CreateWebhook(ctx, webhook); err != nil {
+		return nil, status.Errorf(codes.Internal, "Failed to create webhook: %v", err)
+	}
+
+	res := toPBWebhook(webhook)
+	res.Secret = webhook.Secret
+	return res, nil
+}
+
+// ListWebhooks implements the ListWebhooks RPC method.
+// The interceptor only lets admins call it.
+func (s *Server) ListWebhooks(ctx context.Context, req *pb.ListWebhooksRequest) (*pb.ListWebhooksResponse, error) {
+	slog.DebugContext(ctx, Common.LogReceivedListWebhooks)
+
+	if _, err := s.authenticate(ctx, req.GetSecretCode()); err != nil {
+		return nil, err
+	}
+
+	webhooks, err := s.Store.ListWebhooks(ctx)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "Failed to list webhooks: %v", err)
+	}
+	res := &pb.ListWebhooksResponse{}
+	for _, webhook := range webhooks {
+		res.Webhooks = append(res.Webhooks, toPBWebhook(webhook))
+	}
+	return res, nil
+}
+
+// DeleteWebhook implements the DeleteWebhook RPC method.
+// Deliveries still pending fail on their next attempt. The interceptor only
+// lets admins call it.
+func (s *Server) DeleteWebhook(ctx context.Context, req *pb.DeleteWebhookRequest) (*pb.DeleteWebhookResponse, error) {
+	slog.DebugContext(ctx, Common.LogReceivedDeleteWebhook)
+
+	if _, err := s.authenticate(ctx, req.GetSecretCode()); err != nil {
+		return nil, err
+	}
+
+	err := s.Store.DeleteWebhook(ctx, req.GetWebhookId())
+	if errors.Is(err, Storage.ErrNotFound) {
+		return nil, status.Errorf(codes.NotFound, Common.ErrWebhookNotFound)
+	}
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "Failed to delete webhook: %v", err)
+	}
+	return &pb.DeleteWebhookResponse{}, nil
+}
+
+// deliveryStatusFromPB maps protobuf delivery statuses to the stored ones.
+var deliveryStatusFromPB = map[pb.WebhookDeliveryStatus]Common.DeliveryStatus{
+	pb.WebhookDeliveryStatus_DELIVERY_PENDING:   Common.DeliveryPending,
+	pb.WebhookDeliveryStatus_DELIVERY_SUCCEEDED: Common.DeliverySucceeded,
+	pb.WebhookDeliveryStatus_DELIVERY_FAILED:    Common.DeliveryFailed,
+}
+
+// ListWebhookDeliveries implements the ListWebhookDeliveries RPC method.
+// It returns the most recent deliveries, newest first. The interceptor only
+// lets admins call it.
+func (s *Server) ListWebhookDeliveries(ctx context.Context, req *pb.ListWebhookDeliveriesRequest) (*pb.ListWebhookDeliveriesResponse, error) {
+	slog.DebugContext(ctx, Common.LogReceivedDeliveries)
+
+	if _, err := s.authenticate(ctx, req.GetSecretCode()); err != nil {
+		return nil, err
+	}
+
+	filter := Storage.DeliveryFilter{WebhookID: req.GetWebhookId(), Limit: int(req.GetLimit())}
+	switch {
+	case filter.Limit < 0:
+		return nil, status.Errorf(codes.InvalidArgument, Common.ErrInvalidDeliveryLimit)
+	case filter.Limit == 0:
+		filter.Limit = s.Limits.DefaultDeliveryLimit
+	case filter.Limit > s.Limits.MaxDeliveryLimit:
+		filter.Limit = s.Limits.MaxDeliveryLimit
+	}
+	if req.Status != nil {
+		st, ok := deliveryStatusFromPB[req.GetStatus()]
+		if !ok {
+			return nil, status.Errorf(codes.InvalidArgument, Common.ErrInvalidDeliveryState)
+		}
+		filter.Status = st
+	}
+
+	deliveries, err := s.Store.ListWebhookDeliveries(ctx, filter)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "Failed to list webhook deliveries: %v", err)
+	}
+	res := &pb.ListWebhookDeliveriesResponse{}
+	for _, d := range deliveries {
+		res.Deliveries = append(res.Deliveries, toPBDelivery(d))
+	}
+	return res, nil
+}
+
+// RedeliverWebhook implements the RedeliverWebhook RPC method.
+// The payload is sent again as a new delivery, attempted in the background.
+// The interceptor only lets admins call it.
+func (s *Server) RedeliverWebhook(ctx context.Context, req *pb.RedeliverWebhookRequest) (*pb.WebhookDelivery, error) {
+	slog.DebugContext(ctx, Common.LogReceivedRedeliver)
+
+	if _, err := s.authenticate(ctx, req.GetSecretCode()); err != nil {
+		return nil, err
+	}
+	if s.Webhooks == nil {
+		return nil, status.Errorf(codes.Unavailable, Common.ErrWebhooksDisabled)
+	}
+
+	delivery, err := s.Webhooks.Redeliver(ctx, req.GetDeliveryId())
+	switch {
+	case errors.Is(err, Storage.ErrNotFound):
+		return nil, status.Errorf(codes.NotFound, Common.ErrDeliveryNotFound)
+	case errors.Is(err, Webhook.ErrWebhookDeleted):
+		return nil, status.Errorf(codes.FailedPrecondition, Common.ErrWebhookNotFound)
+	case err != nil:
+		return nil, status.Errorf(codes.Internal, "Failed to redeliver webhook: %v", err)
+	}
+	return toPBDelivery(delivery), nil
+}
@@ -0,0 +1,385 @@
+// Config/Config.go
+package Config
+
+import (
+	"complaint-portal/Auth"
+	"complaint-portal/Common"
+	"complaint-portal/ComplaintService"
+	"complaint-portal/Health"
+	"complaint-portal/Logging"
+	"complaint-portal/Notify"
+	"complaint-portal/Storage"
+	"complaint-portal/Webhook"
+	"errors"
+	"fmt"
+	"net"
+	"time"
+)
+
+// Config is the configuration of the server. Every setting is read, in order
+// of precedence, from a command-line flag, an environment variable, the
+// configuration file or its default; see Load.
+//
+// The yaml tag of a setting is its key in the file. Its environment variable
+// and flag are given by the env and flag tags, or derived from the key: the
+// key of limits.max_page_size is LIMITS_MAX_PAGE_SIZE in the environment and
+// -limits.max-page-size on the command line. A "-" tag leaves a setting out.
+type Config struct {
+	Listen   ListenSettings  `yaml:"listen"`
+	Storage  StorageSettings `yaml:"storage"`
+	Auth     AuthSettings    `yaml:"auth"`
+	Notify   NotifySettings  `yaml:"notify"`
+	Webhooks WebhookSettings `yaml:"webhooks"`
+	Health   HealthSettings  `yaml:"health"`
+	Limits   LimitSettings   `yaml:"limits"`
+	Log      LogSettings     `yaml:"log"`
+}
+
+// ListenSettings holds the addresses the server serves on.
+type ListenSettings struct {
+	GRPC string `yaml:"grpc" env:"GRPC_ADDR" flag:"grpc-addr" usage:"address of the gRPC server"`
+	HTTP string `yaml:"http" env:"HTTP_ADDR" flag:"http-addr" usage:"address of the REST/JSON gateway; empty to disable it"`
+	// Reflection serves gRPC reflection, for tools such as grpcurl.
+	Reflection      bool          `yaml:"reflection" env:"GRPC_REFLECTION" flag:"reflection" usage:"serve gRPC reflection, for tools such as grpcurl"`
+	ShutdownTimeout time.Duration `yaml:"shutdown_timeout" env:"SHUTDOWN_TIMEOUT" flag:"shutdown-timeout" usage:"how long to wait for in-flight requests on SIGTERM"`
+}
+
+// StorageSettings selects and configures the storage backend.
+type StorageSettings struct {
+	Backend    string    `yaml:"backend" env:"STORAGE_BACKEND" flag:"storage" usage:"storage backend: firestore, sqlite or memory"`
+	SQLitePath string    `yaml:"sqlite_path" env:"SQLITE_PATH" flag:"sqlite-path" usage:"database file for the sqlite backend"`
+	Firestore  Firestore `yaml:"firestore"`
+}
+
+// Firestore configures the firestore backend.
+type Firestore struct {
+	ProjectID    string `yaml:"project_id" env:"FIRESTORE_PROJECT" flag:"firestore-project" usage:"Firestore project; read from the credentials file if empty"`
+	Credentials  string `yaml:"credentials" env:"FIRESTORE_CREDENTIALS" flag:"firestore-credentials" usage:"service account key file"`
+	EmulatorHost string `yaml:"emulator_host" env:"FIRESTORE_EMULATOR_HOST" flag:"firestore-emulator-host" usage:"host:port of a Firestore emulator to use instead of Google Cloud"`
+	// Watch streams complaint changes made by other servers to WatchComplaints.
+	Watch       bool        `yaml:"watch" env:"WATCH_FIRESTORE" flag:"watch-firestore" usage:"stream complaint changes made by other servers to WatchComplaints"`
+	Collections Collections `yaml:"collections"`
+}
+
+// Collections names the Firestore collections; see Storage.Collections.
+type Collections struct {
+	Users      string `yaml:"users" usage:"Firestore collection of the users"`
+	Complaints string `yaml:"complaints" usage:"Firestore collection of the complaints"`
+	Comments   string `yaml:"comments" usage:"Firestore subcollection of the comments of each complaint"`
+	Audit      string `yaml:"audit" usage:"Firestore collection of the audit log"`
+	Webhooks   string `yaml:"webhooks" usage:"Firestore collection of the webhooks"`
+	Deliveries string `yaml:"deliveries" usage:"Firestore collection of the webhook deliveries"`
+}
+
+// AuthSettings configures secret codes, sessions and their rate limits.
+type AuthSettings struct {
+	// SessionKey and SecretPepper are kept off the command line, like
+	// NotifySettings.SMTPPassword.
+	SessionKey      Secret        `yaml:"session_key" env:"SESSION_SIGNING_KEY" flag:"-" usage:"key signing session tokens, at least 32 bytes; random if empty"`
+	SessionTTL      time.Duration `yaml:"session_ttl" env:"SESSION_TTL" flag:"session-ttl" usage:"lifetime of session tokens"`
+	SecretPepper    Secret        `yaml:"secret_pepper" env:"SECRET_CODE_PEPPER" flag:"-" usage:"key hashing stored secret codes, at least 16 bytes"`
+	RecoveryCodeTTL time.Duration `yaml:"recovery_code_ttl" usage:"lifetime of the recovery codes issued by ResetSecretCode"`
+	RateLimit       RateLimit     `yaml:"rate_limit"`
+}
+
+// RateLimit configures the limits on secret code attempts; see Auth.Policy.
+type RateLimit struct {
+	ClientRate    float64       `yaml:"client_rate" usage:"secret code attempts per second allowed to each client address"`
+	ClientBurst   int           `yaml:"client_burst" usage:"secret code attempts each client address may make at once"`
+	FreeFailures  int           `yaml:"free_failures" usage:"failed attempts a client address may make before it is locked out"`
+	BaseLockout   time.Duration `yaml:"base_lockout" usage:"first lockout of a client address, doubled on each further failure; 0 disables lockouts"`
+	MaxLockout    time.Duration `yaml:"max_lockout" usage:"longest lockout of a client address"`
+	FailureWindow time.Duration `yaml:"failure_window" usage:"how long failed attempts count against a client address"`
+	GlobalRate    float64       `yaml:"global_rate" usage:"secret code attempts per second allowed to all clients together"`
+	GlobalBurst   int           `yaml:"global_burst" usage:"secret code attempts all clients together may make at once"`
+}
+
+// NotifySettings configures the email notifications, which are only sent when
+// an SMTP server is set.
+type NotifySettings struct {
+	SMTPAddr     string `yaml:"smtp_addr" env:"SMTP_ADDR" flag:"smtp-addr" usage:"host:port of the SMTP server emailing notifications; none are sent if empty"`
+	SMTPFrom     string `yaml:"smtp_from" env:"SMTP_FROM" flag:"smtp-from" usage:"sender address of notification emails"`
+	SMTPUsername string `yaml:"smtp_username" env:"SMTP_USERNAME" flag:"smtp-username" usage:"user name to authenticate to the SMTP server with"`
+	// SMTPPassword is kept off the command line, where other users could see it.
+	SMTPPassword Secret        `yaml:"smtp_password" env:"SMTP_PASSWORD" flag:"-"`
+	Templates    string        `yaml:"templates" env:"NOTIFY_TEMPLATES" flag:"notify-templates" usage:"file overriding the notification email templates"`
+	QueueSize    int           `yaml:"queue_size" usage:"notifications waiting to be sent before new ones are dropped"`
+	MaxAttempts  int           `yaml:"max_attempts" usage:"attempts to send each notification"`
+	Backoff      time.Duration `yaml:"backoff" usage:"wait before the first retry of a notification, doubled after each attempt"`
+}
+
+// WebhookSettings configures the delivery of webhooks; see Webhook.Options.
+type WebhookSettings struct {
+	Workers     int           `yaml:"workers" usage:"webhook deliveries attempted at once"`
+	MaxAttempts int           `yaml:"max_attempts" usage:"attempts to deliver each webhook event"`
+	Backoff     time.Duration `yaml:"backoff" usage:"wait before the first retry of a delivery, doubled after each attempt"`
+	MaxBackoff  time.Duration `yaml:"max_backoff" usage:"longest wait between the attempts of a delivery"`
+	Timeout     time.Duration `yaml:"timeout" usage:"timeout of each delivery attempt"`
+}
+
+// HealthSettings configures the storage probes of the health service.
+type HealthSettings struct {
+	Interval time.Duration `yaml:"interval" usage:"time between the storage probes of the health service"`
+	Timeout  time.Duration `yaml:"timeout" usage:"timeout of each storage probe"`
+}
+
+// LimitSettings bound what a single request may ask for; see ComplaintService.Limits.
+type LimitSettings struct {
+	DefaultPageSize      int           `yaml:"default_page_size" usage:"complaints listed per page when the request sets no page size"`
+	MaxPageSize          int           `yaml:"max_page_size" usage:"most complaints listed per page"`
+	DefaultSearchLimit   int           `yaml:"default_search_limit" usage:"search results returned when the request sets no limit"`
+	MaxSearchLimit       int           `yaml:"max_search_limit" usage:"most search results returned"`
+	DefaultStatsWindow   time.Duration `yaml:"default_stats_window" usage:"how far back complaint statistics go when the request sets no start"`
+	DefaultTopSubmitters int           `yaml:"default_top_submitters" usage:"top submitters listed when the request sets no number"`
+	MaxTopSubmitters     int           `yaml:"max_top_submitters" usage:"most top submitters listed"`
+	ExportChunkSize      int           `yaml:"export_chunk_size" usage:"size in bytes of the chunks of complaint exports"`
+	DefaultDeliveryLimit int           `yaml:"default_delivery_limit" usage:"webhook deliveries listed when the request sets no limit"`
+	MaxDeliveryLimit     int           `yaml:"max_delivery_limit" usage:"most webhook deliveries listed"`
+}
+
+// LogSettings configures the log; see Logging.Options.
+type LogSettings struct {
+	Format string `yaml:"format" env:"LOG_FORMAT" flag:"log-format" usage:"format of the log: json or text"`
+	Level  string `yaml:"level" env:"LOG_LEVEL" flag:"log-level" usage:"lowest level logged: debug, info, warn or error"`
+}
+
+// Secret is a setting that must not be shown: it prints as Common.RedactedSecret
+// when set.
+type Secret string
+
+func (s Secret) String() string {
+	if s == "" {
+		return ""
+	}
+	return Common.RedactedSecret
+}
+
+// Default returns the configuration used where nothing else is set.
+func Default() Config {
+	limits := ComplaintService.DefaultLimits
+	client, global := Auth.DefaultClientPolicy, Auth.DefaultGlobalPolicy
+	return Config{
+		Listen: ListenSettings{GRPC: Common.GRPC_Port, HTTP: Common.HTTP_Port, ShutdownTimeout: Common.DefaultShutdownTimeout},
+		Storage: StorageSettings{
+			Backend:    Storage.BackendFirestore,
+			SQLitePath: Common.DefaultSQLitePath,
+			Firestore: Firestore{
+				Credentials: Common.DefaultCredentialsFile,
+				Collections: Collections(Storage.DefaultCollections),
+			},
+		},
+		Auth: AuthSettings{
+			SessionTTL:      Common.DefaultSessionTTL,
+			RecoveryCodeTTL: limits.RecoveryCodeTTL,
+			RateLimit: RateLimit{
+				ClientRate:    client.Rate,
+				ClientBurst:   client.Burst,
+				FreeFailures:  client.FreeFailures,
+				BaseLockout:   client.BaseLockout,
+				MaxLockout:    client.MaxLockout,
+				FailureWindow: client.FailureWindow,
+				GlobalRate:    global.Rate,
+				GlobalBurst:   global.Burst,
+			},
+		},
+		Notify: NotifySettings{
+			QueueSize:   Notify.DefaultOptions.QueueSize,
+			MaxAttempts: Notify.DefaultOptions.MaxAttempts,
+			Backoff:     Notify.DefaultOptions.Backoff,
+		},
+		Webhooks: WebhookSettings(Webhook.DefaultOptions),
+		Health:   HealthSettings(Health.DefaultOptions),
+		Limits: LimitSettings{
+			DefaultPageSize:      limits.DefaultPageSize,
+			MaxPageSize:          limits.MaxPageSize,
+			DefaultSearchLimit:   limits.DefaultSearchLimit,
+			MaxSearchLimit:       limits.MaxSearchLimit,
+			DefaultStatsWindow:   limits.DefaultStatsWindow,
+			DefaultTopSubmitters: limits.DefaultTopSubmitters,
+			MaxTopSubmitters:     limits.MaxTopSubmitters,
+			ExportChunkSize:      limits.ExportChunkSize,
+			DefaultDeliveryLimit: limits.DefaultDeliveryLimit,
+			MaxDeliveryLimit:     limits.MaxDeliveryLimit,
+		},
+		Log: LogSettings(Logging.DefaultOptions),
+	}
+}
+
+// Validate reports every invalid setting of c, naming them by their keys.
+func (c Config) Validate() error {
+	var errs []error
+	address := func(key, addr string) {
+		if _, _, err := net.SplitHostPort(addr); err != nil {
+			errs = append(errs, fmt.Errorf(Common.ErrInvalidAddress, key, addr, err))
+		}
+	}
+	required := func(key, value string) {
+		if value == "" {
+			errs = append(errs, fmt.Errorf(Common.ErrSettingRequired, key))
+		}
+	}
+	positive := func(key string, value float64) {
+		if value <= 0 {
+			errs = append(errs, fmt.Errorf(Common.ErrSettingPositive, key))
+		}
+	}
+	notAbove := func(key string, value int, maxKey string, max int) {
+		if value > max {
+			errs = append(errs, fmt.Errorf(Common.ErrSettingAbove, key, maxKey))
+		}
+	}
+
+	address("listen.grpc", c.Listen.GRPC)
+	if c.Listen.HTTP != "" {
+		address("listen.http", c.Listen.HTTP)
+	}
+	positive("listen.shutdown_timeout", float64(c.Listen.ShutdownTimeout))
+
+	switch c.Storage.Backend {
+	case Storage.BackendMemory:
+	case Storage.BackendSQLite:
+		required("storage.sqlite_path", c.Storage.SQLitePath)
+	case Storage.BackendFirestore:
+		fs := c.Storage.Firestore
+		if fs.EmulatorHost != "" {
+			address("storage.firestore.emulator_host", fs.EmulatorHost)
+		} else {
+			required("storage.firestore.credentials", fs.Credentials)
+		}
+		required("storage.firestore.collections.users", fs.Collections.Users)
+		required("storage.firestore.collections.complaints", fs.Collections.Complaints)
+		required("storage.firestore.collections.comments", fs.Collections.Comments)
+		required("storage.firestore.collections.audit", fs.Collections.Audit)
+		required("storage.firestore.collections.webhooks", fs.Collections.Webhooks)
+		required("storage.firestore.collections.deliveries", fs.Collections.Deliveries)
+	default:
+		errs = append(errs, fmt.Errorf(Common.ErrUnknownBackend, "storage.backend", c.Storage.Backend))
+	}
+
+	// Stored secret codes are only usable with the pepper they were hashed with,
+	// so it may only be made up when nothing outlives the process.
+	if c.Auth.SecretPepper == "" && c.Storage.Backend != Storage.BackendMemory {
+		errs = append(errs, fmt.Errorf(Common.ErrPepperRequired, "auth.secret_pepper"))
+	}
+	positive("auth.session_ttl", float64(c.Auth.SessionTTL))
+	positive("auth.recovery_code_ttl", float64(c.Auth.RecoveryCodeTTL))
+	rl := c.Auth.RateLimit
+	positive("auth.rate_limit.client_rate", rl.ClientRate)
+	positive("auth.rate_limit.client_burst", float64(rl.ClientBurst))
+	positive("auth.rate_limit.global_rate", rl.GlobalRate)
+	positive("auth.rate_limit.global_burst", float64(rl.GlobalBurst))
+	positive("auth.rate_limit.failure_window", float64(rl.FailureWindow))
+	if rl.FreeFailures < 0 {
+		errs = append(errs, fmt.Errorf(Common.ErrInvalidSetting, "auth.rate_limit.free_failures", "must not be negative"))
+	}
+	if rl.BaseLockout > rl.MaxLockout {
+		errs = append(errs, fmt.Errorf(Common.ErrSettingAbove, "auth.rate_limit.base_lockout", "auth.rate_limit.max_lockout"))
+	}
+
+	if c.Notify.SMTPAddr != "" {
+		address("notify.smtp_addr", c.Notify.SMTPAddr)
+		required("notify.smtp_from", c.Notify.SMTPFrom)
+	}
+	positive("notify.queue_size", float64(c.Notify.QueueSize))
+	positive("notify.max_attempts", float64(c.Notify.MaxAttempts))
+	positive("notify.backoff", float64(c.Notify.Backoff))
+
+	positive("webhooks.workers", float64(c.Webhooks.Workers))
+	positive("webhooks.max_attempts", float64(c.Webhooks.MaxAttempts))
+	positive("webhooks.backoff", float64(c.Webhooks.Backoff))
+	positive("webhooks.timeout", float64(c.Webhooks.Timeout))
+	if c.Webhooks.Backoff > c.Webhooks.MaxBackoff {
+		errs = append(errs, fmt.Errorf(Common.ErrSettingAbove, "webhooks.backoff", "webhooks.max_backoff"))
+	}
+
+	positive("health.interval", float64(c.Health.Interval))
+	positive("health.timeout", float64(c.Health.Timeout))
+
+	l := c.Limits
+	positive("limits.default_page_size", float64(l.DefaultPageSize))
+	positive("limits.default_search_limit", float64(l.DefaultSearchLimit))
+	positive("limits.default_stats_window", float64(l.DefaultStatsWindow))
+	positive("limits.default_top_submitters", float64(l.DefaultTopSubmitters))
+	positive("limits.export_chunk_size", float64(l.ExportChunkSize))
+	positive("limits.default_delivery_limit", float64(l.DefaultDeliveryLimit))
+	notAbove("limits.default_page_size", l.DefaultPageSize, "limits.max_page_size", l.MaxPageSize)
+	notAbove("limits.default_search_limit", l.DefaultSearchLimit, "limits.max_search_limit", l.MaxSearchLimit)
+	notAbove("limits.default_top_submitters", l.DefaultTopSubmitters, "limits.max_top_submitters", l.MaxTopSubmitters)
+	notAbove("limits.default_delivery_limit", l.DefaultDeliveryLimit, "limits.max_delivery_limit", l.MaxDeliveryLimit)
+
+	if c.Log.Format != Logging.FormatJSON && c.Log.Format != Logging.FormatText {
+		errs = append(errs, fmt.Errorf(Common.ErrInvalidSetting, "log.format", fmt.Errorf(Common.ErrUnknownLogFormat, c.Log.Format)))
+	}
+	if _, err := Logging.ParseLevel(c.Log.Level); err != nil {
+		errs = append(errs, fmt.Errorf(Common.ErrInvalidSetting, "log.level", err))
+	}
+	return errors.Join(errs...)
+}
+
+// StorageOptions returns the options opening the configured store.
+func (c Config) StorageOptions() Storage.Options {
+	return Storage.Options{
+		Backend:     c.Storage.Backend,
+		SQLitePath:  c.Storage.SQLitePath,
+		Collections: Storage.Collections(c.Storage.Firestore.Collections),
+	}
+}
+
+// FirebaseOptions returns the options of Common.InitFirebase.
+func (c Config) FirebaseOptions() Common.FirebaseOptions {
+	fs := c.Storage.Firestore
+	return Common.FirebaseOptions{CredentialsFile: fs.Credentials, ProjectID: fs.ProjectID, EmulatorHost: fs.EmulatorHost}
+}
+
+// RateLimitPolicies returns the policies of the per-client and global limiters.
+func (c Config) RateLimitPolicies() (client, global Auth.Policy) {
+	rl := c.Auth.RateLimit
+	client = Auth.Policy{
+		Rate:          rl.ClientRate,
+		Burst:         rl.ClientBurst,
+		FreeFailures:  rl.FreeFailures,
+		BaseLockout:   rl.BaseLockout,
+		MaxLockout:    rl.MaxLockout,
+		FailureWindow: rl.FailureWindow,
+	}
+	global = Auth.Policy{Rate: rl.GlobalRate, Burst: rl.GlobalBurst}
+	return client, global
+}
+
+// ServiceLimits returns the limits of the ComplaintService server.
+func (c Config) ServiceLimits() ComplaintService.Limits {
+	l := c.Limits
+	return ComplaintService.Limits{
+		DefaultPageSize:      l.DefaultPageSize,
+		MaxPageSize:          l.MaxPageSize,
+		DefaultSearchLimit:   l.DefaultSearchLimit,
+		MaxSearchLimit:       l.MaxSearchLimit,
+		DefaultStatsWindow:   l.DefaultStatsWindow,
+		DefaultTopSubmitters: l.DefaultTopSubmitters,
+		MaxTopSubmitters:     l.MaxTopSubmitters,
+		ExportChunkSize:      l.ExportChunkSize,
+		DefaultDeliveryLimit: l.DefaultDeliveryLimit,
+		MaxDeliveryLimit:     l.MaxDeliveryLimit,
+		RecoveryCodeTTL:      c.Auth.RecoveryCodeTTL,
+	}
+}
+
+// NotifyOptions returns the options of the notifier.
+func (c Config) NotifyOptions() Notify.Options {
+	return Notify.Options{QueueSize: c.Notify.QueueSize, MaxAttempts: c.Notify.MaxAttempts, Backoff: c.Notify.Backoff}
+}
+
+// WebhookOptions returns the options of the webhook dispatcher.
+func (c Config) WebhookOptions() Webhook.Options {
+	return Webhook.Options(c.Webhooks)
+}
+
+// HealthOptions returns the options of the health checker.
+func (c Config) HealthOptions() Health.Options {
+	return Health.Options(c.Health)
+}
+
+// LoggingOptions returns the options of the logger.
+func (c Config) LoggingOptions() Logging.Options {
+	return Logging.Options(c.Log)
+}
@@ -0,0 +1,188 @@
+// Config/Config_test.go
+package Config
+
+import (
+	"bytes"
+	"complaint-portal/Storage"
+	"flag"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+)
+
+// load loads a configuration from args and env, with a file holding contents
+// unless it is empty.
+func load(t *testing.T, contents string, args []string, env map[string]string) (Config, *flag.FlagSet, error) {
+	t.Helper()
+	if contents != "" {
+		path := filepath.Join(t.TempDir(), "config.yaml")
+		if err := os.WriteFile(path, []byte(contents), 0o600); err != nil {
+			t.Fatalf("Failed to write the configuration file: %v", err)
+		}
+		args = append([]string{"-config", path}, args...)
+	}
+	fs := flag.NewFlagSet("portal", flag.ContinueOnError)
+	fs.SetOutput(io.Discard)
+	cfg, err := Load(fs, args, func(key string) string { return env[key] })
+	return cfg, fs, err
+}
+
+// TestLoad checks where settings are read from, and their precedence.
+func TestLoad(t *testing.T) {
+	file := "listen:\n  grpc: \":6000\"\n  http: \":6080\"\nstorage:\n  backend: sqlite\nlimits:\n  max_page_size: 100\n  default_stats_window: 48h\n"
+
+	// Test case 1: Nothing set gives the defaults
+	cfg, _, err := load(t, "", nil, nil)
+	if err != nil || cfg != Default() {
+		t.Errorf("Expected the defaults, but got %+v (err: %v)", cfg, err)
+	}
+
+	// Test case 2: The file overrides the defaults, and keeps those it does not set
+	cfg, _, err = load(t, file, nil, nil)
+	if err != nil {
+		t.Fatalf("Expected no error, but got %v", err)
+	}
+	if cfg.Listen.GRPC != ":6000" || cfg.Storage.Backend != Storage.BackendSQLite || cfg.Limits.MaxPageSize != 100 || cfg.Limits.DefaultStatsWindow != 48*time.Hour {
+		t.Errorf("Expected the settings of the file, but got %+v", cfg)
+	}
+	if cfg.Limits.DefaultPageSize != Default().Limits.DefaultPageSize {
+		t.Errorf("Expected the default page size to be kept, but got %d", cfg.Limits.DefaultPageSize)
+	}
+
+	// Test case 3: The environment overrides the file, and flags the environment
+	env := map[string]string{"GRPC_ADDR": ":7000", "HTTP_ADDR": ":7080", "LIMITS_MAX_PAGE_SIZE": "200", "SESSION_TTL": "2h", "LOG_FORMAT": "text", "LOG_LEVEL": "warn"}
+	cfg, fs, err := load(t, file, []string{"-grpc-addr", ":8000", "-limits.max-page-size=300", "-reflection", "-log-level", "debug", "repair-complaints"}, env)
+	if err != nil {
+		t.Fatalf("Expected no error, but got %v", err)
+	}
+	if cfg.Listen.GRPC != ":8000" || cfg.Listen.HTTP != ":7080" || cfg.Limits.MaxPageSize != 300 || cfg.Auth.SessionTTL != 2*time.Hour || !cfg.Listen.Reflection ||
+		cfg.Log.Format != "text" || cfg.Log.Level != "debug" {
+		t.Errorf("Expected flags over the environment over the file, but got %+v", cfg)
+	}
+	if args := fs.Args(); len(args) != 1 || args[0] != "repair-complaints" {
+		t.Errorf("Expected the command to be left, but got %v", args)
+	}
+
+	// Test case 4: The file may be named in the environment
+	path := filepath.Join(t.TempDir(), "portal.yaml")
+	os.WriteFile(path, []byte(file), 0o600)
+	cfg, _, err = load(t, "", nil, map[string]string{"CONFIG_FILE": path})
+	if err != nil || cfg.Listen.GRPC != ":6000" {
+		t.Errorf("Expected the file named by CONFIG_FILE to be read, but got %q (err: %v)", cfg.Listen.GRPC, err)
+	}
+
+	// Test case 5: Secrets are read from the environment, and cannot be given as
+	// flags
+	cfg, _, err = load(t, "", nil, map[string]string{"SECRET_CODE_PEPPER": "pepper", "SMTP_PASSWORD": "hunter2"})
+	if err != nil || cfg.Auth.SecretPepper != "pepper" || cfg.Notify.SMTPPassword != "hunter2" {
+		t.Errorf("Expected the secrets from the environment, but got %+v (err: %v)", cfg.Auth, err)
+	}
+	for _, name := range []string{"-smtp-password", "-session-key", "-secret-pepper"} {
+		if _, _, err := load(t, "", []string{name, "hunter2"}, nil); err == nil {
+			t.Errorf("Expected no %s flag", name)
+		}
+	}
+
+	// Test case 6: Invalid values and unknown keys are refused
+	for name, bad := range map[string]func() error{
+		"unknown key":       func() error { _, _, err := load(t, "limits:\n  max_pagesize: 3\n", nil, nil); return err },
+		"integer duration":  func() error { _, _, err := load(t, "auth:\n  session_ttl: 60\n", nil, nil); return err },
+		"invalid yaml":      func() error { _, _, err := load(t, "listen: [\n", nil, nil); return err },
+		"missing file":      func() error { _, _, err := load(t, "", []string{"-config", "/does/not/exist.yaml"}, nil); return err },
+		"invalid flag":      func() error { _, _, err := load(t, "", []string{"-limits.max-page-size", "many"}, nil); return err },
+		"invalid variable":  func() error { _, _, err := load(t, "", nil, map[string]string{"GRPC_REFLECTION": "maybe"}); return err },
+		"invalid file type": func() error { _, _, err := load(t, "health:\n  interval: soon\n", nil, nil); return err },
+	} {
+		if err := bad(); err == nil {
+			t.Errorf("%s: expected an error", name)
+		}
+	}
+}
+
+// TestValidate checks that invalid settings are reported by their keys.
+func TestValidate(t *testing.T) {
+	valid := Default()
+	valid.Storage.Backend = Storage.BackendMemory
+
+	// Test case 1: The defaults are valid with the memory backend, and the
+	// other backends need a pepper
+	if err := valid.Validate(); err != nil {
+		t.Errorf("Expected the defaults to be valid, but got %v", err)
+	}
+	if err := Default().Validate(); err == nil || !strings.Contains(err.Error(), "auth.secret_pepper") {
+		t.Errorf("Expected the pepper to be required, but got %v", err)
+	}
+
+	// Test case 2: Each invalid setting is reported
+	for key, change := range map[string]func(*Config){
+		"listen.grpc":             func(c *Config) { c.Listen.GRPC = "50051" },
+		"listen.shutdown_timeout": func(c *Config) { c.Listen.ShutdownTimeout = 0 },
+		"storage.backend":         func(c *Config) { c.Storage.Backend = "mongodb" },
+		"storage.sqlite_path":     func(c *Config) { c.Storage.Backend, c.Storage.SQLitePath = Storage.BackendSQLite, "" },
+		"storage.firestore.emulator_host": func(c *Config) {
+			c.Storage.Backend, c.Storage.Firestore.EmulatorHost = Storage.BackendFirestore, "localhost"
+		},
+		"storage.firestore.collections.users": func(c *Config) {
+			c.Storage.Backend, c.Storage.Firestore.Collections.Users = Storage.BackendFirestore, ""
+		},
+		"auth.rate_limit.client_burst":   func(c *Config) { c.Auth.RateLimit.ClientBurst = 0 },
+		"auth.rate_limit.base_lockout":   func(c *Config) { c.Auth.RateLimit.BaseLockout = time.Hour },
+		"auth.rate_limit.failure_window": func(c *Config) { c.Auth.RateLimit.FailureWindow = 0 },
+		"notify.smtp_from":               func(c *Config) { c.Notify.SMTPAddr = "smtp.example.com:587" },
+		"webhooks.backoff":               func(c *Config) { c.Webhooks.MaxBackoff = time.Second },
+		"health.interval":                func(c *Config) { c.Health.Interval = -time.Second },
+		"limits.default_page_size":       func(c *Config) { c.Limits.MaxPageSize = 10 },
+		"limits.export_chunk_size":       func(c *Config) { c.Limits.ExportChunkSize = 0 },
+		"log.format":                     func(c *Config) { c.Log.Format = "xml" },
+		"log.level":                      func(c *Config) { c.Log.Level = "loud" },
+	} {
+		c := valid
+		c.Auth.SecretPepper = "pepper"
+		change(&c)
+		if err := c.Validate(); err == nil || !strings.Contains(err.Error(), key) {
+			t.Errorf("Expected %s to be reported, but got %v", key, err)
+		}
+	}
+
+	// Test case 3: Several invalid settings are reported together
+	c := valid
+	c.Listen.GRPC, c.Limits.ExportChunkSize = "", -1
+	if err := c.Validate(); err == nil || !strings.Contains(err.Error(), "listen.grpc") || !strings.Contains(err.Error(), "limits.export_chunk_size") {
+		t.Errorf("Expected both settings to be reported, but got %v", err)
+	}
+}
+
+// TestWrite checks that a written configuration reads back the same, but for
+// its redacted secrets.
+func TestWrite(t *testing.T) {
+	cfg := Default()
+	cfg.Listen.HTTP = ""
+	cfg.Auth.SecretPepper = "a very secret pepper"
+	cfg.Notify.SMTPPassword = "hunter2"
+	cfg.Auth.RateLimit.ClientRate = 0.25
+	cfg.Limits.DefaultStatsWindow = 90 * time.Minute
+
+	var buf bytes.Buffer
+	if err := Write(&buf, cfg); err != nil {
+		t.Fatalf("Expected no error, but got %v", err)
+	}
+	out := buf.String()
+	if strings.Contains(out, "a very secret pepper") || strings.Contains(out, "hunter2") {
+		t.Errorf("Expected the secrets to be redacted, but got:\n%s", out)
+	}
+	if !strings.Contains(out, "  max_page_size: 500\n") || !strings.Contains(out, "    client_rate: 0.25\n") {
+		t.Errorf("Expected nested settings, but got:\n%s", out)
+	}
+
+	read, _, err := load(t, out, nil, nil)
+	if err != nil {
+		t.Fatalf("Expected the written file to load, but got %v", err)
+	}
+	cfg.Auth.SecretPepper, cfg.Notify.SMTPPassword = "<redacted>", "<redacted>"
+	if read != cfg {
+		t.Errorf("Expected %+v, but read back %+v", cfg, read)
+	}
+}
@@ -0,0 +1,240 @@
+// Config/Load.go
+package Config
+
+import (
+	"complaint-portal/Common"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"reflect"
+	"strconv"
+	"strings"
+	"time"
+
+	"gopkg.in/yaml.v3"
+)
+
+var durationType = reflect.TypeOf(time.Duration(0))
+
+// Load returns the configuration given by, from lowest to highest precedence,
+// the defaults, the YAML file named by the -config flag or the CONFIG_FILE
+// environment variable, the environment and the command-line arguments. It
+// registers a flag for every setting on fs before parsing args with it, so
+// that fs.Args returns the arguments left. getenv reads the environment,
+// usually os.Getenv; empty variables are ignored.
+//
+// The configuration returned is not validated; see Config.Validate.
+func Load(fs *flag.FlagSet, args []string, getenv func(string) string) (Config, error) {
+	cfg := Default()
+	all := settings(&cfg)
+
+	// Flags are parsed first, for the file they may name, but applied last.
+	file := fs.String("config", "", "YAML configuration file; the environment and flags override it")
+	var flags []*flagValue
+	for _, s := range all {
+		if s.flag == "-" {
+			continue
+		}
+		v := &flagValue{setting: s}
+		fs.Var(v, s.flag, s.usage)
+		flags = append(flags, v)
+	}
+	if err := fs.Parse(args); err != nil {
+		return cfg, err
+	}
+
+	path := *file
+	if path == "" {
+		path = getenv(Common.EnvConfigFile)
+	}
+	if path != "" {
+		if err := readFile(&cfg, path); err != nil {
+			return cfg, err
+		}
+	}
+	for _, s := range all {
+		if s.env == "-" {
+			continue
+		}
+		if text := getenv(s.env); text != "" {
+			if err := s.set(text); err != nil {
+				return cfg, fmt.Errorf(Common.ErrInvalidSetting, s.env, err)
+			}
+		}
+	}
+	for _, v := range flags {
+		if v.text != nil {
+			// The flag package already had Set check the text.
+			v.setting.set(*v.text)
+		}
+	}
+	return cfg, nil
+}
+
+// readFile sets the settings found in the YAML file at path. Unknown keys are
+// errors, so that misspelt settings are not silently ignored.
+func readFile(cfg *Config, path string) error {
+	f, err := os.Open(path)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+	dec := yaml.NewDecoder(f)
+	dec.KnownFields(true)
+	if err := dec.Decode(cfg); err != nil && !errors.Is(err, io.EOF) {
+		return fmt.Errorf(Common.ErrReadConfig, path, err)
+	}
+	return nil
+}
+
+// Write writes c to w as a YAML configuration file, with its secrets redacted.
+func Write(w io.Writer, c Config) error {
+	root := &yaml.Node{Kind: yaml.MappingNode}
+	for _, s := range settings(&c) {
+		keys := strings.Split(s.key, ".")
+		parent := root
+		for _, key := range keys[:len(keys)-1] {
+			parent = section(parent, key)
+		}
+		value := &yaml.Node{Kind: yaml.ScalarNode, Value: s.String()}
+		if s.value.Kind() == reflect.String || s.value.Type() == durationType {
+			value.Tag = "!!str"
+		}
+		parent.Content = append(parent.Content, &yaml.Node{Kind: yaml.ScalarNode, Value: keys[len(keys)-1]}, value)
+	}
+	enc := yaml.NewEncoder(w)
+	enc.SetIndent(2)
+	if err := enc.Encode(root); err != nil {
+		return err
+	}
+	return enc.Close()
+}
+
+// section returns the mapping under key in parent, adding it if it is not the
+// last one. Settings come in the order of their fields, so the settings of a
+// section are never split.
+func section(parent *yaml.Node, key string) *yaml.Node {
+	if n := len(parent.Content); n > 0 && parent.Content[n-2].Value == key {
+		return parent.Content[n-1]
+	}
+	child := &yaml.Node{Kind: yaml.MappingNode}
+	parent.Content = append(parent.Content, &yaml.Node{Kind: yaml.ScalarNode, Value: key}, child)
+	return child
+}
+
+// setting is a single setting of a Config, with the names it is read under.
+type setting struct {
+	// key is the path of the setting in the file, such as "limits.max_page_size".
+	key   string
+	env   string
+	flag  string
+	usage string
+	value reflect.Value
+}
+
+// settings returns the settings of c, in the order of their fields. Setting
+// them sets the fields of c.
+func settings(c *Config) []setting {
+	var all []setting
+	var walk func(prefix string, v reflect.Value)
+	walk = func(prefix string, v reflect.Value) {
+		for i := 0; i < v.NumField(); i++ {
+			field := v.Type().Field(i)
+			key := prefix + field.Tag.Get("yaml")
+			if field.Type.Kind() == reflect.Struct {
+				walk(key+".", v.Field(i))
+				continue
+			}
+			s := setting{key: key, env: field.Tag.Get("env"), flag: field.Tag.Get("flag"), usage: field.Tag.Get("usage"), value: v.Field(i)}
+			if s.env == "" {
+				s.env = strings.ToUpper(strings.ReplaceAll(key, ".", "_"))
+			}
+			if s.flag == "" {
+				s.flag = strings.ReplaceAll(key, "_", "-")
+			}
+			all = append(all, s)
+		}
+	}
+	walk("", reflect.ValueOf(c).Elem())
+	return all
+}
+
+// set parses text as the value of the setting. The setting is left unchanged
+// if text is invalid.
+func (s setting) set(text string) error {
+	switch {
+	case s.value.Type() == durationType:
+		d, err := time.ParseDuration(text)
+		if err != nil {
+			return err
+		}
+		s.value.SetInt(int64(d))
+	case s.value.Kind() == reflect.String:
+		s.value.SetString(text)
+	case s.value.Kind() == reflect.Bool:
+		b, err := strconv.ParseBool(text)
+		if err != nil {
+			return err
+		}
+		s.value.SetBool(b)
+	case s.value.Kind() == reflect.Int:
+		n, err := strconv.Atoi(text)
+		if err != nil {
+			return err
+		}
+		s.value.SetInt(int64(n))
+	case s.value.Kind() == reflect.Float64:
+		f, err := strconv.ParseFloat(text, 64)
+		if err != nil {
+			return err
+		}
+		s.value.SetFloat(f)
+	default:
+		panic("Config: unsupported setting type " + s.value.Type().String())
+	}
+	return nil
+}
+
+// String formats the value of the setting as set accepts it, except for
+// secrets, which are redacted.
+func (s setting) String() string {
+	switch v := s.value.Interface().(type) {
+	case Secret:
+		return v.String()
+	case time.Duration:
+		return v.String()
+	}
+	return fmt.Sprint(s.value.Interface())
+}
+
+// flagValue is the flag.Value of a setting. It keeps the text of the flag,
+// which is only set once the file and the environment have been read.
+type flagValue struct {
+	setting setting
+	text    *string
+}
+
+func (f *flagValue) String() string {
+	// The flag package calls String on zero flagValues.
+	if f == nil || !f.setting.value.IsValid() {
+		return ""
+	}
+	return f.setting.String()
+}
+
+func (f *flagValue) Set(text string) error {
+	scratch := f.setting
+	scratch.value = reflect.New(scratch.value.Type()).Elem()
+	if err := scratch.set(text); err != nil {
+		return err
+	}
+	f.text = &text
+	return nil
+}
+
+// IsBoolFlag lets boolean settings be given as -name, without a value.
+func (f *flagValue) IsBoolFlag() bool {
+	return f.setting.value.Kind() == reflect.Bool
+}
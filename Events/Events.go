@@ -0,0 +1,245 @@
+// Events/Events.go
+package Events
+
+import (
+	"complaint-portal/Common"
+	"context"
+	"crypto/rand"
+	"encoding/base64"
+	"encoding/hex"
+	"errors"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+// Type is what happened to a complaint.
+type Type int
+
+const (
+	Created Type = iota + 1
+	Updated
+	// Resolved is an update that resolved the complaint.
+	Resolved
+)
+
+// DefaultHistory is how many events a bus keeps for resuming subscribers.
+const DefaultHistory = 1024
+
+// subscriberBuffer is how many events a subscriber may fall behind before it is dropped.
+const subscriberBuffer = 64
+
+var (
+	// ErrInvalidResumeToken is returned by Subscribe for a token no bus issued.
+	ErrInvalidResumeToken = errors.New("invalid resume token")
+	// ErrResumeExpired is returned by Subscribe when events after the token are
+	// no longer kept, or were kept by a bus that has since restarted.
+	ErrResumeExpired = errors.New("resume token expired")
+	// ErrSlowSubscriber is returned by Next once the subscriber fell too far
+	// behind and was dropped. It can resume from the last event it received.
+	ErrSlowSubscriber = errors.New("subscriber fell behind")
+)
+
+// Event is a change to a complaint.
+type Event struct {
+	// Seq orders the events of a bus, starting at 1.
+	Seq  uint64
+	Type Type
+	// Complaint is the complaint after the change.
+	Complaint Common.Complaint
+	At        time.Time
+	// Token resumes a subscription right after this event.
+	Token string
+}
+
+// Filter selects events. Empty fields match every event.
+type Filter struct {
+	UserID string
+}
+
+// Matches reports whether the filter selects the event.
+func (f Filter) Matches(e Event) bool {
+	return f.UserID == "" || e.Complaint.UserID == f.UserID
+}
+
+// UpdateType returns Resolved if the complaint's latest change resolved it, and
+// Updated otherwise.
+func UpdateType(c Common.Complaint) Type {
+	if c.Resolved && !c.ResolvedAt.IsZero() && c.ResolvedAt.Equal(c.UpdatedAt) {
+		return Resolved
+	}
+	return Updated
+}
+
+// Bus fans complaint events out to subscribers, and keeps the latest ones so
+// that subscribers can resume where they left off. It is safe for concurrent use.
+type Bus struct {
+	mu sync.Mutex
+	// epoch tells the tokens of this bus from those of an earlier process.
+	epoch   string
+	seq     uint64
+	history []Event // ring of the latest events; history[seq%len] is event seq
+	subs    map[*Subscription]struct{}
+	// versions is the UpdatedAt of the last event published for each complaint.
+	versions map[string]time.Time
+	now      func() time.Time
+}
+
+// NewBus returns a bus keeping the latest history events.
+func NewBus(history int) *Bus {
+	epoch := make([]byte, 8)
+	rand.Read(epoch)
+	return &Bus{
+		epoch:    hex.EncodeToString(epoch),
+		history:  make([]Event, history),
+		subs:     map[*Subscription]struct{}{},
+		versions: map[string]time.Time{},
+		now:      time.Now,
+	}
+}
+
+// Publish sends an event for the complaint to every matching subscriber.
+func (b *Bus) Publish(t Type, c Common.Complaint) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.publish(t, c)
+}
+
+// PublishIfNew is Publish for sources that may report a change the bus has
+// already seen, such as a database listener also seeing this server's writes.
+// It skips the complaint if its UpdatedAt is that of the last event for it, and
+// reports whether it was published.
+func (b *Bus) PublishIfNew(t Type, c Common.Complaint) bool {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	if last, ok := b.versions[c.ID]; ok && last.Equal(c.UpdatedAt) {
+		return false
+	}
+	b.publish(t, c)
+	return true
+}
+
+// publish sends an event; b.mu must be held.
+func (b *Bus) publish(t Type, c Common.Complaint) {
+	b.seq++
+	e := Event{Seq: b.seq, Type: t, Complaint: c, At: b.now(), Token: b.token(b.seq)}
+	b.versions[c.ID] = c.UpdatedAt
+	if len(b.history) > 0 {
+		b.history[b.seq%uint64(len(b.history))] = e
+	}
+	for sub := range b.subs {
+		if !sub.filter.Matches(e) {
+			continue
+		}
+		select {
+		case sub.ch <- e:
+		default:
+			b.drop(sub)
+		}
+	}
+}
+
+// token encodes the position right after event seq.
+func (b *Bus) token(seq uint64) string {
+	return base64.RawURLEncoding.EncodeToString([]byte(b.epoch + "." + strconv.FormatUint(seq, 10)))
+}
+
+// parseToken decodes a token into the epoch and sequence number it was issued with.
+func parseToken(token string) (string, uint64, error) {
+	raw, err := base64.RawURLEncoding.DecodeString(token)
+	if err != nil {
+		return "", 0, ErrInvalidResumeToken
+	}
+	epoch, seq, ok := strings.Cut(string(raw), ".")
+	if !ok {
+		return "", 0, ErrInvalidResumeToken
+	}
+	n, err := strconv.ParseUint(seq, 10, 64)
+	if err != nil {
+		return "", 0, ErrInvalidResumeToken
+	}
+	return epoch, n, nil
+}
+
+// Subscribe returns a subscription to the events selected by filter. With a
+// resume token, it first replays the kept events published after the token's.
+// Otherwise it starts with the next event published.
+func (b *Bus) Subscribe(filter Filter, resumeToken string) (*Subscription, error) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	sub := &Subscription{bus: b, filter: filter, ch: make(chan Event, subscriberBuffer)}
+	if resumeToken != "" {
+		epoch, after, err := parseToken(resumeToken)
+		if err != nil {
+			return nil, err
+		}
+		if epoch != b.epoch {
+			return nil, ErrResumeExpired
+		}
+		if after > b.seq {
+			return nil, ErrInvalidResumeToken
+		}
+		if b.seq-after > uint64(len(b.history)) {
+			return nil, ErrResumeExpired
+		}
+		for seq := after + 1; seq <= b.seq; seq++ {
+			if e := b.history[seq%uint64(len(b.history))]; filter.Matches(e) {
+				sub.backlog = append(sub.backlog, e)
+			}
+		}
+	}
+	b.subs[sub] = struct{}{}
+	return sub, nil
+}
+
+// Subscribers returns the number of active subscriptions.
+func (b *Bus) Subscribers() int {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return len(b.subs)
+}
+
+// drop unregisters a subscriber and closes its channel; b.mu must be held.
+func (b *Bus) drop(sub *Subscription) {
+	if _, ok := b.subs[sub]; ok {
+		delete(b.subs, sub)
+		close(sub.ch)
+	}
+}
+
+// Subscription receives the events of a bus. It is meant to be used by a
+// single goroutine.
+type Subscription struct {
+	bus     *Bus
+	filter  Filter
+	backlog []Event
+	ch      chan Event
+}
+
+// Next waits for the next event. It returns ctx's error once ctx is done, and
+// ErrSlowSubscriber if the subscription was dropped for falling behind.
+func (s *Subscription) Next(ctx context.Context) (Event, error) {
+	if len(s.backlog) > 0 {
+		e := s.backlog[0]
+		s.backlog = s.backlog[1:]
+		return e, nil
+	}
+	select {
+	case e, ok := <-s.ch:
+		if !ok {
+			return Event{}, ErrSlowSubscriber
+		}
+		return e, nil
+	case <-ctx.Done():
+		return Event{}, ctx.Err()
+	}
+}
+
+// Close ends the subscription.
+func (s *Subscription) Close() {
+	s.bus.mu.Lock()
+	defer s.bus.mu.Unlock()
+	s.bus.drop(s)
+}
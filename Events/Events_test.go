@@ -0,0 +1,183 @@
+// Events/Events_test.go
+package Events
+
+import (
+	"complaint-portal/Common"
+	"complaint-portal/Storage"
+	"context"
+	"errors"
+	"testing"
+	"time"
+)
+
+// next returns the subscription's next event, failing the test if none comes quickly.
+func next(t *testing.T, sub *Subscription) Event {
+	t.Helper()
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+	defer cancel()
+	e, err := sub.Next(ctx)
+	if err != nil {
+		t.Fatalf("Expected an event, but got %v", err)
+	}
+	return e
+}
+
+// TestBus tests publishing, filtering and resuming.
+func TestBus(t *testing.T) {
+	bus := NewBus(3)
+	all, _ := bus.Subscribe(Filter{}, "")
+	mine, _ := bus.Subscribe(Filter{UserID: "u1"}, "")
+	defer all.Close()
+	defer mine.Close()
+
+	bus.Publish(Created, Common.Complaint{ID: "c1", UserID: "u1"})
+	bus.Publish(Created, Common.Complaint{ID: "c2", UserID: "u2"})
+	bus.Publish(Updated, Common.Complaint{ID: "c1", UserID: "u1"})
+
+	// Test case 1: Subscribers receive the events selected by their filter, in order
+	first := next(t, all)
+	if first.Seq != 1 || first.Type != Created || first.Complaint.ID != "c1" || first.Token == "" {
+		t.Errorf("Expected the creation of c1 first, but got %+v", first)
+	}
+	if e := next(t, all); e.Complaint.ID != "c2" {
+		t.Errorf("Expected c2 second, but got %+v", e)
+	}
+	if e := next(t, mine); e.Complaint.ID != "c1" || e.Type != Created {
+		t.Errorf("Expected the creation of c1, but got %+v", e)
+	}
+	if e := next(t, mine); e.Complaint.ID != "c1" || e.Type != Updated {
+		t.Errorf("Expected the update of c1 and nothing of u2, but got %+v", e)
+	}
+
+	// Test case 2: Resuming replays the kept events after the token
+	resumed, err := bus.Subscribe(Filter{}, first.Token)
+	if err != nil {
+		t.Fatalf("Expected to resume, but got %v", err)
+	}
+	if e := next(t, resumed); e.Seq != 2 {
+		t.Errorf("Expected to resume at event 2, but got %+v", e)
+	}
+	if e := next(t, resumed); e.Seq != 3 {
+		t.Errorf("Expected event 3, but got %+v", e)
+	}
+	bus.Publish(Resolved, Common.Complaint{ID: "c2", UserID: "u2"})
+	if e := next(t, resumed); e.Seq != 4 || e.Type != Resolved {
+		t.Errorf("Expected the live event 4, but got %+v", e)
+	}
+	resumed.Close()
+
+	// Test case 3: Tokens older than the kept events, or from another bus, have expired
+	bus.Publish(Updated, Common.Complaint{ID: "c2", UserID: "u2"})
+	if _, err := bus.Subscribe(Filter{}, first.Token); !errors.Is(err, ErrResumeExpired) {
+		t.Errorf("Expected ErrResumeExpired once event 2 is dropped, but got %v", err)
+	}
+	if _, err := NewBus(3).Subscribe(Filter{}, first.Token); !errors.Is(err, ErrResumeExpired) {
+		t.Errorf("Expected ErrResumeExpired from another bus, but got %v", err)
+	}
+
+	// Test case 4: Malformed tokens and tokens from the future are invalid
+	for _, token := range []string{"%%%", "bm9wZQ", bus.token(99)} {
+		if _, err := bus.Subscribe(Filter{}, token); !errors.Is(err, ErrInvalidResumeToken) {
+			t.Errorf("Expected ErrInvalidResumeToken for %q, but got %v", token, err)
+		}
+	}
+}
+
+// TestSlowSubscriber tests that a subscriber falling behind is dropped and can resume.
+func TestSlowSubscriber(t *testing.T) {
+	bus := NewBus(DefaultHistory)
+	sub, _ := bus.Subscribe(Filter{}, "")
+	first := Event{}
+	for i := 0; i <= subscriberBuffer; i++ {
+		bus.Publish(Created, Common.Complaint{ID: "c"})
+	}
+	for i := 0; i < subscriberBuffer; i++ {
+		if e := next(t, sub); i == 0 {
+			first = e
+		}
+	}
+	if _, err := sub.Next(context.Background()); !errors.Is(err, ErrSlowSubscriber) {
+		t.Fatalf("Expected ErrSlowSubscriber, but got %v", err)
+	}
+	sub.Close()
+
+	resumed, err := bus.Subscribe(Filter{}, first.Token)
+	if err != nil {
+		t.Fatalf("Expected to resume, but got %v", err)
+	}
+	if e := next(t, resumed); e.Seq != 2 {
+		t.Errorf("Expected to resume at event 2, but got %+v", e)
+	}
+}
+
+// TestPublishIfNew tests that a change already published is not published again.
+func TestPublishIfNew(t *testing.T) {
+	bus := NewBus(DefaultHistory)
+	at := time.Now()
+	bus.Publish(Updated, Common.Complaint{ID: "c1", UpdatedAt: at})
+	if bus.PublishIfNew(Updated, Common.Complaint{ID: "c1", UpdatedAt: at}) {
+		t.Errorf("Expected the same version not to be published again")
+	}
+	if !bus.PublishIfNew(Updated, Common.Complaint{ID: "c1", UpdatedAt: at.Add(time.Second)}) {
+		t.Errorf("Expected a newer version to be published")
+	}
+	if !bus.PublishIfNew(Created, Common.Complaint{ID: "c2"}) {
+		t.Errorf("Expected an unknown complaint to be published")
+	}
+}
+
+// TestUpdateType tests that only the change resolving a complaint is a Resolved event.
+func TestUpdateType(t *testing.T) {
+	at := time.Now()
+	c := Common.Complaint{ID: "c1"}
+	c.SetStatus(Common.StatusResolved, "agent", "", at)
+	if got := UpdateType(c); got != Resolved {
+		t.Errorf("Expected Resolved, but got %v", got)
+	}
+	c.SetStatus(Common.StatusClosed, "agent", "", at.Add(time.Minute))
+	if got := UpdateType(c); got != Updated {
+		t.Errorf("Expected Updated for closing a resolved complaint, but got %v", got)
+	}
+}
+
+// TestPublishingStore checks that complaint writes through the store are published.
+func TestPublishingStore(t *testing.T) {
+	ctx := context.Background()
+	memory := Storage.NewMemoryStore()
+	memory.Reset()
+	defer memory.Reset()
+	if err := memory.CreateUser(ctx, Common.User{ID: "u1", Name: "Ada", Email: "ada@example.com"}); err != nil {
+		t.Fatalf("Failed to create user: %v", err)
+	}
+
+	bus := NewBus(DefaultHistory)
+	store := NewPublishingStore(memory, bus)
+	sub, _ := bus.Subscribe(Filter{}, "")
+	defer sub.Close()
+
+	if err := store.CreateComplaint(ctx, Common.Complaint{ID: "c1", UserID: "u1", Status: Common.StatusOpen}); err != nil {
+		t.Fatalf("Failed to create complaint: %v", err)
+	}
+	if e := next(t, sub); e.Type != Created || e.Complaint.ID != "c1" {
+		t.Errorf("Expected the creation of c1, but got %+v", e)
+	}
+	_, err := store.UpdateComplaint(ctx, "c1", func(c *Common.Complaint) error {
+		c.SetStatus(Common.StatusResolved, "agent", "", time.Now())
+		return nil
+	})
+	if err != nil {
+		t.Fatalf("Failed to update complaint: %v", err)
+	}
+	if e := next(t, sub); e.Type != Resolved || !e.Complaint.Resolved {
+		t.Errorf("Expected c1 to be resolved, but got %+v", e)
+	}
+
+	// Failed writes publish nothing.
+	if err := store.CreateComplaint(ctx, Common.Complaint{ID: "c2", UserID: "nobody"}); err == nil {
+		t.Fatalf("Expected an error for a missing owner")
+	}
+	bus.Publish(Updated, Common.Complaint{ID: "marker"})
+	if e := next(t, sub); e.Complaint.ID != "marker" {
+		t.Errorf("Expected no event for the failed write, but got %+v", e)
+	}
+}
@@ -0,0 +1,36 @@
+// Events/Store.go
+package Events
+
+import (
+	"complaint-portal/Common"
+	"complaint-portal/Storage"
+	"context"
+)
+
+// PublishingStore is a Storage.Store that publishes an event on a Bus for every
+// complaint created or updated through it.
+type PublishingStore struct {
+	Storage.Store
+	Bus *Bus
+}
+
+// NewPublishingStore wraps store so that its complaint writes are published on bus.
+func NewPublishingStore(store Storage.Store, bus *Bus) *PublishingStore {
+	return &PublishingStore{Store: store, Bus: bus}
+}
+
+func (s *PublishingStore) CreateComplaint(ctx context.Context, complaint Common.Complaint) error {
+	if err := s.Store.CreateComplaint(ctx, complaint); err != nil {
+		return err
+	}
+	s.Bus.Publish(Created, complaint)
+	return nil
+}
+
+func (s *PublishingStore) UpdateComplaint(ctx context.Context, id string, update func(*Common.Complaint) error) (Common.Complaint, error) {
+	complaint, err := s.Store.UpdateComplaint(ctx, id, update)
+	if err == nil {
+		s.Bus.Publish(UpdateType(complaint), complaint)
+	}
+	return complaint, err
+}
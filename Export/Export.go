@@ -0,0 +1,265 @@
+// Export/Export.go
+package Export
+
+import (
+	"bytes"
+	"complaint-portal/Common"
+	"complaint-portal/Storage"
+	"context"
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Format is the file format of an export.
+type Format int
+
+const (
+	// CSV writes a header row, then one row per complaint.
+	CSV Format = iota
+	// NDJSON writes one JSON object per complaint and line.
+	NDJSON
+)
+
+// Formats maps the names accepted by ParseFormat to formats.
+var Formats = map[string]Format{"csv": CSV, "ndjson": NDJSON}
+
+// ParseFormat returns the format with the given name.
+func ParseFormat(name string) (Format, error) {
+	f, ok := Formats[strings.ToLower(name)]
+	if !ok {
+		return 0, fmt.Errorf("unknown export format %q", name)
+	}
+	return f, nil
+}
+
+// Column is an exported field of a complaint or of its user.
+type Column string
+
+const (
+	ColumnID         Column = "id"
+	ColumnTitle      Column = "title"
+	ColumnSummary    Column = "summary"
+	ColumnSeverity   Column = "severity"
+	ColumnStatus     Column = "status"
+	ColumnResolved   Column = "resolved"
+	ColumnUserID     Column = "user_id"
+	ColumnUserName   Column = "user_name"
+	ColumnUserEmail  Column = "user_email"
+	ColumnCreatedAt  Column = "created_at"
+	ColumnUpdatedAt  Column = "updated_at"
+	ColumnResolvedAt Column = "resolved_at"
+)
+
+// Columns are every column, in the order used when none are selected.
+var Columns = []Column{
+	ColumnID, ColumnTitle, ColumnSummary, ColumnSeverity, ColumnStatus, ColumnResolved,
+	ColumnUserID, ColumnUserName, ColumnUserEmail, ColumnCreatedAt, ColumnUpdatedAt, ColumnResolvedAt,
+}
+
+// ErrUnknownColumn is returned by ParseColumns for a name that is not a column.
+var ErrUnknownColumn = errors.New("unknown column")
+
+// ParseColumns returns the named columns, in order, or every column if names is
+// empty. A column named twice is only exported once.
+func ParseColumns(names []string) ([]Column, error) {
+	if len(names) == 0 {
+		return Columns, nil
+	}
+	known := map[Column]bool{}
+	for _, c := range Columns {
+		known[c] = true
+	}
+	var columns []Column
+	seen := map[Column]bool{}
+	for _, name := range names {
+		c := Column(strings.ToLower(strings.TrimSpace(name)))
+		if !known[c] {
+			return nil, fmt.Errorf("%w %q", ErrUnknownColumn, name)
+		}
+		if !seen[c] {
+			seen[c] = true
+			columns = append(columns, c)
+		}
+	}
+	return columns, nil
+}
+
+// pageSize is the number of complaints read from the store at a time. It is a
+// variable so that tests can make it small.
+var pageSize = 500
+
+// Write exports every complaint selected by the query's filters, in its order,
+// to w, with the name and email of its user. It ignores the query's cursor and
+// limit: complaints are read and written a page at a time, so an export of any
+// size only holds one page in memory. It returns how many complaints it wrote.
+func Write(ctx context.Context, store Storage.Store, query Storage.ComplaintQuery, w io.Writer, format Format, columns []Column) (int, error) {
+	rows, err := newRowWriter(w, format, columns)
+	if err != nil {
+		return 0, err
+	}
+	users := Storage.NewUserLookup(store)
+	query.After, query.Limit = nil, pageSize
+
+	n := 0
+	for {
+		page, err := store.QueryComplaints(ctx, query)
+		if err != nil {
+			return n, err
+		}
+		ids := make([]string, len(page))
+		for i, c := range page {
+			ids[i] = c.UserID
+		}
+		if err := users.Load(ctx, ids); err != nil {
+			return n, err
+		}
+		for _, c := range page {
+			u, _ := users.Get(c.UserID)
+			if err := rows.write(c, u); err != nil {
+				return n, err
+			}
+			n++
+		}
+		if err := rows.flush(); err != nil {
+			return n, err
+		}
+		if len(page) < pageSize {
+			return n, nil
+		}
+		after := Storage.CursorOf(page[len(page)-1])
+		query.After = &after
+	}
+}
+
+// rowWriter writes complaints in one format.
+type rowWriter struct {
+	w       io.Writer
+	csv     *csv.Writer
+	columns []Column
+}
+
+func newRowWriter(w io.Writer, format Format, columns []Column) (*rowWriter, error) {
+	r := &rowWriter{w: w, columns: columns}
+	switch format {
+	case CSV:
+		r.csv = csv.NewWriter(w)
+		header := make([]string, len(columns))
+		for i, c := range columns {
+			header[i] = string(c)
+		}
+		return r, r.csv.Write(header)
+	case NDJSON:
+		return r, nil
+	default:
+		return nil, fmt.Errorf("unknown export format %d", format)
+	}
+}
+
+// write writes one complaint and its user, who is the zero User if missing.
+func (r *rowWriter) write(c Common.Complaint, u Common.User) error {
+	if r.csv != nil {
+		record := make([]string, len(r.columns))
+		for i, col := range r.columns {
+			record[i] = text(value(col, c, u))
+		}
+		return r.csv.Write(record)
+	}
+
+	// Objects are built by hand so that keys keep the order of the columns.
+	var buf bytes.Buffer
+	buf.WriteByte('{')
+	for i, col := range r.columns {
+		if i > 0 {
+			buf.WriteByte(',')
+		}
+		key, _ := json.Marshal(string(col))
+		val, err := json.Marshal(value(col, c, u))
+		if err != nil {
+			return err
+		}
+		buf.Write(key)
+		buf.WriteByte(':')
+		buf.Write(val)
+	}
+	buf.WriteString("}\n")
+	_, err := r.w.Write(buf.Bytes())
+	return err
+}
+
+// flush writes out anything buffered.
+func (r *rowWriter) flush() error {
+	if r.csv != nil {
+		r.csv.Flush()
+		return r.csv.Error()
+	}
+	return nil
+}
+
+// value returns a column of a complaint and its user: a string, an int, a bool,
+// or nil for a time that is not known.
+func value(col Column, c Common.Complaint, u Common.User) interface{} {
+	switch col {
+	case ColumnID:
+		return c.ID
+	case ColumnTitle:
+		return c.Title
+	case ColumnSummary:
+		return c.Summary
+	case ColumnSeverity:
+		return c.Severity
+	case ColumnStatus:
+		return string(c.CurrentStatus())
+	case ColumnResolved:
+		return c.Resolved
+	case ColumnUserID:
+		return c.UserID
+	case ColumnUserName:
+		return u.Name
+	case ColumnUserEmail:
+		return u.Email
+	case ColumnCreatedAt:
+		return timeValue(c.CreatedAt)
+	case ColumnUpdatedAt:
+		return timeValue(c.UpdatedAt)
+	case ColumnResolvedAt:
+		return timeValue(c.ResolvedAt)
+	}
+	return nil
+}
+
+// timeValue formats a time as RFC 3339 in UTC, or returns nil for the zero time.
+func timeValue(t time.Time) interface{} {
+	if t.IsZero() {
+		return nil
+	}
+	return t.UTC().Format(time.RFC3339Nano)
+}
+
+// text formats a value for a CSV cell.
+func text(v interface{}) string {
+	switch v := v.(type) {
+	case string:
+		return csvSafe(v)
+	case int:
+		return strconv.Itoa(v)
+	case bool:
+		return strconv.FormatBool(v)
+	}
+	return ""
+}
+
+// csvSafe keeps spreadsheets from running a cell as a formula, by prefixing the
+// cells that would be with a quote. Titles and summaries are written by
+// customers, so an export must not execute them when opened.
+func csvSafe(s string) string {
+	if s != "" && strings.ContainsRune("=+-@\t\r", rune(s[0])) {
+		return "'" + s
+	}
+	return s
+}
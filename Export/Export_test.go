@@ -0,0 +1,155 @@
+// Export/Export_test.go
+package Export
+
+import (
+	"bytes"
+	"complaint-portal/Common"
+	"complaint-portal/Storage"
+	"context"
+	"encoding/csv"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+)
+
+// hidingStore hides a user from GetUsers, as if it had been deleted.
+type hidingStore struct {
+	Storage.Store
+	hidden string
+}
+
+func (s hidingStore) GetUsers(ctx context.Context, ids []string) (map[string]Common.User, error) {
+	users, err := s.Store.GetUsers(ctx, ids)
+	delete(users, s.hidden)
+	return users, err
+}
+
+// newExportStore returns a store with three complaints, one of them belonging
+// to a user who no longer exists.
+func newExportStore(t *testing.T) Storage.Store {
+	ctx := context.Background()
+	store := Storage.NewMemoryStore()
+	store.Reset()
+	t.Cleanup(store.Reset)
+	for _, u := range []Common.User{{ID: "u1", Name: "Ada", Email: "ada@example.com"}, {ID: "gone", Name: "Gone"}} {
+		if err := store.CreateUser(ctx, u); err != nil {
+			t.Fatalf("Failed to create user: %v", err)
+		}
+	}
+	created := time.Date(2026, time.March, 2, 10, 0, 0, 0, time.UTC)
+	complaints := []Common.Complaint{
+		{ID: "c1", UserID: "u1", Title: "Router, again", Summary: "It says \"no\"", Severity: 2, CreatedAt: created, UpdatedAt: created,
+			Resolved: true, Status: Common.StatusResolved, ResolvedAt: created.Add(time.Hour)},
+		{ID: "c2", UserID: "u1", Title: "=HYPERLINK(\"http://x\")", Severity: 4, CreatedAt: created.Add(time.Minute), UpdatedAt: created.Add(time.Minute)},
+		{ID: "c3", UserID: "gone", Title: "Orphan", Severity: 1, CreatedAt: created.Add(2 * time.Minute), UpdatedAt: created.Add(2 * time.Minute)},
+	}
+	for _, c := range complaints {
+		if err := store.CreateComplaint(ctx, c); err != nil {
+			t.Fatalf("Failed to create complaint: %v", err)
+		}
+	}
+	return hidingStore{Store: store, hidden: "gone"}
+}
+
+func TestParseColumns(t *testing.T) {
+	// Test case 1: No names selects every column
+	columns, err := ParseColumns(nil)
+	if err != nil || len(columns) != len(Columns) {
+		t.Errorf("Expected every column, but got %v (err: %v)", columns, err)
+	}
+
+	// Test case 2: Names keep their order and are deduplicated
+	columns, err = ParseColumns([]string{"title", " ID ", "title"})
+	if err != nil || len(columns) != 2 || columns[0] != ColumnTitle || columns[1] != ColumnID {
+		t.Errorf("Expected [title id], but got %v (err: %v)", columns, err)
+	}
+
+	// Test case 3: Unknown columns are refused
+	if _, err := ParseColumns([]string{"id", "password"}); !errors.Is(err, ErrUnknownColumn) {
+		t.Errorf("Expected ErrUnknownColumn, but got %v", err)
+	}
+}
+
+func TestWriteCSV(t *testing.T) {
+	store := newExportStore(t)
+	ctx := context.Background()
+	defer func(n int) { pageSize = n }(pageSize)
+	pageSize = 1
+
+	// Test case 1: Every page is written, with users joined in and formulas defused
+	var buf bytes.Buffer
+	query := Storage.ComplaintQuery{Sort: Storage.OldestFirst}
+	n, err := Write(ctx, store, query, &buf, CSV, []Column{ColumnID, ColumnTitle, ColumnSummary, ColumnUserName, ColumnUserEmail, ColumnResolvedAt})
+	if err != nil || n != 3 {
+		t.Fatalf("Expected 3 complaints, but got %d (err: %v)", n, err)
+	}
+	records, err := csv.NewReader(&buf).ReadAll()
+	if err != nil {
+		t.Fatalf("Expected valid CSV, but got %v", err)
+	}
+	want := [][]string{
+		{"id", "title", "summary", "user_name", "user_email", "resolved_at"},
+		{"c1", "Router, again", "It says \"no\"", "Ada", "ada@example.com", "2026-03-02T11:00:00Z"},
+		{"c2", "'=HYPERLINK(\"http://x\")", "", "Ada", "ada@example.com", ""},
+		{"c3", "Orphan", "", "", "", ""},
+	}
+	if len(records) != len(want) {
+		t.Fatalf("Expected %d records, but got %v", len(want), records)
+	}
+	for i := range want {
+		if strings.Join(records[i], "|") != strings.Join(want[i], "|") {
+			t.Errorf("Expected record %d to be %q, but got %q", i, want[i], records[i])
+		}
+	}
+
+	// Test case 2: Filters are applied
+	buf.Reset()
+	query.MinSeverity = 2
+	if n, err := Write(ctx, store, query, &buf, CSV, []Column{ColumnID}); err != nil || n != 2 || buf.String() != "id\nc1\nc2\n" {
+		t.Errorf("Expected c1 and c2, but got %d: %q (err: %v)", n, buf.String(), err)
+	}
+
+	// Test case 3: An empty export still has its header
+	buf.Reset()
+	query.MinSeverity = 5
+	if n, err := Write(ctx, store, query, &buf, CSV, []Column{ColumnID, ColumnSeverity}); err != nil || n != 0 || buf.String() != "id,severity\n" {
+		t.Errorf("Expected only the header, but got %d: %q (err: %v)", n, buf.String(), err)
+	}
+}
+
+// TestCSVSafe checks that every cell a spreadsheet would run as a formula is
+// quoted, and that the others are kept as they are.
+func TestCSVSafe(t *testing.T) {
+	for in, want := range map[string]string{
+		"=cmd|' /C calc'!A0": "'=cmd|' /C calc'!A0",
+		"+1+1":               "'+1+1",
+		"-2+3":               "'-2+3",
+		"@SUM(A1:A2)":        "'@SUM(A1:A2)",
+		"\t=1+1":             "'\t=1+1",
+		"\r=1+1":             "'\r=1+1",
+		"Router = broken":    "Router = broken",
+		"":                   "",
+	} {
+		if got := csvSafe(in); got != want {
+			t.Errorf("Expected csvSafe(%q) to be %q, but got %q", in, want, got)
+		}
+	}
+}
+
+func TestWriteNDJSON(t *testing.T) {
+	store := newExportStore(t)
+	var buf bytes.Buffer
+	query := Storage.ComplaintQuery{Sort: Storage.OldestFirst, UserID: "u1"}
+	n, err := Write(context.Background(), store, query, &buf, NDJSON, []Column{ColumnSeverity, ColumnID, ColumnResolved, ColumnStatus, ColumnResolvedAt})
+	if err != nil || n != 2 {
+		t.Fatalf("Expected 2 complaints, but got %d (err: %v)", n, err)
+	}
+
+	// Test case 1: Keys follow the columns and values keep their JSON types
+	want := `{"severity":2,"id":"c1","resolved":true,"status":"Resolved","resolved_at":"2026-03-02T11:00:00Z"}` + "\n" +
+		`{"severity":4,"id":"c2","resolved":false,"status":"Open","resolved_at":null}` + "\n"
+	if buf.String() != want {
+		t.Errorf("Expected %q, but got %q", want, buf.String())
+	}
+}
@@ -0,0 +1,315 @@
+// Gateway/Gateway.go
+package Gateway
+
+import (
+	"bytes"
+	"complaint-portal/Common"
+	pb "complaint-portal/Generated/ComplaintService"
+	"complaint-portal/Logging"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/encoding/protojson"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protoreflect"
+	"google.golang.org/protobuf/reflect/protoregistry"
+)
+
+// maxBodySize bounds the JSON body of a request.
+const maxBodySize = 1 << 20
+
+// Service is the descriptor of the service the gateway exposes.
+var Service = pb.File_proto_complaint_proto.Services().ByName("ComplaintService")
+
+// marshalOptions write every field, so that clients see zero values too.
+var marshalOptions = protojson.MarshalOptions{EmitUnpopulated: true}
+
+// Gateway serves ComplaintService over HTTP/JSON, relaying each request to the
+// gRPC server, so that the same interceptors authorize, rate limit and audit
+// it. Messages are serialized with protojson; see Routes for the paths, and
+// OpenAPIPath for their description.
+//
+// Clients authenticate with the usual "Authorization: Bearer <token>" header,
+// or with their secret code in the request or the X-Secret-Code header. Errors
+// are returned as a JSON google.rpc.Status, with the gRPC code mapped to the
+// closest HTTP status.
+type Gateway struct {
+	conn grpc.ClientConnInterface
+	mux  *http.ServeMux
+}
+
+// New returns a gateway relaying requests over conn.
+func New(conn grpc.ClientConnInterface) *Gateway {
+	g := &Gateway{conn: conn, mux: http.NewServeMux()}
+	for _, route := range Routes {
+		method := Service.Methods().ByName(protoreflect.Name(route.RPC))
+		if method == nil {
+			panic("Gateway: no RPC named " + route.RPC)
+		}
+		g.mux.Handle(route.Method+" "+route.Path, g.handler(route, method))
+	}
+	spec, err := OpenAPI()
+	if err != nil {
+		panic(err)
+	}
+	g.mux.HandleFunc("GET "+OpenAPIPath, func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		w.Write(spec)
+	})
+	return g
+}
+
+// ServeHTTP implements http.Handler.
+func (g *Gateway) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	g.mux.ServeHTTP(w, r)
+}
+
+func (g *Gateway) handler(route Route, method protoreflect.MethodDescriptor) http.HandlerFunc {
+	fullMethod := "/" + string(Service.FullName()) + "/" + route.RPC
+	return func(w http.ResponseWriter, r *http.Request) {
+		req, err := newRequest(r, route, method.Input())
+		if err != nil {
+			writeError(w, err)
+			return
+		}
+		ctx := outgoingContext(w, r)
+		if method.IsStreamingServer() {
+			g.stream(ctx, w, fullMethod, req, method.Output())
+			return
+		}
+		res := newMessage(method.Output())
+		if err := g.conn.Invoke(ctx, fullMethod, req, res); err != nil {
+			writeError(w, err)
+			return
+		}
+		body, err := marshalOptions.Marshal(res)
+		if err != nil {
+			writeError(w, status.Errorf(codes.Internal, "%v", err))
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		w.Write(body)
+	}
+}
+
+// stream relays a server-streaming RPC. Exports are written as the file they
+// form; other streams as newline-delimited JSON, one message per line, flushed
+// as they arrive.
+func (g *Gateway) stream(ctx context.Context, w http.ResponseWriter, fullMethod string, req proto.Message, output protoreflect.MessageDescriptor) {
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+	stream, err := g.conn.NewStream(ctx, &grpc.StreamDesc{ServerStreams: true}, fullMethod)
+	if err == nil {
+		err = stream.SendMsg(req)
+	}
+	if err == nil {
+		err = stream.CloseSend()
+	}
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+	if export, ok := req.(*pb.ExportComplaintsRequest); ok {
+		writeExport(w, stream, export.GetFormat())
+		return
+	}
+
+	// The response is committed before the first message, which may only come
+	// much later; errors then end the stream as an {"error": ...} line.
+	w.Header().Set("Content-Type", "application/x-ndjson")
+	w.WriteHeader(http.StatusOK)
+	flush(w)
+	for {
+		msg := newMessage(output)
+		err := stream.RecvMsg(msg)
+		if errors.Is(err, io.EOF) || ctx.Err() != nil {
+			return
+		}
+		if err != nil {
+			st, _ := marshalOptions.Marshal(status.Convert(err).Proto())
+			w.Write([]byte(`{"error":` + string(st) + "}\n"))
+			return
+		}
+		line, err := marshalOptions.Marshal(msg)
+		if err != nil {
+			return
+		}
+		w.Write(append(line, '\n'))
+		flush(w)
+	}
+}
+
+// writeExport writes the chunks of an export. Until the first chunk arrives,
+// errors still get their own HTTP status.
+func writeExport(w http.ResponseWriter, stream grpc.ClientStream, format pb.ExportFormat) {
+	contentType, name := "text/csv; charset=utf-8", "complaints.csv"
+	if format == pb.ExportFormat_NDJSON {
+		contentType, name = "application/x-ndjson", "complaints.ndjson"
+	}
+	started := false
+	for {
+		chunk := &pb.ExportChunk{}
+		err := stream.RecvMsg(chunk)
+		if err != nil && !errors.Is(err, io.EOF) && !started {
+			writeError(w, err)
+			return
+		}
+		if !started {
+			w.Header().Set("Content-Type", contentType)
+			w.Header().Set("Content-Disposition", `attachment; filename="`+name+`"`)
+			started = true
+		}
+		if err != nil {
+			// Past the first chunk, a failure can only cut the file short.
+			return
+		}
+		if _, err := w.Write(chunk.GetData()); err != nil {
+			return
+		}
+	}
+}
+
+// newRequest builds the request message of a route from the HTTP request.
+func newRequest(r *http.Request, route Route, input protoreflect.MessageDescriptor) (proto.Message, error) {
+	req := newMessage(input)
+	if route.Body {
+		body, err := io.ReadAll(http.MaxBytesReader(nil, r.Body, maxBodySize))
+		if err != nil {
+			return nil, status.Errorf(codes.InvalidArgument, Common.ErrInvalidJSON, err)
+		}
+		if len(bytes.TrimSpace(body)) > 0 {
+			if err := protojson.Unmarshal(body, req); err != nil {
+				return nil, status.Errorf(codes.InvalidArgument, Common.ErrInvalidJSON, err)
+			}
+		}
+	} else {
+		secret := input.Fields().ByName("secret_code")
+		for key, values := range r.URL.Query() {
+			// URLs end up in access logs and browser histories, so secret codes
+			// only come in the header or a JSON body.
+			if secret != nil && (key == string(secret.Name()) || key == secret.JSONName()) {
+				return nil, status.Errorf(codes.InvalidArgument, Common.ErrSecretCodeInURL, Common.SecretCodeHeader)
+			}
+			if err := setField(req.ProtoReflect(), key, values); err != nil {
+				return nil, status.Errorf(codes.InvalidArgument, Common.ErrInvalidParameter, key, err)
+			}
+		}
+	}
+	for _, name := range PathParams(route.Path) {
+		if err := setField(req.ProtoReflect(), name, []string{r.PathValue(name)}); err != nil {
+			return nil, status.Errorf(codes.InvalidArgument, Common.ErrInvalidParameter, name, err)
+		}
+	}
+	if code := r.Header.Get(Common.SecretCodeHeader); code != "" {
+		msg := req.ProtoReflect()
+		if fd := input.Fields().ByName("secret_code"); fd != nil && !msg.Has(fd) {
+			msg.Set(fd, protoreflect.ValueOfString(code))
+		}
+	}
+	return req, nil
+}
+
+// PathParams returns the names of the parameters of a route path, in order.
+func PathParams(path string) []string {
+	var names []string
+	for _, segment := range strings.Split(path, "/") {
+		if name, ok := strings.CutPrefix(segment, "{"); ok {
+			names = append(names, strings.TrimSuffix(name, "}"))
+		}
+	}
+	return names
+}
+
+// outgoingContext carries the HTTP request's credentials, client address and
+// request ID to the gRPC server. Requests without a valid X-Request-Id header
+// are given one, which is sent back like the client's.
+func outgoingContext(w http.ResponseWriter, r *http.Request) context.Context {
+	md := metadata.MD{}
+	id := r.Header.Get(Common.RequestIDMetadata)
+	if !Logging.ValidRequestID(id) {
+		id = Logging.NewRequestID()
+	}
+	md.Set(Common.RequestIDMetadata, id)
+	w.Header().Set(Common.RequestIDMetadata, id)
+	if auth := r.Header.Get("Authorization"); auth != "" {
+		md.Set(Common.AuthorizationMetadata, auth)
+	}
+	host, _, err := net.SplitHostPort(r.RemoteAddr)
+	if err != nil {
+		host = r.RemoteAddr
+	}
+	md.Set(Common.ClientAddrMetadata, host)
+	return metadata.NewOutgoingContext(r.Context(), md)
+}
+
+func newMessage(desc protoreflect.MessageDescriptor) proto.Message {
+	mt, err := protoregistry.GlobalTypes.FindMessageByName(desc.FullName())
+	if err != nil {
+		panic(err)
+	}
+	return mt.New().Interface()
+}
+
+// writeError writes err as a JSON google.rpc.Status. Rate limited calls also
+// get a Retry-After header.
+func writeError(w http.ResponseWriter, err error) {
+	st := status.Convert(err)
+	for _, detail := range st.Details() {
+		if retry, ok := detail.(*errdetails.RetryInfo); ok {
+			w.Header().Set("Retry-After", strconv.Itoa(int(retry.GetRetryDelay().AsDuration().Seconds())))
+		}
+	}
+	body, merr := marshalOptions.Marshal(st.Proto())
+	if merr != nil {
+		body = []byte(fmt.Sprintf(`{"code":%d,"message":%q}`, st.Code(), st.Message()))
+	}
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(HTTPStatus(st.Code()))
+	w.Write(body)
+}
+
+// httpStatuses maps gRPC codes to HTTP statuses.
+var httpStatuses = map[codes.Code]int{
+	codes.OK:                 http.StatusOK,
+	codes.Canceled:           499,
+	codes.Unknown:            http.StatusInternalServerError,
+	codes.InvalidArgument:    http.StatusBadRequest,
+	codes.DeadlineExceeded:   http.StatusGatewayTimeout,
+	codes.NotFound:           http.StatusNotFound,
+	codes.AlreadyExists:      http.StatusConflict,
+	codes.PermissionDenied:   http.StatusForbidden,
+	codes.ResourceExhausted:  http.StatusTooManyRequests,
+	codes.FailedPrecondition: http.StatusBadRequest,
+	codes.Aborted:            http.StatusConflict,
+	codes.OutOfRange:         http.StatusBadRequest,
+	codes.Unimplemented:      http.StatusNotImplemented,
+	codes.Internal:           http.StatusInternalServerError,
+	codes.Unavailable:        http.StatusServiceUnavailable,
+	codes.DataLoss:           http.StatusInternalServerError,
+	codes.Unauthenticated:    http.StatusUnauthorized,
+}
+
+// HTTPStatus returns the HTTP status of responses failing with a gRPC code.
+func HTTPStatus(code codes.Code) int {
+	if s, ok := httpStatuses[code]; ok {
+		return s
+	}
+	return http.StatusInternalServerError
+}
+
+func flush(w http.ResponseWriter) {
+	if f, ok := w.(http.Flusher); ok {
+		f.Flush()
+	}
+}
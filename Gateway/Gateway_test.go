@@ -0,0 +1,254 @@
+// Gateway/Gateway_test.go
+package Gateway
+
+import (
+	"bufio"
+	"complaint-portal/Auth"
+	pb "complaint-portal/Generated/ComplaintService"
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/encoding/protojson"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/known/durationpb"
+)
+
+// fakeService records the requests it gets, with the caller's address and
+// authorization, and answers a few RPCs.
+type fakeService struct {
+	pb.UnimplementedComplaintServiceServer
+	requests chan proto.Message
+	addrs    chan string
+	auths    chan string
+}
+
+func (f *fakeService) record(ctx context.Context, req proto.Message) {
+	md, _ := metadata.FromIncomingContext(ctx)
+	f.requests <- req
+	f.addrs <- Auth.ClientAddr(ctx)
+	f.auths <- strings.Join(md.Get("authorization"), ",")
+}
+
+func (f *fakeService) SubmitComplaint(ctx context.Context, req *pb.SubmitComplaintRequest) (*pb.Complaint, error) {
+	f.record(ctx, req)
+	return &pb.Complaint{Id: "c1", Title: req.GetTitle()}, nil
+}
+
+func (f *fakeService) GetUserComplaints(ctx context.Context, req *pb.GetUserComplaintsRequest) (*pb.GetUserComplaintsResponse, error) {
+	f.record(ctx, req)
+	return &pb.GetUserComplaintsResponse{}, nil
+}
+
+func (f *fakeService) TransitionComplaint(ctx context.Context, req *pb.TransitionComplaintRequest) (*pb.Complaint, error) {
+	f.record(ctx, req)
+	return &pb.Complaint{Id: req.GetComplaintId(), Status: req.GetStatus()}, nil
+}
+
+func (f *fakeService) ViewComplaint(ctx context.Context, req *pb.ViewComplaintRequest) (*pb.Complaint, error) {
+	return nil, status.Errorf(codes.NotFound, "no complaint %s", req.GetComplaintId())
+}
+
+func (f *fakeService) Login(ctx context.Context, req *pb.LoginRequest) (*pb.User, error) {
+	st, _ := status.New(codes.ResourceExhausted, "slow down").WithDetails(&errdetails.RetryInfo{RetryDelay: durationpb.New(30 * time.Second)})
+	return nil, st.Err()
+}
+
+func (f *fakeService) ExportComplaints(req *pb.ExportComplaintsRequest, stream pb.ComplaintService_ExportComplaintsServer) error {
+	if len(req.GetColumns()) == 0 {
+		return status.Errorf(codes.InvalidArgument, "no columns")
+	}
+	for _, part := range []string{"id,title\n", "c1,Router\n"} {
+		stream.Send(&pb.ExportChunk{Data: []byte(part)})
+	}
+	return nil
+}
+
+func (f *fakeService) WatchComplaints(req *pb.WatchComplaintsRequest, stream pb.ComplaintService_WatchComplaintsServer) error {
+	stream.Send(&pb.ComplaintEvent{Type: pb.ComplaintEventType_COMPLAINT_CREATED, ResumeToken: "t1"})
+	stream.Send(&pb.ComplaintEvent{Type: pb.ComplaintEventType_COMPLAINT_RESOLVED, ResumeToken: "t2"})
+	return status.Errorf(codes.Unavailable, "server stopping")
+}
+
+// newTestGateway serves a fakeService through the gateway, as main does.
+func newTestGateway(t *testing.T) (*fakeService, *httptest.Server) {
+	service := &fakeService{requests: make(chan proto.Message, 10), addrs: make(chan string, 10), auths: make(chan string, 10)}
+	s := grpc.NewServer()
+	pb.RegisterComplaintServiceServer(s, service)
+	lis := NewListener()
+	go s.Serve(lis)
+	t.Cleanup(s.Stop)
+	conn, err := grpc.NewClient("passthrough:///gateway", grpc.WithContextDialer(lis.DialContext), grpc.WithTransportCredentials(insecure.NewCredentials()))
+	if err != nil {
+		t.Fatalf("Failed to connect to the gateway listener: %v", err)
+	}
+	t.Cleanup(func() { conn.Close() })
+	server := httptest.NewServer(New(conn))
+	t.Cleanup(server.Close)
+	return service, server
+}
+
+func do(t *testing.T, method, url, body string, header http.Header) (*http.Response, string) {
+	t.Helper()
+	req, _ := http.NewRequest(method, url, strings.NewReader(body))
+	for k, v := range header {
+		req.Header[k] = v
+	}
+	res, err := http.DefaultClient.Do(req)
+	if err != nil {
+		t.Fatalf("Failed to call %s %s: %v", method, url, err)
+	}
+	defer res.Body.Close()
+	b, _ := io.ReadAll(res.Body)
+	return res, string(b)
+}
+
+func TestRoutesCoverService(t *testing.T) {
+	routed := map[string]bool{}
+	for _, route := range Routes {
+		if routed[route.RPC] {
+			t.Errorf("RPC %s has more than one route", route.RPC)
+		}
+		routed[route.RPC] = true
+	}
+	methods := Service.Methods()
+	for i := 0; i < methods.Len(); i++ {
+		if name := string(methods.Get(i).Name()); !routed[name] {
+			t.Errorf("RPC %s has no route in Routes", name)
+		}
+	}
+}
+
+func TestGateway(t *testing.T) {
+	service, server := newTestGateway(t)
+
+	// Test case 1: A JSON body becomes the request, with the secret code header and the client's address
+	res, body := do(t, "POST", server.URL+"/v1/complaints", `{"title":"Router","severity":3}`, http.Header{"X-Secret-Code": {"code"}})
+	if res.StatusCode != http.StatusOK || res.Header.Get("Content-Type") != "application/json" {
+		t.Fatalf("Expected a JSON 200, but got %d %s", res.StatusCode, body)
+	}
+	req := (<-service.requests).(*pb.SubmitComplaintRequest)
+	if req.GetTitle() != "Router" || req.GetSeverity() != 3 || req.GetSecretCode() != "code" {
+		t.Errorf("Expected the body and secret code in the request, but got %v", req)
+	}
+	if addr := <-service.addrs; addr != "127.0.0.1" {
+		t.Errorf("Expected the HTTP client's address, but got %q", addr)
+	}
+	<-service.auths
+	var complaint pb.Complaint
+	if err := protojson.Unmarshal([]byte(body), &complaint); err != nil || complaint.GetId() != "c1" || !strings.Contains(body, `"resolved":false`) {
+		t.Errorf("Expected the complaint with its zero fields, but got %s (err: %v)", body, err)
+	}
+
+	// Test case 2: Query parameters fill nested, enum, repeated, optional and timestamp fields
+	query := "?filter.min_severity=2&filter.statuses=OPEN&filter.statuses=4&filter.resolved=false&filter.createdSince=2024-03-01T00:00:00Z&sort=OLDEST_FIRST&page_size=10"
+	res, body = do(t, "GET", server.URL+"/v1/complaints"+query, "", http.Header{"Authorization": {"Bearer token"}})
+	if res.StatusCode != http.StatusOK {
+		t.Fatalf("Expected 200, but got %d %s", res.StatusCode, body)
+	}
+	list := (<-service.requests).(*pb.GetUserComplaintsRequest)
+	filter := list.GetFilter()
+	if filter.GetMinSeverity() != 2 || len(filter.GetStatuses()) != 2 || filter.GetStatuses()[1] != pb.ComplaintStatus(4) || filter.Resolved == nil || filter.GetResolved() ||
+		filter.GetCreatedSince().AsTime() != time.Date(2024, 3, 1, 0, 0, 0, 0, time.UTC) || list.GetSort() != pb.ComplaintSort_OLDEST_FIRST || list.GetPageSize() != 10 {
+		t.Errorf("Expected every parameter in the request, but got %v", list)
+	}
+	<-service.addrs
+	if auth := <-service.auths; auth != "Bearer token" {
+		t.Errorf("Expected the authorization header as metadata, but got %q", auth)
+	}
+
+	// Test case 3: Path parameters take precedence over the body
+	do(t, "POST", server.URL+"/v1/complaints/c9/transitions", `{"complaint_id":"other","status":"RESOLVED"}`, nil)
+	transition := (<-service.requests).(*pb.TransitionComplaintRequest)
+	if transition.GetComplaintId() != "c9" || transition.GetStatus() != pb.ComplaintStatus_RESOLVED {
+		t.Errorf("Expected complaint c9 to be resolved, but got %v", transition)
+	}
+	<-service.addrs
+	<-service.auths
+
+	// Test case 4: Bad bodies and parameters are refused before reaching the server
+	for _, c := range []struct{ method, path, body string }{
+		{"POST", "/v1/complaints", `{"title":`},
+		{"POST", "/v1/complaints", `{"unknown":1}`},
+		{"GET", "/v1/complaints?page_size=many", ""},
+		{"GET", "/v1/complaints?sort=SIDEWAYS", ""},
+		{"GET", "/v1/complaints?filter.unknown=1", ""},
+		{"GET", "/v1/complaints?page_size=1&page_size=2", ""},
+		{"GET", "/v1/complaints?secret_code=code", ""},
+		{"GET", "/v1/complaints/c1?secretCode=code", ""},
+	} {
+		res, body := do(t, c.method, server.URL+c.path, c.body, nil)
+		if res.StatusCode != http.StatusBadRequest || !strings.Contains(body, `"code":3`) {
+			t.Errorf("Expected a 400 for %s %s, but got %d %s", c.method, c.path, res.StatusCode, body)
+		}
+	}
+
+	// Test case 5: gRPC errors are mapped to HTTP statuses, with Retry-After when rate limited
+	res, body = do(t, "GET", server.URL+"/v1/complaints/c2", "", nil)
+	if res.StatusCode != http.StatusNotFound || !strings.Contains(body, "no complaint c2") {
+		t.Errorf("Expected a 404, but got %d %s", res.StatusCode, body)
+	}
+	res, _ = do(t, "POST", server.URL+"/v1/login", `{}`, nil)
+	if res.StatusCode != http.StatusTooManyRequests || res.Header.Get("Retry-After") != "30" {
+		t.Errorf("Expected a 429 with Retry-After: 30, but got %d %v", res.StatusCode, res.Header)
+	}
+	res, _ = do(t, "POST", server.URL+"/v1/webhooks", `{}`, nil)
+	if res.StatusCode != http.StatusNotImplemented {
+		t.Errorf("Expected a 501 for an unimplemented RPC, but got %d", res.StatusCode)
+	}
+
+	// Test case 6: The client's request ID is sent back, and requests without a valid one are given one
+	res, _ = do(t, "GET", server.URL+"/v1/complaints/c2", "", http.Header{"X-Request-Id": {"req-42"}})
+	if id := res.Header.Get("X-Request-Id"); id != "req-42" {
+		t.Errorf("Expected the client's request ID, but got %q", id)
+	}
+	res, _ = do(t, "GET", server.URL+"/v1/complaints/c2", "", http.Header{"X-Request-Id": {strings.Repeat("a", 200)}})
+	if id := res.Header.Get("X-Request-Id"); len(id) != 32 {
+		t.Errorf("Expected a new request ID, but got %q", id)
+	}
+}
+
+func TestGatewayStreams(t *testing.T) {
+	_, server := newTestGateway(t)
+
+	// Test case 1: Exports are downloaded as the file their chunks form
+	res, body := do(t, "GET", server.URL+"/v1/complaints/export?columns=id&columns=title", "", nil)
+	if res.StatusCode != http.StatusOK || body != "id,title\nc1,Router\n" || !strings.HasPrefix(res.Header.Get("Content-Type"), "text/csv") {
+		t.Errorf("Expected the CSV file, but got %d %v %q", res.StatusCode, res.Header, body)
+	}
+
+	// Test case 2: An export failing before its first chunk gets an error status
+	res, _ = do(t, "GET", server.URL+"/v1/complaints/export", "", nil)
+	if res.StatusCode != http.StatusBadRequest {
+		t.Errorf("Expected a 400, but got %d", res.StatusCode)
+	}
+
+	// Test case 3: Other streams are newline-delimited JSON, ending with the error that stopped them
+	res, body = do(t, "GET", server.URL+"/v1/complaints/watch", "", nil)
+	if res.Header.Get("Content-Type") != "application/x-ndjson" {
+		t.Errorf("Expected NDJSON, but got %v", res.Header)
+	}
+	var lines []map[string]any
+	scanner := bufio.NewScanner(strings.NewReader(body))
+	for scanner.Scan() {
+		var line map[string]any
+		if err := json.Unmarshal(scanner.Bytes(), &line); err != nil {
+			t.Fatalf("Expected a JSON line, but got %q", scanner.Text())
+		}
+		lines = append(lines, line)
+	}
+	if len(lines) != 3 || lines[0]["resumeToken"] != "t1" || lines[1]["type"] != "COMPLAINT_RESOLVED" || lines[2]["error"] == nil {
+		t.Errorf("Expected two events then an error, but got %v", lines)
+	}
+}
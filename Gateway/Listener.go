@@ -0,0 +1,73 @@
+// Gateway/Listener.go
+package Gateway
+
+import (
+	"complaint-portal/Common"
+	"context"
+	"errors"
+	"net"
+	"sync"
+)
+
+// Listener is an in-process net.Listener connecting the gateway to the gRPC
+// server without a network round trip. The server sees its connections as
+// coming from Common.GatewayNetwork, which is how Auth.ClientAddr knows to
+// trust the client address the gateway forwards.
+type Listener struct {
+	conns  chan net.Conn
+	closed chan struct{}
+	once   sync.Once
+}
+
+// NewListener returns a Listener to pass to grpc.Server.Serve.
+func NewListener() *Listener {
+	return &Listener{conns: make(chan net.Conn), closed: make(chan struct{})}
+}
+
+// Accept implements net.Listener.
+func (l *Listener) Accept() (net.Conn, error) {
+	select {
+	case conn := <-l.conns:
+		return conn, nil
+	case <-l.closed:
+		return nil, net.ErrClosed
+	}
+}
+
+// Close implements net.Listener.
+func (l *Listener) Close() error {
+	l.once.Do(func() { close(l.closed) })
+	return nil
+}
+
+// Addr implements net.Listener.
+func (l *Listener) Addr() net.Addr {
+	return gatewayAddr{}
+}
+
+// DialContext connects to the server accepting from l. It has the signature
+// grpc.WithContextDialer expects; the address is ignored.
+func (l *Listener) DialContext(ctx context.Context, _ string) (net.Conn, error) {
+	client, server := net.Pipe()
+	select {
+	case l.conns <- gatewayConn{server}:
+		return client, nil
+	case <-l.closed:
+		return nil, errors.New("gateway listener closed")
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	}
+}
+
+// gatewayConn is the server end of a connection from the gateway.
+type gatewayConn struct {
+	net.Conn
+}
+
+func (gatewayConn) LocalAddr() net.Addr  { return gatewayAddr{} }
+func (gatewayConn) RemoteAddr() net.Addr { return gatewayAddr{} }
+
+type gatewayAddr struct{}
+
+func (gatewayAddr) Network() string { return Common.GatewayNetwork }
+func (gatewayAddr) String() string  { return Common.GatewayNetwork }
@@ -0,0 +1,274 @@
+// Gateway/OpenAPI.go
+package Gateway
+
+import (
+	"complaint-portal/Auth"
+	"encoding/json"
+	"strings"
+	"unicode"
+
+	"google.golang.org/protobuf/reflect/protoreflect"
+)
+
+// OpenAPIPath is where the gateway serves its OpenAPI document.
+const OpenAPIPath = "/openapi.json"
+
+// Security schemes of the OpenAPI document.
+const (
+	bearerScheme     = "sessionToken"
+	secretCodeScheme = "secretCode"
+)
+
+// object is a JSON object of the OpenAPI document. encoding/json writes its
+// keys sorted, so the same descriptors always give the same document.
+type object = map[string]any
+
+// OpenAPI returns the OpenAPI 3 document of the gateway, generated from Routes
+// and the descriptors of the proto messages. A committed copy lives in
+// Generated/openapi.json; see the README to regenerate it.
+func OpenAPI() ([]byte, error) {
+	schemas := object{"Status": statusSchema, "Any": anySchema}
+	paths := object{}
+	for _, route := range Routes {
+		method := Service.Methods().ByName(protoreflect.Name(route.RPC))
+		item, _ := paths[route.Path].(object)
+		if item == nil {
+			item = object{}
+			paths[route.Path] = item
+		}
+		item[strings.ToLower(route.Method)] = operation(route, method, schemas)
+	}
+	doc := object{
+		"openapi": "3.0.3",
+		"info": object{
+			"title":   "Complaint Portal API",
+			"version": "v1",
+			"description": "HTTP/JSON API of the complaint portal, relayed to its gRPC service " + string(Service.FullName()) + ". " +
+				"Messages use the Protocol Buffers JSON mapping.",
+		},
+		"paths": paths,
+		"components": object{
+			"schemas": schemas,
+			"securitySchemes": object{
+				bearerScheme: object{
+					"type": "http", "scheme": "bearer",
+					"description": "The session token returned by POST /v1/login.",
+				},
+				secretCodeScheme: object{
+					"type": "apiKey", "in": "header", "name": "X-Secret-Code",
+					"description": "The user's secret code, which may also be sent as the secret_code field of a JSON body, but never in the URL.",
+				},
+			},
+			"responses": object{
+				"Error": object{
+					"description": "The call failed; the HTTP status matches the gRPC code.",
+					"content":     object{"application/json": object{"schema": ref("Status")}},
+				},
+			},
+		},
+	}
+	return json.MarshalIndent(doc, "", "  ")
+}
+
+// accessNotes describe who may call a method, by access level.
+var accessNotes = map[Auth.Access]string{
+	Auth.Public:        "No credentials are needed.",
+	Auth.Authenticated: "Any signed-in user may call it.",
+	Auth.Staff:         "Only agents and admins may call it.",
+	Auth.Admin:         "Only admins may call it.",
+}
+
+// operation describes the route of an RPC, adding the schemas it uses.
+func operation(route Route, method protoreflect.MethodDescriptor, schemas object) object {
+	access := Auth.MethodAccess["/"+string(Service.FullName())+"/"+route.RPC]
+	op := object{
+		"operationId": route.RPC,
+		"tags":        []string{strings.Split(route.Path, "/")[2]},
+		"summary":     summary(route.RPC),
+		"description": accessNotes[access],
+		"responses": object{
+			"200":     response(method, schemas),
+			"default": object{"$ref": "#/components/responses/Error"},
+		},
+	}
+	if access == Auth.Public {
+		op["security"] = []object{}
+	} else {
+		op["security"] = []object{{bearerScheme: []string{}}, {secretCodeScheme: []string{}}}
+	}
+
+	input := method.Input()
+	var params []object
+	for _, name := range PathParams(route.Path) {
+		params = append(params, object{
+			"name": name, "in": "path", "required": true,
+			"schema": fieldSchema(input.Fields().ByName(protoreflect.Name(name)), schemas),
+		})
+	}
+	if route.Body {
+		op["requestBody"] = object{
+			"required": true,
+			"content":  object{"application/json": object{"schema": messageSchema(input, schemas)}},
+		}
+	} else {
+		params = append(params, queryParams(input, "", PathParams(route.Path), schemas)...)
+	}
+	if len(params) > 0 {
+		op["parameters"] = params
+	}
+	return op
+}
+
+// response describes the successful response of a method.
+func response(method protoreflect.MethodDescriptor, schemas object) object {
+	output := messageSchema(method.Output(), schemas)
+	switch {
+	case !method.IsStreamingServer():
+		return object{"description": "OK", "content": object{"application/json": object{"schema": output}}}
+	case method.Output().Name() == "ExportChunk":
+		file := object{"schema": object{"type": "string", "format": "binary"}}
+		return object{
+			"description": "The exported file, in the requested format.",
+			"content":     object{"text/csv": file, "application/x-ndjson": file},
+		}
+	default:
+		return object{
+			"description": "A stream of messages, one JSON object per line. An error stopping the stream is sent as a last {\"error\": Status} line.",
+			"content":     object{"application/x-ndjson": object{"schema": output}},
+		}
+	}
+}
+
+// queryParams describes the fields of msg as query parameters, nested ones
+// named with dots. The secret code is left to the security schemes.
+func queryParams(msg protoreflect.MessageDescriptor, prefix string, skip []string, schemas object) []object {
+	var params []object
+	fields := msg.Fields()
+	for i := 0; i < fields.Len(); i++ {
+		fd := fields.Get(i)
+		name := prefix + string(fd.Name())
+		if name == "secret_code" || contains(skip, name) {
+			continue
+		}
+		if fd.Message() != nil && !fd.IsList() && !isScalarMessage(fd.Message()) {
+			params = append(params, queryParams(fd.Message(), name+".", nil, schemas)...)
+			continue
+		}
+		params = append(params, object{"name": name, "in": "query", "schema": fieldSchema(fd, schemas)})
+	}
+	return params
+}
+
+// messageSchema returns a reference to the schema of a message, adding it and
+// those of its fields to schemas. Well-known types are described inline.
+func messageSchema(md protoreflect.MessageDescriptor, schemas object) object {
+	switch md.FullName() {
+	case "google.protobuf.Timestamp":
+		return object{"type": "string", "format": "date-time"}
+	case "google.protobuf.Duration":
+		return object{"type": "string", "pattern": `^-?[0-9]+(\.[0-9]+)?s$`, "example": "3600s"}
+	}
+	name := string(md.Name())
+	if _, ok := schemas[name]; ok {
+		return ref(name)
+	}
+	properties := object{}
+	schema := object{"type": "object", "properties": properties}
+	schemas[name] = schema
+	fields := md.Fields()
+	for i := 0; i < fields.Len(); i++ {
+		properties[fields.Get(i).JSONName()] = fieldSchema(fields.Get(i), schemas)
+	}
+	return ref(name)
+}
+
+// fieldSchema describes the JSON value of a field.
+func fieldSchema(fd protoreflect.FieldDescriptor, schemas object) object {
+	if fd.IsList() {
+		return object{"type": "array", "items": valueSchema(fd, schemas)}
+	}
+	return valueSchema(fd, schemas)
+}
+
+func valueSchema(fd protoreflect.FieldDescriptor, schemas object) object {
+	switch fd.Kind() {
+	case protoreflect.BoolKind:
+		return object{"type": "boolean"}
+	case protoreflect.Int32Kind, protoreflect.Sint32Kind, protoreflect.Sfixed32Kind:
+		return object{"type": "integer", "format": "int32"}
+	case protoreflect.Uint32Kind, protoreflect.Fixed32Kind:
+		return object{"type": "integer", "format": "int64", "minimum": 0}
+	case protoreflect.Int64Kind, protoreflect.Sint64Kind, protoreflect.Sfixed64Kind, protoreflect.Uint64Kind, protoreflect.Fixed64Kind:
+		// protojson writes 64-bit integers as strings.
+		return object{"type": "string", "format": "int64"}
+	case protoreflect.FloatKind:
+		return object{"type": "number", "format": "float"}
+	case protoreflect.DoubleKind:
+		return object{"type": "number", "format": "double"}
+	case protoreflect.BytesKind:
+		return object{"type": "string", "format": "byte"}
+	case protoreflect.EnumKind:
+		return enumSchema(fd.Enum(), schemas)
+	case protoreflect.MessageKind, protoreflect.GroupKind:
+		return messageSchema(fd.Message(), schemas)
+	default:
+		return object{"type": "string"}
+	}
+}
+
+// enumSchema returns a reference to the schema of an enum, adding it to schemas.
+func enumSchema(ed protoreflect.EnumDescriptor, schemas object) object {
+	name := string(ed.Name())
+	if _, ok := schemas[name]; !ok {
+		var names []string
+		for i := 0; i < ed.Values().Len(); i++ {
+			names = append(names, string(ed.Values().Get(i).Name()))
+		}
+		schemas[name] = object{"type": "string", "enum": names}
+	}
+	return ref(name)
+}
+
+// summary spells out an RPC name, e.g. "Get user complaints" for GetUserComplaints.
+func summary(rpc string) string {
+	var b strings.Builder
+	for i, r := range rpc {
+		if i > 0 && unicode.IsUpper(r) {
+			b.WriteByte(' ')
+			r = unicode.ToLower(r)
+		}
+		b.WriteRune(r)
+	}
+	return b.String()
+}
+
+func ref(name string) object {
+	return object{"$ref": "#/components/schemas/" + name}
+}
+
+func contains(names []string, name string) bool {
+	for _, n := range names {
+		if n == name {
+			return true
+		}
+	}
+	return false
+}
+
+// statusSchema is the JSON form of google.rpc.Status, the body of every error.
+var statusSchema = object{
+	"type": "object",
+	"properties": object{
+		"code":    object{"type": "integer", "format": "int32", "description": "The gRPC status code."},
+		"message": object{"type": "string"},
+		"details": object{"type": "array", "items": ref("Any")},
+	},
+}
+
+// anySchema is the JSON form of google.protobuf.Any, such as the RetryInfo of
+// rate limited calls.
+var anySchema = object{
+	"type":                 "object",
+	"properties":           object{"@type": object{"type": "string"}},
+	"additionalProperties": true,
+}
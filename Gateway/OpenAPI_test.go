@@ -0,0 +1,94 @@
+// Gateway/OpenAPI_test.go
+package Gateway
+
+import (
+	"bytes"
+	"encoding/json"
+	"flag"
+	"net/http"
+	"os"
+	"strings"
+	"testing"
+)
+
+// specFile is the committed copy of the OpenAPI document.
+const specFile = "../Generated/openapi.json"
+
+var update = flag.Bool("update", false, "rewrite "+specFile+" from the proto definitions")
+
+// TestOpenAPIDrift fails when the committed document no longer matches the
+// proto definitions. Regenerate it with: go test ./Gateway -run TestOpenAPIDrift -update
+func TestOpenAPIDrift(t *testing.T) {
+	spec, err := OpenAPI()
+	if err != nil {
+		t.Fatalf("Failed to generate the OpenAPI document: %v", err)
+	}
+	spec = append(spec, '\n')
+	if *update {
+		if err := os.WriteFile(specFile, spec, 0o644); err != nil {
+			t.Fatalf("Failed to write %s: %v", specFile, err)
+		}
+	}
+	committed, err := os.ReadFile(specFile)
+	if err != nil {
+		t.Fatalf("Failed to read %s: %v", specFile, err)
+	}
+	if !bytes.Equal(committed, spec) {
+		t.Errorf("%s is out of date with the proto definitions; regenerate it with: go test ./Gateway -run TestOpenAPIDrift -update", specFile)
+	}
+}
+
+func TestOpenAPI(t *testing.T) {
+	_, server := newTestGateway(t)
+
+	// Test case 1: The gateway serves the document
+	res, body := do(t, "GET", server.URL+OpenAPIPath, "", nil)
+	var doc struct {
+		OpenAPI    string                               `json:"openapi"`
+		Paths      map[string]map[string]map[string]any `json:"paths"`
+		Components struct {
+			Schemas map[string]map[string]any `json:"schemas"`
+		} `json:"components"`
+	}
+	if res.StatusCode != http.StatusOK || json.Unmarshal([]byte(body), &doc) != nil || !strings.HasPrefix(doc.OpenAPI, "3.") {
+		t.Fatalf("Expected an OpenAPI 3 document, but got %d %.100s", res.StatusCode, body)
+	}
+
+	// Test case 2: Every route is described, with its credentials
+	for _, route := range Routes {
+		op := doc.Paths[route.Path][strings.ToLower(route.Method)]
+		if op == nil || op["operationId"] != route.RPC {
+			t.Errorf("Expected an operation for %s %s, but got %v", route.Method, route.Path, op)
+			continue
+		}
+		security, _ := op["security"].([]any)
+		if public := route.RPC == "Register" || route.RPC == "Login" || route.RPC == "RedeemRecoveryCode"; public != (len(security) == 0) {
+			t.Errorf("Expected %s to need credentials unless public, but got %v", route.RPC, security)
+		}
+	}
+	list := doc.Paths["/v1/complaints"]["get"]
+	params, _ := json.Marshal(list["parameters"])
+	if !strings.Contains(string(params), `"filter.min_severity"`) || strings.Contains(string(params), "secret_code") {
+		t.Errorf("Expected nested query parameters without the secret code, but got %s", params)
+	}
+
+	// Test case 3: Every reference resolves, and schemas use the JSON names
+	for _, r := range refs(body) {
+		if _, ok := doc.Components.Schemas[strings.TrimPrefix(r, "#/components/schemas/")]; !ok && r != "#/components/responses/Error" {
+			t.Errorf("Expected %s to resolve", r)
+		}
+	}
+	complaint, _ := json.Marshal(doc.Components.Schemas["Complaint"])
+	if !strings.Contains(string(complaint), `"statusHistory"`) || !strings.Contains(string(complaint), `"date-time"`) {
+		t.Errorf("Expected the Complaint schema with camelCase fields and timestamps, but got %s", complaint)
+	}
+}
+
+// refs returns every "$ref" of a JSON document.
+func refs(doc string) []string {
+	var out []string
+	for _, part := range strings.Split(doc, `"$ref": "`)[1:] {
+		out = append(out, part[:strings.Index(part, `"`)])
+	}
+	return out
+}
@@ -0,0 +1,45 @@
+// Gateway/Routes.go
+package Gateway
+
+// Route maps an HTTP method and path to a ComplaintService RPC. Path
+// parameters, in braces, name the request field they fill. The other fields
+// come from the JSON body, or from the query string for routes without one.
+type Route struct {
+	Method string
+	Path   string
+	RPC    string
+	// Body is set for routes reading the request from a JSON body.
+	Body bool
+}
+
+// Routes lists the route of every RPC. A test fails until a new RPC is added.
+var Routes = []Route{
+	{"POST", "/v1/users", "Register", true},
+	{"POST", "/v1/login", "Login", true},
+	{"POST", "/v1/logout", "Logout", true},
+	{"POST", "/v1/session/refresh", "RefreshSession", true},
+	{"POST", "/v1/secret-code/rotate", "RotateSecretCode", true},
+	{"POST", "/v1/users/{user_id}/secret-code/reset", "ResetSecretCode", true},
+	{"POST", "/v1/users/{user_id}/secret-code/redeem", "RedeemRecoveryCode", true},
+	{"POST", "/v1/staff", "CreateStaffUser", true},
+
+	{"POST", "/v1/complaints", "SubmitComplaint", true},
+	{"GET", "/v1/complaints", "GetUserComplaints", false},
+	{"GET", "/v1/complaints/{complaint_id}", "ViewComplaint", false},
+	{"POST", "/v1/complaints/{complaint_id}/resolve", "ResolveComplaint", true},
+	{"POST", "/v1/complaints/{complaint_id}/transitions", "TransitionComplaint", true},
+	{"POST", "/v1/complaints/{complaint_id}/comments", "AddComment", true},
+	{"GET", "/v1/complaints/{complaint_id}/comments", "ListComments", false},
+	{"GET", "/v1/complaints/search", "SearchComplaints", false},
+	{"GET", "/v1/complaints/watch", "WatchComplaints", false},
+	{"GET", "/v1/complaints/export", "ExportComplaints", false},
+	{"GET", "/v1/admin/complaints", "GetAdminComplaints", false},
+	{"GET", "/v1/admin/stats", "GetComplaintStats", false},
+	{"GET", "/v1/admin/audit-log", "QueryAuditLog", false},
+
+	{"POST", "/v1/webhooks", "CreateWebhook", true},
+	{"GET", "/v1/webhooks", "ListWebhooks", false},
+	{"DELETE", "/v1/webhooks/{webhook_id}", "DeleteWebhook", false},
+	{"GET", "/v1/webhooks/deliveries", "ListWebhookDeliveries", false},
+	{"POST", "/v1/webhooks/deliveries/{delivery_id}/redeliver", "RedeliverWebhook", true},
+}
@@ -29,6 +29,25 @@ type ComplaintServiceClient interface {
 	GetAdminComplaints(ctx context.Context, in *GetAdminComplaintsRequest, opts ...grpc.CallOption) (*GetAdminComplaintsResponse, error)
 	ViewComplaint(ctx context.Context, in *ViewComplaintRequest, opts ...grpc.CallOption) (*Complaint, error)
 	ResolveComplaint(ctx context.Context, in *ResolveComplaintRequest, opts ...grpc.CallOption) (*ResolveComplaintResponse, error)
+	TransitionComplaint(ctx context.Context, in *TransitionComplaintRequest, opts ...grpc.CallOption) (*Complaint, error)
+	AddComment(ctx context.Context, in *AddCommentRequest, opts ...grpc.CallOption) (*Comment, error)
+	ListComments(ctx context.Context, in *ListCommentsRequest, opts ...grpc.CallOption) (*ListCommentsResponse, error)
+	CreateStaffUser(ctx context.Context, in *CreateStaffUserRequest, opts ...grpc.CallOption) (*User, error)
+	Logout(ctx context.Context, in *LogoutRequest, opts ...grpc.CallOption) (*LogoutResponse, error)
+	RefreshSession(ctx context.Context, in *RefreshSessionRequest, opts ...grpc.CallOption) (*Session, error)
+	RotateSecretCode(ctx context.Context, in *RotateSecretCodeRequest, opts ...grpc.CallOption) (*SecretCodeResponse, error)
+	ResetSecretCode(ctx context.Context, in *ResetSecretCodeRequest, opts ...grpc.CallOption) (*ResetSecretCodeResponse, error)
+	RedeemRecoveryCode(ctx context.Context, in *RedeemRecoveryCodeRequest, opts ...grpc.CallOption) (*SecretCodeResponse, error)
+	QueryAuditLog(ctx context.Context, in *QueryAuditLogRequest, opts ...grpc.CallOption) (*QueryAuditLogResponse, error)
+	SearchComplaints(ctx context.Context, in *SearchComplaintsRequest, opts ...grpc.CallOption) (*SearchComplaintsResponse, error)
+	WatchComplaints(ctx context.Context, in *WatchComplaintsRequest, opts ...grpc.CallOption) (ComplaintService_WatchComplaintsClient, error)
+	GetComplaintStats(ctx context.Context, in *GetComplaintStatsRequest, opts ...grpc.CallOption) (*GetComplaintStatsResponse, error)
+	ExportComplaints(ctx context.Context, in *ExportComplaintsRequest, opts ...grpc.CallOption) (ComplaintService_ExportComplaintsClient, error)
+	CreateWebhook(ctx context.Context, in *CreateWebhookRequest, opts ...grpc.CallOption) (*Webhook, error)
+	ListWebhooks(ctx context.Context, in *ListWebhooksRequest, opts ...grpc.CallOption) (*ListWebhooksResponse, error)
+	DeleteWebhook(ctx context.Context, in *DeleteWebhookRequest, opts ...grpc.CallOption) (*DeleteWebhookResponse, error)
+	ListWebhookDeliveries(ctx context.Context, in *ListWebhookDeliveriesRequest, opts ...grpc.CallOption) (*ListWebhookDeliveriesResponse, error)
+	RedeliverWebhook(ctx context.Context, in *RedeliverWebhookRequest, opts ...grpc.CallOption) (*WebhookDelivery, error)
 }
 
 type complaintServiceClient struct {
@@ -102,6 +121,223 @@ func (c *complaintServiceClient) ResolveComplaint(ctx context.Context, in *Resol
 	return out, nil
 }
 
+func (c *complaintServiceClient) TransitionComplaint(ctx context.Context, in *TransitionComplaintRequest, opts ...grpc.CallOption) (*Complaint, error) {
+	out := new(Complaint)
+	err := c.cc.Invoke(ctx, "/complaint.ComplaintService/TransitionComplaint", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *complaintServiceClient) AddComment(ctx context.Context, in *AddCommentRequest, opts ...grpc.CallOption) (*Comment, error) {
+	out := new(Comment)
+	err := c.cc.Invoke(ctx, "/complaint.ComplaintService/AddComment", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *complaintServiceClient) ListComments(ctx context.Context, in *ListCommentsRequest, opts ...grpc.CallOption) (*ListCommentsResponse, error) {
+	out := new(ListCommentsResponse)
+	err := c.cc.Invoke(ctx, "/complaint.ComplaintService/ListComments", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *complaintServiceClient) CreateStaffUser(ctx context.Context, in *CreateStaffUserRequest, opts ...grpc.CallOption) (*User, error) {
+	out := new(User)
+	err := c.cc.Invoke(ctx, "/complaint.ComplaintService/CreateStaffUser", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *complaintServiceClient) Logout(ctx context.Context, in *LogoutRequest, opts ...grpc.CallOption) (*LogoutResponse, error) {
+	out := new(LogoutResponse)
+	err := c.cc.Invoke(ctx, "/complaint.ComplaintService/Logout", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *complaintServiceClient) RefreshSession(ctx context.Context, in *RefreshSessionRequest, opts ...grpc.CallOption) (*Session, error) {
+	out := new(Session)
+	err := c.cc.Invoke(ctx, "/complaint.ComplaintService/RefreshSession", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *complaintServiceClient) RotateSecretCode(ctx context.Context, in *RotateSecretCodeRequest, opts ...grpc.CallOption) (*SecretCodeResponse, error) {
+	out := new(SecretCodeResponse)
+	err := c.cc.Invoke(ctx, "/complaint.ComplaintService/RotateSecretCode", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *complaintServiceClient) ResetSecretCode(ctx context.Context, in *ResetSecretCodeRequest, opts ...grpc.CallOption) (*ResetSecretCodeResponse, error) {
+	out := new(ResetSecretCodeResponse)
+	err := c.cc.Invoke(ctx, "/complaint.ComplaintService/ResetSecretCode", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *complaintServiceClient) RedeemRecoveryCode(ctx context.Context, in *RedeemRecoveryCodeRequest, opts ...grpc.CallOption) (*SecretCodeResponse, error) {
+	out := new(SecretCodeResponse)
+	err := c.cc.Invoke(ctx, "/complaint.ComplaintService/RedeemRecoveryCode", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *complaintServiceClient) QueryAuditLog(ctx context.Context, in *QueryAuditLogRequest, opts ...grpc.CallOption) (*QueryAuditLogResponse, error) {
+	out := new(QueryAuditLogResponse)
+	err := c.cc.Invoke(ctx, "/complaint.ComplaintService/QueryAuditLog", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *complaintServiceClient) SearchComplaints(ctx context.Context, in *SearchComplaintsRequest, opts ...grpc.CallOption) (*SearchComplaintsResponse, error) {
+	out := new(SearchComplaintsResponse)
+	err := c.cc.Invoke(ctx, "/complaint.ComplaintService/SearchComplaints", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *complaintServiceClient) WatchComplaints(ctx context.Context, in *WatchComplaintsRequest, opts ...grpc.CallOption) (ComplaintService_WatchComplaintsClient, error) {
+	stream, err := c.cc.NewStream(ctx, &ComplaintService_ServiceDesc.Streams[0], "/complaint.ComplaintService/WatchComplaints", opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &complaintServiceWatchComplaintsClient{stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+type ComplaintService_WatchComplaintsClient interface {
+	Recv() (*ComplaintEvent, error)
+	grpc.ClientStream
+}
+
+type complaintServiceWatchComplaintsClient struct {
+	grpc.ClientStream
+}
+
+func (x *complaintServiceWatchComplaintsClient) Recv() (*ComplaintEvent, error) {
+	m := new(ComplaintEvent)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+func (c *complaintServiceClient) GetComplaintStats(ctx context.Context, in *GetComplaintStatsRequest, opts ...grpc.CallOption) (*GetComplaintStatsResponse, error) {
+	out := new(GetComplaintStatsResponse)
+	err := c.cc.Invoke(ctx, "/complaint.ComplaintService/GetComplaintStats", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *complaintServiceClient) ExportComplaints(ctx context.Context, in *ExportComplaintsRequest, opts ...grpc.CallOption) (ComplaintService_ExportComplaintsClient, error) {
+	stream, err := c.cc.NewStream(ctx, &ComplaintService_ServiceDesc.Streams[1], "/complaint.ComplaintService/ExportComplaints", opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &complaintServiceExportComplaintsClient{stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+type ComplaintService_ExportComplaintsClient interface {
+	Recv() (*ExportChunk, error)
+	grpc.ClientStream
+}
+
+type complaintServiceExportComplaintsClient struct {
+	grpc.ClientStream
+}
+
+func (x *complaintServiceExportComplaintsClient) Recv() (*ExportChunk, error) {
+	m := new(ExportChunk)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+func (c *complaintServiceClient) CreateWebhook(ctx context.Context, in *CreateWebhookRequest, opts ...grpc.CallOption) (*Webhook, error) {
+	out := new(Webhook)
+	err := c.cc.Invoke(ctx, "/complaint.ComplaintService/CreateWebhook", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *complaintServiceClient) ListWebhooks(ctx context.Context, in *ListWebhooksRequest, opts ...grpc.CallOption) (*ListWebhooksResponse, error) {
+	out := new(ListWebhooksResponse)
+	err := c.cc.Invoke(ctx, "/complaint.ComplaintService/ListWebhooks", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *complaintServiceClient) DeleteWebhook(ctx context.Context, in *DeleteWebhookRequest, opts ...grpc.CallOption) (*DeleteWebhookResponse, error) {
+	out := new(DeleteWebhookResponse)
+	err := c.cc.Invoke(ctx, "/complaint.ComplaintService/DeleteWebhook", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *complaintServiceClient) ListWebhookDeliveries(ctx context.Context, in *ListWebhookDeliveriesRequest, opts ...grpc.CallOption) (*ListWebhookDeliveriesResponse, error) {
+	out := new(ListWebhookDeliveriesResponse)
+	err := c.cc.Invoke(ctx, "/complaint.ComplaintService/ListWebhookDeliveries", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *complaintServiceClient) RedeliverWebhook(ctx context.Context, in *RedeliverWebhookRequest, opts ...grpc.CallOption) (*WebhookDelivery, error) {
+	out := new(WebhookDelivery)
+	err := c.cc.Invoke(ctx, "/complaint.ComplaintService/RedeliverWebhook", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 // ComplaintServiceServer is the server API for ComplaintService service.
 // All implementations must embed UnimplementedComplaintServiceServer
 // for forward compatibility
@@ -113,6 +349,25 @@ type ComplaintServiceServer interface {
 	GetAdminComplaints(context.Context, *GetAdminComplaintsRequest) (*GetAdminComplaintsResponse, error)
 	ViewComplaint(context.Context, *ViewComplaintRequest) (*Complaint, error)
 	ResolveComplaint(context.Context, *ResolveComplaintRequest) (*ResolveComplaintResponse, error)
+	TransitionComplaint(context.Context, *TransitionComplaintRequest) (*Complaint, error)
+	AddComment(context.Context, *AddCommentRequest) (*Comment, error)
+	ListComments(context.Context, *ListCommentsRequest) (*ListCommentsResponse, error)
+	CreateStaffUser(context.Context, *CreateStaffUserRequest) (*User, error)
+	Logout(context.Context, *LogoutRequest) (*LogoutResponse, error)
+	RefreshSession(context.Context, *RefreshSessionRequest) (*Session, error)
+	RotateSecretCode(context.Context, *RotateSecretCodeRequest) (*SecretCodeResponse, error)
+	ResetSecretCode(context.Context, *ResetSecretCodeRequest) (*ResetSecretCodeResponse, error)
+	RedeemRecoveryCode(context.Context, *RedeemRecoveryCodeRequest) (*SecretCodeResponse, error)
+	QueryAuditLog(context.Context, *QueryAuditLogRequest) (*QueryAuditLogResponse, error)
+	SearchComplaints(context.Context, *SearchComplaintsRequest) (*SearchComplaintsResponse, error)
+	WatchComplaints(*WatchComplaintsRequest, ComplaintService_WatchComplaintsServer) error
+	GetComplaintStats(context.Context, *GetComplaintStatsRequest) (*GetComplaintStatsResponse, error)
+	ExportComplaints(*ExportComplaintsRequest, ComplaintService_ExportComplaintsServer) error
+	CreateWebhook(context.Context, *CreateWebhookRequest) (*Webhook, error)
+	ListWebhooks(context.Context, *ListWebhooksRequest) (*ListWebhooksResponse, error)
+	DeleteWebhook(context.Context, *DeleteWebhookRequest) (*DeleteWebhookResponse, error)
+	ListWebhookDeliveries(context.Context, *ListWebhookDeliveriesRequest) (*ListWebhookDeliveriesResponse, error)
+	RedeliverWebhook(context.Context, *RedeliverWebhookRequest) (*WebhookDelivery, error)
 	mustEmbedUnimplementedComplaintServiceServer()
 }
 
@@ -141,6 +396,63 @@ func (UnimplementedComplaintServiceServer) ViewComplaint(context.Context, *ViewC
 func (UnimplementedComplaintServiceServer) ResolveComplaint(context.Context, *ResolveComplaintRequest) (*ResolveComplaintResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method ResolveComplaint not implemented")
 }
+func (UnimplementedComplaintServiceServer) TransitionComplaint(context.Context, *TransitionComplaintRequest) (*Complaint, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method TransitionComplaint not implemented")
+}
+func (UnimplementedComplaintServiceServer) AddComment(context.Context, *AddCommentRequest) (*Comment, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method AddComment not implemented")
+}
+func (UnimplementedComplaintServiceServer) ListComments(context.Context, *ListCommentsRequest) (*ListCommentsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListComments not implemented")
+}
+func (UnimplementedComplaintServiceServer) CreateStaffUser(context.Context, *CreateStaffUserRequest) (*User, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method CreateStaffUser not implemented")
+}
+func (UnimplementedComplaintServiceServer) Logout(context.Context, *LogoutRequest) (*LogoutResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Logout not implemented")
+}
+func (UnimplementedComplaintServiceServer) RefreshSession(context.Context, *RefreshSessionRequest) (*Session, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method RefreshSession not implemented")
+}
+func (UnimplementedComplaintServiceServer) RotateSecretCode(context.Context, *RotateSecretCodeRequest) (*SecretCodeResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method RotateSecretCode not implemented")
+}
+func (UnimplementedComplaintServiceServer) ResetSecretCode(context.Context, *ResetSecretCodeRequest) (*ResetSecretCodeResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ResetSecretCode not implemented")
+}
+func (UnimplementedComplaintServiceServer) RedeemRecoveryCode(context.Context, *RedeemRecoveryCodeRequest) (*SecretCodeResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method RedeemRecoveryCode not implemented")
+}
+func (UnimplementedComplaintServiceServer) QueryAuditLog(context.Context, *QueryAuditLogRequest) (*QueryAuditLogResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method QueryAuditLog not implemented")
+}
+func (UnimplementedComplaintServiceServer) SearchComplaints(context.Context, *SearchComplaintsRequest) (*SearchComplaintsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method SearchComplaints not implemented")
+}
+func (UnimplementedComplaintServiceServer) WatchComplaints(*WatchComplaintsRequest, ComplaintService_WatchComplaintsServer) error {
+	return status.Errorf(codes.Unimplemented, "method WatchComplaints not implemented")
+}
+func (UnimplementedComplaintServiceServer) GetComplaintStats(context.Context, *GetComplaintStatsRequest) (*GetComplaintStatsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetComplaintStats not implemented")
+}
+func (UnimplementedComplaintServiceServer) ExportComplaints(*ExportComplaintsRequest, ComplaintService_ExportComplaintsServer) error {
+	return status.Errorf(codes.Unimplemented, "method ExportComplaints not implemented")
+}
+func (UnimplementedComplaintServiceServer) CreateWebhook(context.Context, *CreateWebhookRequest) (*Webhook, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method CreateWebhook not implemented")
+}
+func (UnimplementedComplaintServiceServer) ListWebhooks(context.Context, *ListWebhooksRequest) (*ListWebhooksResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListWebhooks not implemented")
+}
+func (UnimplementedComplaintServiceServer) DeleteWebhook(context.Context, *DeleteWebhookRequest) (*DeleteWebhookResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method DeleteWebhook not implemented")
+}
+func (UnimplementedComplaintServiceServer) ListWebhookDeliveries(context.Context, *ListWebhookDeliveriesRequest) (*ListWebhookDeliveriesResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListWebhookDeliveries not implemented")
+}
+func (UnimplementedComplaintServiceServer) RedeliverWebhook(context.Context, *RedeliverWebhookRequest) (*WebhookDelivery, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method RedeliverWebhook not implemented")
+}
 func (UnimplementedComplaintServiceServer) mustEmbedUnimplementedComplaintServiceServer() {}
 
 // UnsafeComplaintServiceServer may be embedded to opt out of forward compatibility for this service.
@@ -280,6 +592,354 @@ func _ComplaintService_ResolveComplaint_Handler(srv interface{}, ctx context.Con
 	return interceptor(ctx, in, info, handler)
 }
 
+func _ComplaintService_TransitionComplaint_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(TransitionComplaintRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ComplaintServiceServer).TransitionComplaint(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/complaint.ComplaintService/TransitionComplaint",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ComplaintServiceServer).TransitionComplaint(ctx, req.(*TransitionComplaintRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ComplaintService_AddComment_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(AddCommentRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ComplaintServiceServer).AddComment(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/complaint.ComplaintService/AddComment",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ComplaintServiceServer).AddComment(ctx, req.(*AddCommentRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ComplaintService_ListComments_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListCommentsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ComplaintServiceServer).ListComments(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/complaint.ComplaintService/ListComments",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ComplaintServiceServer).ListComments(ctx, req.(*ListCommentsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ComplaintService_CreateStaffUser_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CreateStaffUserRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ComplaintServiceServer).CreateStaffUser(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/complaint.ComplaintService/CreateStaffUser",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ComplaintServiceServer).CreateStaffUser(ctx, req.(*CreateStaffUserRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ComplaintService_Logout_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(LogoutRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ComplaintServiceServer).Logout(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/complaint.ComplaintService/Logout",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ComplaintServiceServer).Logout(ctx, req.(*LogoutRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ComplaintService_RefreshSession_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RefreshSessionRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ComplaintServiceServer).RefreshSession(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/complaint.ComplaintService/RefreshSession",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ComplaintServiceServer).RefreshSession(ctx, req.(*RefreshSessionRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ComplaintService_RotateSecretCode_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RotateSecretCodeRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ComplaintServiceServer).RotateSecretCode(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/complaint.ComplaintService/RotateSecretCode",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ComplaintServiceServer).RotateSecretCode(ctx, req.(*RotateSecretCodeRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ComplaintService_ResetSecretCode_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ResetSecretCodeRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ComplaintServiceServer).ResetSecretCode(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/complaint.ComplaintService/ResetSecretCode",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ComplaintServiceServer).ResetSecretCode(ctx, req.(*ResetSecretCodeRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ComplaintService_RedeemRecoveryCode_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RedeemRecoveryCodeRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ComplaintServiceServer).RedeemRecoveryCode(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/complaint.ComplaintService/RedeemRecoveryCode",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ComplaintServiceServer).RedeemRecoveryCode(ctx, req.(*RedeemRecoveryCodeRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ComplaintService_QueryAuditLog_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(QueryAuditLogRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ComplaintServiceServer).QueryAuditLog(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/complaint.ComplaintService/QueryAuditLog",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ComplaintServiceServer).QueryAuditLog(ctx, req.(*QueryAuditLogRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ComplaintService_SearchComplaints_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SearchComplaintsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ComplaintServiceServer).SearchComplaints(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/complaint.ComplaintService/SearchComplaints",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ComplaintServiceServer).SearchComplaints(ctx, req.(*SearchComplaintsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ComplaintService_WatchComplaints_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(WatchComplaintsRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(ComplaintServiceServer).WatchComplaints(m, &complaintServiceWatchComplaintsServer{stream})
+}
+
+type ComplaintService_WatchComplaintsServer interface {
+	Send(*ComplaintEvent) error
+	grpc.ServerStream
+}
+
+type complaintServiceWatchComplaintsServer struct {
+	grpc.ServerStream
+}
+
+func (x *complaintServiceWatchComplaintsServer) Send(m *ComplaintEvent) error {
+	return x.ServerStream.SendMsg(m)
+}
+
+func _ComplaintService_GetComplaintStats_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetComplaintStatsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ComplaintServiceServer).GetComplaintStats(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/complaint.ComplaintService/GetComplaintStats",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ComplaintServiceServer).GetComplaintStats(ctx, req.(*GetComplaintStatsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ComplaintService_ExportComplaints_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(ExportComplaintsRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(ComplaintServiceServer).ExportComplaints(m, &complaintServiceExportComplaintsServer{stream})
+}
+
+type ComplaintService_ExportComplaintsServer interface {
+	Send(*ExportChunk) error
+	grpc.ServerStream
+}
+
+type complaintServiceExportComplaintsServer struct {
+	grpc.ServerStream
+}
+
+func (x *complaintServiceExportComplaintsServer) Send(m *ExportChunk) error {
+	return x.ServerStream.SendMsg(m)
+}
+
+func _ComplaintService_CreateWebhook_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CreateWebhookRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ComplaintServiceServer).CreateWebhook(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/complaint.ComplaintService/CreateWebhook",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ComplaintServiceServer).CreateWebhook(ctx, req.(*CreateWebhookRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ComplaintService_ListWebhooks_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListWebhooksRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ComplaintServiceServer).ListWebhooks(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/complaint.ComplaintService/ListWebhooks",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ComplaintServiceServer).ListWebhooks(ctx, req.(*ListWebhooksRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ComplaintService_DeleteWebhook_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(DeleteWebhookRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ComplaintServiceServer).DeleteWebhook(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/complaint.ComplaintService/DeleteWebhook",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ComplaintServiceServer).DeleteWebhook(ctx, req.(*DeleteWebhookRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ComplaintService_ListWebhookDeliveries_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListWebhookDeliveriesRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ComplaintServiceServer).ListWebhookDeliveries(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/complaint.ComplaintService/ListWebhookDeliveries",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ComplaintServiceServer).ListWebhookDeliveries(ctx, req.(*ListWebhookDeliveriesRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ComplaintService_RedeliverWebhook_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RedeliverWebhookRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ComplaintServiceServer).RedeliverWebhook(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/complaint.ComplaintService/RedeliverWebhook",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ComplaintServiceServer).RedeliverWebhook(ctx, req.(*RedeliverWebhookRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 // ComplaintService_ServiceDesc is the grpc.ServiceDesc for ComplaintService service.
 // It's only intended for direct use with grpc.RegisterService,
 // and not to be introspected or modified (even as a copy)
@@ -315,7 +975,86 @@ var ComplaintService_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "ResolveComplaint",
 			Handler:    _ComplaintService_ResolveComplaint_Handler,
 		},
+		{
+			MethodName: "TransitionComplaint",
+			Handler:    _ComplaintService_TransitionComplaint_Handler,
+		},
+		{
+			MethodName: "AddComment",
+			Handler:    _ComplaintService_AddComment_Handler,
+		},
+		{
+			MethodName: "ListComments",
+			Handler:    _ComplaintService_ListComments_Handler,
+		},
+		{
+			MethodName: "CreateStaffUser",
+			Handler:    _ComplaintService_CreateStaffUser_Handler,
+		},
+		{
+			MethodName: "Logout",
+			Handler:    _ComplaintService_Logout_Handler,
+		},
+		{
+			MethodName: "RefreshSession",
+			Handler:    _ComplaintService_RefreshSession_Handler,
+		},
+		{
+			MethodName: "RotateSecretCode",
+			Handler:    _ComplaintService_RotateSecretCode_Handler,
+		},
+		{
+			MethodName: "ResetSecretCode",
+			Handler:    _ComplaintService_ResetSecretCode_Handler,
+		},
+		{
+			MethodName: "RedeemRecoveryCode",
+			Handler:    _ComplaintService_RedeemRecoveryCode_Handler,
+		},
+		{
+			MethodName: "QueryAuditLog",
+			Handler:    _ComplaintService_QueryAuditLog_Handler,
+		},
+		{
+			MethodName: "SearchComplaints",
+			Handler:    _ComplaintService_SearchComplaints_Handler,
+		},
+		{
+			MethodName: "GetComplaintStats",
+			Handler:    _ComplaintService_GetComplaintStats_Handler,
+		},
+		{
+			MethodName: "CreateWebhook",
+			Handler:    _ComplaintService_CreateWebhook_Handler,
+		},
+		{
+			MethodName: "ListWebhooks",
+			Handler:    _ComplaintService_ListWebhooks_Handler,
+		},
+		{
+			MethodName: "DeleteWebhook",
+			Handler:    _ComplaintService_DeleteWebhook_Handler,
+		},
+		{
+			MethodName: "ListWebhookDeliveries",
+			Handler:    _ComplaintService_ListWebhookDeliveries_Handler,
+		},
+		{
+			MethodName: "RedeliverWebhook",
+			Handler:    _ComplaintService_RedeliverWebhook_Handler,
+		},
+	},
+	Streams: []grpc.StreamDesc{
+		{
+			StreamName:    "WatchComplaints",
+			Handler:       _ComplaintService_WatchComplaints_Handler,
+			ServerStreams: true,
+		},
+		{
+			StreamName:    "ExportComplaints",
+			Handler:       _ComplaintService_ExportComplaints_Handler,
+			ServerStreams: true,
+		},
 	},
-	Streams:  []grpc.StreamDesc{},
 	Metadata: "proto/complaint.proto",
 }
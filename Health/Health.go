@@ -0,0 +1,124 @@
+// Health/Health.go
+package Health
+
+import (
+	"complaint-portal/Common"
+	"complaint-portal/Storage"
+	"context"
+	"log/slog"
+	"sync"
+	"time"
+
+	"google.golang.org/grpc/health"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
+)
+
+// Options tune a Checker.
+type Options struct {
+	// Interval is the time between two probes of the store.
+	Interval time.Duration
+	// Timeout bounds each probe.
+	Timeout time.Duration
+}
+
+// DefaultOptions probe the store every 10 seconds.
+var DefaultOptions = Options{Interval: 10 * time.Second, Timeout: 3 * time.Second}
+
+// Checker reports the services of a gRPC server as serving while their store
+// answers, through the standard grpc.health.v1 service.
+type Checker struct {
+	// Server is the health service to register with the gRPC server.
+	Server *health.Server
+
+	store    Storage.Store
+	services []string
+	opts     Options
+
+	mu      sync.Mutex
+	serving bool
+	checked bool
+
+	done chan struct{}
+	stop sync.Once
+	wg   sync.WaitGroup
+}
+
+// NewChecker returns a Checker reporting the named services, and the server as
+// a whole, as serving only while store answers. Everything is reported as not
+// serving until the first probe succeeds.
+func NewChecker(store Storage.Store, services []string, opts Options) *Checker {
+	if opts.Interval <= 0 {
+		opts.Interval = DefaultOptions.Interval
+	}
+	if opts.Timeout <= 0 {
+		opts.Timeout = DefaultOptions.Timeout
+	}
+	c := &Checker{
+		Server:   health.NewServer(),
+		store:    store,
+		services: append([]string{""}, services...),
+		opts:     opts,
+		done:     make(chan struct{}),
+	}
+	c.set(healthpb.HealthCheckResponse_NOT_SERVING)
+	return c
+}
+
+// Check probes the store once and updates the reported status.
+func (c *Checker) Check(ctx context.Context) error {
+	ctx, cancel := context.WithTimeout(ctx, c.opts.Timeout)
+	defer cancel()
+	err := c.store.Ping(ctx)
+
+	c.mu.Lock()
+	changed := !c.checked || c.serving != (err == nil)
+	c.serving, c.checked = err == nil, true
+	c.mu.Unlock()
+	if err != nil {
+		c.set(healthpb.HealthCheckResponse_NOT_SERVING)
+		if changed {
+			slog.Warn(Common.LogStoreUnhealthy, Common.LogKeyError, err)
+		}
+		return err
+	}
+	c.set(healthpb.HealthCheckResponse_SERVING)
+	if changed {
+		slog.Info(Common.LogStoreHealthy)
+	}
+	return nil
+}
+
+// Start probes the store now, then every Interval until Shutdown is called.
+func (c *Checker) Start() {
+	c.Check(context.Background())
+	c.wg.Add(1)
+	go func() {
+		defer c.wg.Done()
+		ticker := time.NewTicker(c.opts.Interval)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-c.done:
+				return
+			case <-ticker.C:
+				c.Check(context.Background())
+			}
+		}
+	}()
+}
+
+// Shutdown stops probing and reports every service as not serving for good,
+// so that load balancers stop sending requests while the server drains.
+func (c *Checker) Shutdown() {
+	c.stop.Do(func() {
+		close(c.done)
+		c.wg.Wait()
+		c.Server.Shutdown()
+	})
+}
+
+func (c *Checker) set(st healthpb.HealthCheckResponse_ServingStatus) {
+	for _, service := range c.services {
+		c.Server.SetServingStatus(service, st)
+	}
+}
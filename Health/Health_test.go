@@ -0,0 +1,90 @@
+// Health/Health_test.go
+package Health
+
+import (
+	"complaint-portal/Storage"
+	"context"
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
+)
+
+// flakyStore is a store whose Ping fails while down is set.
+type flakyStore struct {
+	Storage.Store
+	mu   sync.Mutex
+	down bool
+}
+
+func (f *flakyStore) Ping(ctx context.Context) error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	if f.down {
+		return errors.New("connection refused")
+	}
+	return nil
+}
+
+func (f *flakyStore) setDown(down bool) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.down = down
+}
+
+// statusOf returns the status the checker reports for a service.
+func statusOf(t *testing.T, c *Checker, service string) healthpb.HealthCheckResponse_ServingStatus {
+	t.Helper()
+	res, err := c.Server.Check(context.Background(), &healthpb.HealthCheckRequest{Service: service})
+	if err != nil {
+		t.Fatalf("Failed to check %q: %v", service, err)
+	}
+	return res.GetStatus()
+}
+
+func TestChecker(t *testing.T) {
+	store := &flakyStore{Store: Storage.NewMemoryStore()}
+	c := NewChecker(store, []string{"complaint.ComplaintService"}, Options{Interval: time.Millisecond})
+
+	// Test case 1: Nothing is serving before the first probe
+	if st := statusOf(t, c, ""); st != healthpb.HealthCheckResponse_NOT_SERVING {
+		t.Errorf("Expected NOT_SERVING before probing, but got %v", st)
+	}
+
+	// Test case 2: The server and the service serve while the store answers
+	if err := c.Check(context.Background()); err != nil {
+		t.Fatalf("Expected the probe to succeed, but got %v", err)
+	}
+	for _, service := range []string{"", "complaint.ComplaintService"} {
+		if st := statusOf(t, c, service); st != healthpb.HealthCheckResponse_SERVING {
+			t.Errorf("Expected %q to be SERVING, but got %v", service, st)
+		}
+	}
+
+	// Test case 3: The periodic probe notices the store going down, and back up
+	c.Start()
+	store.setDown(true)
+	waitFor(t, c, healthpb.HealthCheckResponse_NOT_SERVING)
+	store.setDown(false)
+	waitFor(t, c, healthpb.HealthCheckResponse_SERVING)
+
+	// Test case 4: After Shutdown, nothing serves any more, even with a healthy store
+	c.Shutdown()
+	c.Check(context.Background())
+	if st := statusOf(t, c, "complaint.ComplaintService"); st != healthpb.HealthCheckResponse_NOT_SERVING {
+		t.Errorf("Expected NOT_SERVING after Shutdown, but got %v", st)
+	}
+}
+
+// waitFor waits for the server's status to become want.
+func waitFor(t *testing.T, c *Checker, want healthpb.HealthCheckResponse_ServingStatus) {
+	t.Helper()
+	for deadline := time.Now().Add(time.Second); statusOf(t, c, "") != want; {
+		if time.Now().After(deadline) {
+			t.Fatalf("Expected the status to become %v", want)
+		}
+		time.Sleep(time.Millisecond)
+	}
+}
@@ -0,0 +1,113 @@
+// Logging/Interceptor.go
+package Logging
+
+import (
+	"complaint-portal/Common"
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"log/slog"
+	"time"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+)
+
+// maxRequestIDLength bounds the request IDs accepted from clients.
+const maxRequestIDLength = 128
+
+// UnaryServerInterceptor gives every call a request ID, and logs a record per
+// call with its method, caller, latency and status code. The request ID is the
+// one the client sent in the x-request-id metadata, or a new one, and is sent
+// back in the response header. It must run first, so that calls refused by the
+// other interceptors are logged too.
+func UnaryServerInterceptor(logger *slog.Logger) grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+		ctx, id := startCall(ctx)
+		grpc.SetHeader(ctx, metadata.Pairs(Common.RequestIDMetadata, id))
+		start := time.Now()
+		res, err := handler(ctx, req)
+		logCall(ctx, logger, info.FullMethod, start, err)
+		return res, err
+	}
+}
+
+// StreamServerInterceptor is UnaryServerInterceptor for streaming RPCs. The
+// record is logged when the stream ends.
+func StreamServerInterceptor(logger *slog.Logger) grpc.StreamServerInterceptor {
+	return func(srv interface{}, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+		ctx, id := startCall(ss.Context())
+		ss.SetHeader(metadata.Pairs(Common.RequestIDMetadata, id))
+		start := time.Now()
+		err := handler(srv, &callStream{ServerStream: ss, ctx: ctx})
+		logCall(ctx, logger, info.FullMethod, start, err)
+		return err
+	}
+}
+
+// startCall returns ctx with the call stored in it, and its request ID.
+func startCall(ctx context.Context) (context.Context, string) {
+	md, _ := metadata.FromIncomingContext(ctx)
+	id := ""
+	if values := md.Get(Common.RequestIDMetadata); len(values) > 0 && ValidRequestID(values[0]) {
+		id = values[0]
+	} else {
+		id = NewRequestID()
+	}
+	ctx, _ = withCall(ctx, id)
+	return ctx, id
+}
+
+// logCall logs the record of a finished call. Calls failing because of the
+// server are logged as errors, the others as information.
+func logCall(ctx context.Context, logger *slog.Logger, method string, start time.Time, err error) {
+	code := status.Code(err)
+	level := slog.LevelInfo
+	switch code {
+	case codes.Internal, codes.Unknown, codes.DataLoss, codes.Unimplemented:
+		level = slog.LevelError
+	}
+	attrs := []slog.Attr{
+		slog.String(Common.LogKeyMethod, method),
+		slog.String(Common.LogKeyCode, code.String()),
+		slog.Float64(Common.LogKeyLatency, float64(time.Since(start).Microseconds())/1000),
+	}
+	if err != nil {
+		attrs = append(attrs, slog.String(Common.LogKeyError, status.Convert(err).Message()))
+	}
+	logger.LogAttrs(ctx, level, Common.LogFinishedCall, attrs...)
+}
+
+// NewRequestID returns a random request ID.
+func NewRequestID() string {
+	b := make([]byte, 16)
+	rand.Read(b)
+	return hex.EncodeToString(b)
+}
+
+// ValidRequestID reports whether a request ID sent by a client may be kept:
+// it must be short and only hold printable ASCII characters other than spaces,
+// so that it cannot forge log lines or headers.
+func ValidRequestID(id string) bool {
+	if id == "" || len(id) > maxRequestIDLength {
+		return false
+	}
+	for i := 0; i < len(id); i++ {
+		if id[i] <= ' ' || id[i] > '~' {
+			return false
+		}
+	}
+	return true
+}
+
+// callStream serves the context of the call to the stream's handler.
+type callStream struct {
+	grpc.ServerStream
+	ctx context.Context
+}
+
+func (s *callStream) Context() context.Context {
+	return s.ctx
+}
@@ -0,0 +1,124 @@
+// Logging/Logging.go
+package Logging
+
+import (
+	"complaint-portal/Common"
+	"context"
+	"fmt"
+	"io"
+	"log/slog"
+	"strings"
+	"sync"
+)
+
+// Supported values for the log format setting.
+const (
+	FormatJSON = "json"
+	FormatText = "text"
+)
+
+// Options configure the loggers returned by New.
+type Options struct {
+	// Format is FormatJSON or FormatText.
+	Format string
+	// Level is the lowest level logged: debug, info, warn or error.
+	Level string
+}
+
+// DefaultOptions log JSON lines from the info level up.
+var DefaultOptions = Options{Format: FormatJSON, Level: "info"}
+
+// New returns a logger writing to w. Every record is redacted (see Redact),
+// and records logged with the context of a call carry its request ID and, once
+// known, the ID of the calling user.
+func New(w io.Writer, opts Options) (*slog.Logger, error) {
+	level, err := ParseLevel(opts.Level)
+	if err != nil {
+		return nil, err
+	}
+	handlerOpts := &slog.HandlerOptions{Level: level, ReplaceAttr: Redact}
+	var handler slog.Handler
+	switch opts.Format {
+	case FormatJSON:
+		handler = slog.NewJSONHandler(w, handlerOpts)
+	case FormatText:
+		handler = slog.NewTextHandler(w, handlerOpts)
+	default:
+		return nil, fmt.Errorf(Common.ErrUnknownLogFormat, opts.Format)
+	}
+	return slog.New(contextHandler{handler}), nil
+}
+
+// ParseLevel parses a log level name, such as "debug" or "warn".
+func ParseLevel(name string) (slog.Level, error) {
+	var level slog.Level
+	err := level.UnmarshalText([]byte(strings.ToUpper(name)))
+	return level, err
+}
+
+// contextHandler adds the request and user IDs of the call in the context of
+// each record.
+type contextHandler struct {
+	slog.Handler
+}
+
+func (h contextHandler) Handle(ctx context.Context, r slog.Record) error {
+	if c, ok := ctx.Value(callKey{}).(*call); ok {
+		r.AddAttrs(slog.String(Common.LogKeyRequestID, c.requestID))
+		if userID := c.user(); userID != "" {
+			r.AddAttrs(slog.String(Common.LogKeyUserID, userID))
+		}
+	}
+	return h.Handler.Handle(ctx, r)
+}
+
+func (h contextHandler) WithAttrs(attrs []slog.Attr) slog.Handler {
+	return contextHandler{h.Handler.WithAttrs(attrs)}
+}
+
+func (h contextHandler) WithGroup(name string) slog.Handler {
+	return contextHandler{h.Handler.WithGroup(name)}
+}
+
+// call is what is known about the call a context belongs to. The user is only
+// known once an inner interceptor has authenticated it, after the context was
+// made, so it is set in place.
+type call struct {
+	requestID string
+	mu        sync.Mutex
+	userID    string
+}
+
+func (c *call) user() string {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.userID
+}
+
+// callKey is the context key under which the call is stored.
+type callKey struct{}
+
+// withCall returns a copy of ctx belonging to a call with the given request ID.
+func withCall(ctx context.Context, requestID string) (context.Context, *call) {
+	c := &call{requestID: requestID}
+	return context.WithValue(ctx, callKey{}, c), c
+}
+
+// RequestID returns the request ID of the call ctx belongs to, or "" outside
+// of calls.
+func RequestID(ctx context.Context) string {
+	if c, ok := ctx.Value(callKey{}).(*call); ok {
+		return c.requestID
+	}
+	return ""
+}
+
+// SetUserID records the user making the call ctx belongs to, for the records
+// logged about it. It does nothing outside of calls.
+func SetUserID(ctx context.Context, userID string) {
+	if c, ok := ctx.Value(callKey{}).(*call); ok {
+		c.mu.Lock()
+		c.userID = userID
+		c.mu.Unlock()
+	}
+}
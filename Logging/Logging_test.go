@@ -0,0 +1,196 @@
+// Logging/Logging_test.go
+package Logging
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"log/slog"
+	"strings"
+	"testing"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+)
+
+// newTestLogger returns a JSON logger writing to a buffer, and a function
+// returning the records written so far.
+func newTestLogger(t *testing.T, level string) (*slog.Logger, func() []map[string]interface{}) {
+	t.Helper()
+	var buf bytes.Buffer
+	logger, err := New(&buf, Options{Format: FormatJSON, Level: level})
+	if err != nil {
+		t.Fatalf("Expected no error, but got %v", err)
+	}
+	return logger, func() []map[string]interface{} {
+		var records []map[string]interface{}
+		for _, line := range strings.Split(strings.TrimSpace(buf.String()), "\n") {
+			if line == "" {
+				continue
+			}
+			var record map[string]interface{}
+			if err := json.Unmarshal([]byte(line), &record); err != nil {
+				t.Fatalf("Expected a JSON record, but got %q: %v", line, err)
+			}
+			records = append(records, record)
+		}
+		return records
+	}
+}
+
+// TestNew checks the formats and levels of the loggers.
+func TestNew(t *testing.T) {
+	// Test case 1: Records below the level are dropped
+	logger, records := newTestLogger(t, "warn")
+	logger.Info("hidden")
+	logger.Warn("shown", "count", 3)
+	if got := records(); len(got) != 1 || got[0]["msg"] != "shown" || got[0]["count"] != 3.0 || got[0]["level"] != "WARN" {
+		t.Errorf("Expected the warning alone, but got %v", got)
+	}
+
+	// Test case 2: The text format is supported
+	var buf bytes.Buffer
+	text, err := New(&buf, Options{Format: FormatText, Level: "debug"})
+	if err != nil {
+		t.Fatalf("Expected no error, but got %v", err)
+	}
+	text.Debug("hello", "key", "value")
+	if !strings.Contains(buf.String(), "msg=hello key=value") {
+		t.Errorf("Expected a text record, but got %q", buf.String())
+	}
+
+	// Test case 3: Unknown formats and levels are refused
+	for _, opts := range []Options{{Format: "xml", Level: "info"}, {Format: FormatJSON, Level: "loud"}} {
+		if _, err := New(&buf, opts); err == nil {
+			t.Errorf("Expected an error for %+v", opts)
+		}
+	}
+}
+
+// TestRedact checks that credentials and email addresses are never logged.
+func TestRedact(t *testing.T) {
+	logger, records := newTestLogger(t, "info")
+	logger.Info("Registered jane.doe+test@example.com",
+		"secret_code", "a1b2c3d4e5f6",
+		"NewSecretCode", "f6e5d4c3b2a1",
+		"authorization", "Bearer abc.def",
+		"email", "jane@mail.example.org",
+		"error", errors.New("cannot send to jane@example.com: mailbox full"),
+		"complaint_id", "1234abcd",
+	)
+	record := records()[0]
+	line, _ := json.Marshal(record)
+	for _, clear := range []string{"jane", "a1b2c3d4e5f6", "f6e5d4c3b2a1", "abc.def"} {
+		if strings.Contains(string(line), clear) {
+			t.Errorf("Expected %q to be redacted, but got %s", clear, line)
+		}
+	}
+	if record["msg"] != "Registered ***@example.com" || record["email"] != "***@mail.example.org" {
+		t.Errorf("Expected the domains of the email addresses to be kept, but got %s", line)
+	}
+	if record["secret_code"] != "<redacted>" || record["error"] != "cannot send to ***@example.com: mailbox full" || record["complaint_id"] != "1234abcd" {
+		t.Errorf("Expected only the credentials and emails to be redacted, but got %s", line)
+	}
+}
+
+// TestUnaryServerInterceptor checks the request IDs and the records of calls.
+func TestUnaryServerInterceptor(t *testing.T) {
+	logger, records := newTestLogger(t, "debug")
+	interceptor := UnaryServerInterceptor(logger)
+	info := &grpc.UnaryServerInfo{FullMethod: "/complaint.ComplaintService/ViewComplaint"}
+	call := func(ctx context.Context, err error) string {
+		var id string
+		interceptor(ctx, nil, info, func(ctx context.Context, req interface{}) (interface{}, error) {
+			id = RequestID(ctx)
+			SetUserID(ctx, "u1")
+			logger.DebugContext(ctx, "handling")
+			return nil, err
+		})
+		return id
+	}
+
+	// Test case 1: The client's request ID is kept, and every record of the call
+	// carries it and the user
+	ctx := metadata.NewIncomingContext(context.Background(), metadata.Pairs("x-request-id", "req-42"))
+	if id := call(ctx, nil); id != "req-42" {
+		t.Errorf("Expected the client's request ID, but got %q", id)
+	}
+	got := records()
+	if len(got) != 2 {
+		t.Fatalf("Expected 2 records, but got %v", got)
+	}
+	for _, record := range got {
+		if record["request_id"] != "req-42" || record["user_id"] != "u1" {
+			t.Errorf("Expected the request and user IDs, but got %v", record)
+		}
+	}
+	if finished := got[1]; finished["msg"] != "Finished call" || finished["method"] != info.FullMethod || finished["code"] != "OK" || finished["level"] != "INFO" {
+		t.Errorf("Expected the record of the call, but got %v", finished)
+	} else if _, ok := finished["latency_ms"].(float64); !ok {
+		t.Errorf("Expected the latency, but got %v", finished)
+	}
+
+	// Test case 2: Calls without a valid request ID are given one
+	for _, ctx := range []context.Context{
+		context.Background(),
+		metadata.NewIncomingContext(context.Background(), metadata.Pairs("x-request-id", "forged\nline")),
+		metadata.NewIncomingContext(context.Background(), metadata.Pairs("x-request-id", strings.Repeat("a", 200))),
+	} {
+		if id := call(ctx, nil); len(id) != 32 {
+			t.Errorf("Expected a new request ID, but got %q", id)
+		}
+	}
+
+	// Test case 3: Failed calls are logged with their code and message, server
+	// errors as errors
+	before := len(records())
+	call(context.Background(), status.Error(codes.NotFound, "Complaint not found"))
+	call(context.Background(), status.Error(codes.Internal, "Failed to query database"))
+	got = records()[before:]
+	if got[1]["code"] != "NotFound" || got[1]["error"] != "Complaint not found" || got[1]["level"] != "INFO" {
+		t.Errorf("Expected a NotFound record, but got %v", got[1])
+	}
+	if got[3]["code"] != "Internal" || got[3]["level"] != "ERROR" {
+		t.Errorf("Expected an Internal error record, but got %v", got[3])
+	}
+}
+
+// testStream is a server stream with a context and headers.
+type testStream struct {
+	grpc.ServerStream
+	ctx    context.Context
+	header metadata.MD
+}
+
+func (s *testStream) Context() context.Context { return s.ctx }
+
+func (s *testStream) SetHeader(md metadata.MD) error {
+	s.header = metadata.Join(s.header, md)
+	return nil
+}
+
+// TestStreamServerInterceptor checks that streams get a request ID, sent back
+// in the header, and are logged when they end.
+func TestStreamServerInterceptor(t *testing.T) {
+	logger, records := newTestLogger(t, "info")
+	interceptor := StreamServerInterceptor(logger)
+	ss := &testStream{ctx: metadata.NewIncomingContext(context.Background(), metadata.Pairs("x-request-id", "watch-1"))}
+	info := &grpc.StreamServerInfo{FullMethod: "/complaint.ComplaintService/WatchComplaints"}
+	var id string
+	err := interceptor(nil, ss, info, func(srv interface{}, stream grpc.ServerStream) error {
+		id = RequestID(stream.Context())
+		return status.Error(codes.Unavailable, "Server is shutting down")
+	})
+	if status.Code(err) != codes.Unavailable || id != "watch-1" {
+		t.Errorf("Expected the stream's error and request ID, but got %v and %q", err, id)
+	}
+	if got := ss.header.Get("x-request-id"); len(got) != 1 || got[0] != "watch-1" {
+		t.Errorf("Expected the request ID in the header, but got %v", got)
+	}
+	if got := records(); len(got) != 1 || got[0]["method"] != info.FullMethod || got[0]["code"] != "Unavailable" || got[0]["request_id"] != "watch-1" {
+		t.Errorf("Expected the record of the stream, but got %v", got)
+	}
+}
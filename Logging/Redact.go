@@ -0,0 +1,58 @@
+// Logging/Redact.go
+package Logging
+
+import (
+	"complaint-portal/Common"
+	"fmt"
+	"log/slog"
+	"regexp"
+	"strings"
+)
+
+// sensitiveKeys are parts of the keys of attributes that hold credentials.
+// Such attributes are never logged, whatever their value.
+var sensitiveKeys = []string{"secret", "password", "token", "authorization", "pepper", "session_key", "recovery_code"}
+
+// emailPattern matches email addresses in free text. The part before the @ is
+// masked; the domain is kept, as it helps to tell mail problems apart.
+var emailPattern = regexp.MustCompile(`[A-Za-z0-9.!#$%&'*+/=?^_{|}~-]+@([A-Za-z0-9-]+(?:\.[A-Za-z0-9-]+)+)`)
+
+// Redact is the slog.HandlerOptions.ReplaceAttr of the loggers returned by New.
+// It drops the values of credential attributes, such as secret codes and
+// session tokens, and masks the email addresses in every other string, the
+// message included. Errors and fmt.Stringers are logged as their redacted text.
+func Redact(groups []string, a slog.Attr) slog.Attr {
+	if isSensitive(a.Key) {
+		return slog.String(a.Key, Common.RedactedSecret)
+	}
+	switch a.Value.Kind() {
+	case slog.KindString:
+		return slog.String(a.Key, RedactEmails(a.Value.String()))
+	case slog.KindAny:
+		switch v := a.Value.Any().(type) {
+		case error:
+			return slog.String(a.Key, RedactEmails(v.Error()))
+		case fmt.Stringer:
+			return slog.String(a.Key, RedactEmails(v.String()))
+		}
+	}
+	return a
+}
+
+// RedactEmails masks the email addresses in s.
+func RedactEmails(s string) string {
+	if !strings.Contains(s, "@") {
+		return s
+	}
+	return emailPattern.ReplaceAllString(s, Common.RedactedEmail+"@$1")
+}
+
+func isSensitive(key string) bool {
+	key = strings.ToLower(key)
+	for _, part := range sensitiveKeys {
+		if strings.Contains(key, part) {
+			return true
+		}
+	}
+	return false
+}
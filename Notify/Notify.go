@@ -0,0 +1,236 @@
+// Notify/Notify.go
+package Notify
+
+import (
+	"complaint-portal/Common"
+	"complaint-portal/Storage"
+	"context"
+	"errors"
+	"log/slog"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+// Kind is a reason to email a complaint's owner.
+type Kind string
+
+const (
+	// Submitted confirms that a complaint was received.
+	Submitted Kind = "submitted"
+	// StatusChanged tells that a complaint moved to another status.
+	StatusChanged Kind = "status_changed"
+	// Commented tells that staff commented on a complaint.
+	Commented Kind = "commented"
+)
+
+// Kinds lists every kind of notification.
+var Kinds = []Kind{Submitted, StatusChanged, Commented}
+
+// Event is something that happened to a complaint. Complaint may be left empty
+// for Commented, in which case it is read from the store.
+type Event struct {
+	Kind      Kind
+	Complaint Common.Complaint
+	// From is the previous status, for StatusChanged.
+	From Common.ComplaintStatus
+	// Comment is the new comment, for Commented.
+	Comment Common.Comment
+}
+
+// Options tune a Notifier's queue.
+type Options struct {
+	// QueueSize is how many notifications may wait to be sent. Notifications
+	// arriving while the queue is full are dropped.
+	QueueSize int
+	// MaxAttempts is how many times a message is sent before giving up.
+	MaxAttempts int
+	// Backoff is the wait before the first retry, doubled for each next one.
+	Backoff time.Duration
+}
+
+// DefaultOptions retry a message for about half a minute.
+var DefaultOptions = Options{QueueSize: 256, MaxAttempts: 5, Backoff: 2 * time.Second}
+
+// job is a queued notification. msg is nil until the event is rendered.
+type job struct {
+	event    Event
+	msg      *Message
+	attempts int
+}
+
+// Notifier emails complaint owners about their complaints. Events are queued
+// and sent by a background worker, so that callers never wait for the mail
+// server; failed sends are retried with exponential backoff.
+type Notifier struct {
+	store     Storage.Store
+	sender    Sender
+	templates *Templates
+	opts      Options
+
+	queue    chan job
+	done     chan struct{}
+	stopped  chan struct{}
+	stop     sync.Once
+	inFlight int64
+}
+
+// NewNotifier returns a Notifier reading users and complaints from store and
+// sending messages rendered from templates through sender. Its worker runs
+// until Close is called.
+func NewNotifier(store Storage.Store, sender Sender, templates *Templates, opts Options) *Notifier {
+	if opts.QueueSize <= 0 {
+		opts.QueueSize = DefaultOptions.QueueSize
+	}
+	if opts.MaxAttempts <= 0 {
+		opts.MaxAttempts = DefaultOptions.MaxAttempts
+	}
+	n := &Notifier{
+		store:     store,
+		sender:    sender,
+		templates: templates,
+		opts:      opts,
+		queue:     make(chan job, opts.QueueSize),
+		done:      make(chan struct{}),
+		stopped:   make(chan struct{}),
+	}
+	go n.run()
+	return n
+}
+
+// Notify queues an event. It never blocks: the event is dropped if the queue
+// is full or the notifier closed.
+func (n *Notifier) Notify(e Event) {
+	atomic.AddInt64(&n.inFlight, 1)
+	n.push(job{event: e})
+}
+
+func (n *Notifier) push(j job) {
+	select {
+	case <-n.done:
+		n.finish()
+		return
+	default:
+	}
+	select {
+	case n.queue <- j:
+	default:
+		slog.Warn(Common.LogNotificationDropped, Common.LogKeyKind, j.event.Kind, Common.LogKeyComplaint, complaintID(j.event))
+		n.finish()
+	}
+}
+
+func (n *Notifier) finish() {
+	atomic.AddInt64(&n.inFlight, -1)
+}
+
+// Pending returns how many notifications are queued, being sent or waiting
+// for a retry.
+func (n *Notifier) Pending() int {
+	return int(atomic.LoadInt64(&n.inFlight))
+}
+
+// Drain waits until every notification queued so far has been sent or given
+// up on, or until ctx is done.
+func (n *Notifier) Drain(ctx context.Context) error {
+	ticker := time.NewTicker(10 * time.Millisecond)
+	defer ticker.Stop()
+	for n.Pending() > 0 {
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-ticker.C:
+		}
+	}
+	return nil
+}
+
+// Close stops the worker. Notifications still queued or waiting for a retry
+// are dropped; call Drain first to send them.
+func (n *Notifier) Close() {
+	n.stop.Do(func() {
+		close(n.done)
+		<-n.stopped
+	})
+}
+
+func (n *Notifier) run() {
+	defer close(n.stopped)
+	for {
+		select {
+		case <-n.done:
+			return
+		case j := <-n.queue:
+			n.process(j)
+		}
+	}
+}
+
+// process renders a job's message if needed and sends it, scheduling a retry
+// if that fails.
+func (n *Notifier) process(j job) {
+	ctx := context.Background()
+	if j.msg == nil {
+		msg, err := n.render(ctx, j.event)
+		if err != nil {
+			slog.Error(Common.LogNotificationFailed, Common.LogKeyKind, j.event.Kind, Common.LogKeyComplaint, complaintID(j.event), Common.LogKeyError, err)
+		}
+		if msg == nil {
+			n.finish()
+			return
+		}
+		j.msg = msg
+	}
+
+	j.attempts++
+	err := n.sender.Send(ctx, *j.msg)
+	if err == nil {
+		n.finish()
+		return
+	}
+	if !Retryable(err) || j.attempts >= n.opts.MaxAttempts {
+		slog.Error(Common.LogNotificationGaveUp, Common.LogKeyKind, j.event.Kind, Common.LogKeyComplaint, complaintID(j.event), Common.LogKeyAttempts, j.attempts, Common.LogKeyError, err)
+		n.finish()
+		return
+	}
+	time.AfterFunc(n.opts.Backoff<<(j.attempts-1), func() { n.push(j) })
+}
+
+// render returns the message telling the complaint's owner about the event, or
+// nil if there is no one to tell.
+func (n *Notifier) render(ctx context.Context, e Event) (*Message, error) {
+	if e.Complaint.ID == "" && e.Comment.ComplaintID != "" {
+		c, err := n.store.GetComplaint(ctx, e.Comment.ComplaintID)
+		if err != nil {
+			return nil, err
+		}
+		e.Complaint = c
+	}
+	user, err := n.store.GetUser(ctx, e.Complaint.UserID)
+	if errors.Is(err, Storage.ErrNotFound) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	if user.Email == "" {
+		return nil, nil
+	}
+
+	data := Data{User: user, Complaint: e.Complaint, From: e.From, To: e.Complaint.CurrentStatus(), Comment: e.Comment}
+	if h := e.Complaint.StatusHistory; e.Kind == StatusChanged && len(h) > 0 {
+		data.Note = h[len(h)-1].Note
+	}
+	subject, body, err := n.templates.Render(e.Kind, data)
+	if err != nil {
+		return nil, err
+	}
+	return &Message{To: user.Email, Subject: subject, Body: body}, nil
+}
+
+func complaintID(e Event) string {
+	if e.Complaint.ID != "" {
+		return e.Complaint.ID
+	}
+	return e.Comment.ComplaintID
+}
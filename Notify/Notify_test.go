@@ -0,0 +1,298 @@
+// Notify/Notify_test.go
+package Notify
+
+import (
+	"bufio"
+	"complaint-portal/Common"
+	"complaint-portal/Storage"
+	"context"
+	"errors"
+	"net"
+	"net/textproto"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+)
+
+// newTestStore returns a store with Ada, who has an email, and Bob, who does not.
+func newTestStore(t *testing.T) *Storage.MemoryStore {
+	store := Storage.NewMemoryStore()
+	store.Reset()
+	t.Cleanup(store.Reset)
+	for _, u := range []Common.User{{ID: "u1", Name: "Ada", Email: "ada@example.com"}, {ID: "u2", Name: "Bob"}} {
+		if err := store.CreateUser(context.Background(), u); err != nil {
+			t.Fatalf("Failed to create user: %v", err)
+		}
+	}
+	return store
+}
+
+// drain waits for the notifier to be idle.
+func drain(t *testing.T, n *Notifier) {
+	t.Helper()
+	ctx, cancel := context.WithTimeout(context.Background(), 2*time.Second)
+	defer cancel()
+	if err := n.Drain(ctx); err != nil {
+		t.Fatalf("Expected the notifications to be sent, but %d are pending", n.Pending())
+	}
+}
+
+func TestTemplates(t *testing.T) {
+	data := Data{
+		User:      Common.User{Name: "Ada"},
+		Complaint: Common.Complaint{ID: "c1", Title: "Broken\nrouter"},
+		From:      Common.StatusOpen,
+		To:        Common.StatusResolved,
+		Note:      "Replaced the router",
+		Comment:   Common.Comment{Body: "We are on it"},
+	}
+
+	// Test case 1: Every kind has a built-in subject and body, with subjects on one line
+	for _, kind := range Kinds {
+		subject, body, err := DefaultTemplates().Render(kind, data)
+		if err != nil || subject == "" || strings.Contains(subject, "\n") || !strings.HasPrefix(body, "Hello Ada,") {
+			t.Errorf("Expected a %s message, but got %q / %q (err: %v)", kind, subject, body, err)
+		}
+	}
+	_, body, _ := DefaultTemplates().Render(StatusChanged, data)
+	if !strings.Contains(body, "from Open to Resolved") || !strings.Contains(body, "Note: Replaced the router") {
+		t.Errorf("Expected the status change and its note, but got %q", body)
+	}
+
+	// Test case 2: Custom templates replace the built-in ones they define
+	templates, err := ParseTemplates(`{{define "submitted.subject"}}Ticket {{.Complaint.ID}}{{end}}`)
+	if err != nil {
+		t.Fatalf("Expected no error, but got %v", err)
+	}
+	subject, body, _ := templates.Render(Submitted, data)
+	if subject != "Ticket c1" || !strings.Contains(body, "We received your complaint") {
+		t.Errorf("Expected a custom subject and the built-in body, but got %q / %q", subject, body)
+	}
+
+	// Test case 3: Invalid templates are refused
+	if _, err := ParseTemplates(`{{define "submitted.body"}}{{.Nope`); err == nil {
+		t.Errorf("Expected a parse error, but got none")
+	}
+	if _, _, err := DefaultTemplates().Render(Kind("unknown"), data); err == nil {
+		t.Errorf("Expected an error for an unknown kind, but got none")
+	}
+}
+
+// flakySender fails its first `failures` sends with err.
+type flakySender struct {
+	MemorySender
+	mu       sync.Mutex
+	failures int
+	err      error
+	attempts int
+}
+
+func (f *flakySender) Send(ctx context.Context, msg Message) error {
+	f.mu.Lock()
+	f.attempts++
+	fail := f.attempts <= f.failures
+	f.mu.Unlock()
+	if fail {
+		return f.err
+	}
+	return f.MemorySender.Send(ctx, msg)
+}
+
+func TestNotifier(t *testing.T) {
+	store := newTestStore(t)
+	opts := Options{QueueSize: 8, MaxAttempts: 3, Backoff: time.Millisecond}
+
+	// Test case 1: Notifications go to the complaint's owner, read from the store for comments
+	sender := &MemorySender{}
+	n := NewNotifier(store, sender, DefaultTemplates(), opts)
+	defer n.Close()
+	_ = store.CreateComplaint(context.Background(), Common.Complaint{ID: "c1", UserID: "u1", Title: "Router"})
+	n.Notify(Event{Kind: Submitted, Complaint: Common.Complaint{ID: "c1", UserID: "u1", Title: "Router"}})
+	n.Notify(Event{Kind: Commented, Comment: Common.Comment{ComplaintID: "c1", Body: "On it"}})
+	drain(t, n)
+	msgs := sender.Messages()
+	if len(msgs) != 2 || msgs[0].To != "ada@example.com" || !strings.Contains(msgs[1].Subject, "Router") || !strings.Contains(msgs[1].Body, "On it") {
+		t.Errorf("Expected 2 messages to Ada, but got %+v", msgs)
+	}
+
+	// Test case 2: Users without an email, or who no longer exist, are skipped
+	n.Notify(Event{Kind: Submitted, Complaint: Common.Complaint{ID: "c2", UserID: "u2"}})
+	n.Notify(Event{Kind: Submitted, Complaint: Common.Complaint{ID: "c3", UserID: "gone"}})
+	drain(t, n)
+	if len(sender.Messages()) != 2 {
+		t.Errorf("Expected no more messages, but got %+v", sender.Messages())
+	}
+
+	// Test case 3: Failed sends are retried until they succeed
+	flaky := &flakySender{failures: 2, err: errors.New("connection refused")}
+	n2 := NewNotifier(store, flaky, DefaultTemplates(), opts)
+	defer n2.Close()
+	n2.Notify(Event{Kind: Submitted, Complaint: Common.Complaint{ID: "c1", UserID: "u1"}})
+	drain(t, n2)
+	if flaky.attempts != 3 || len(flaky.Messages()) != 1 {
+		t.Errorf("Expected 1 message sent on the 3rd attempt, but got %d after %d", len(flaky.Messages()), flaky.attempts)
+	}
+
+	// Test case 4: Retries stop after MaxAttempts, or at once on a permanent failure
+	flaky = &flakySender{failures: 10, err: errors.New("timeout")}
+	n3 := NewNotifier(store, flaky, DefaultTemplates(), opts)
+	defer n3.Close()
+	n3.Notify(Event{Kind: Submitted, Complaint: Common.Complaint{ID: "c1", UserID: "u1"}})
+	drain(t, n3)
+	if flaky.attempts != 3 {
+		t.Errorf("Expected 3 attempts, but got %d", flaky.attempts)
+	}
+	flaky = &flakySender{failures: 10, err: &textproto.Error{Code: 550, Msg: "no such user"}}
+	n4 := NewNotifier(store, flaky, DefaultTemplates(), opts)
+	defer n4.Close()
+	n4.Notify(Event{Kind: Submitted, Complaint: Common.Complaint{ID: "c1", UserID: "u1"}})
+	drain(t, n4)
+	if flaky.attempts != 1 {
+		t.Errorf("Expected 1 attempt, but got %d", flaky.attempts)
+	}
+
+	// Test case 5: Notifications after Close are dropped
+	n.Close()
+	n.Notify(Event{Kind: Submitted, Complaint: Common.Complaint{ID: "c1", UserID: "u1"}})
+	if n.Pending() != 0 {
+		t.Errorf("Expected nothing pending after Close, but got %d", n.Pending())
+	}
+}
+
+func TestNotifyingStore(t *testing.T) {
+	ctx := context.Background()
+	memory := newTestStore(t)
+	sender := &MemorySender{}
+	n := NewNotifier(memory, sender, DefaultTemplates(), Options{Backoff: time.Millisecond})
+	defer n.Close()
+	store := NewNotifyingStore(memory, n)
+
+	// Test case 1: Submissions, status changes and staff comments are notified
+	_ = store.CreateComplaint(ctx, Common.Complaint{ID: "c1", UserID: "u1", Title: "Router", Status: Common.StatusOpen})
+	_, _ = store.UpdateComplaint(ctx, "c1", func(c *Common.Complaint) error {
+		c.SetStatus(Common.StatusResolved, "agent", "", time.Now())
+		return nil
+	})
+	_ = store.AddComment(ctx, Common.Comment{ID: "m1", ComplaintID: "c1", Role: Common.CommentByStaff, Body: "Fixed"})
+	drain(t, n)
+	msgs := sender.Messages()
+	if len(msgs) != 3 || !strings.Contains(msgs[1].Body, "from Open to Resolved") || !strings.Contains(msgs[2].Body, "Fixed") {
+		t.Fatalf("Expected 3 messages, but got %+v", msgs)
+	}
+
+	// Test case 2: Other updates and the owner's own comments are not
+	_, _ = store.UpdateComplaint(ctx, "c1", func(c *Common.Complaint) error {
+		c.Title = "Router again"
+		return nil
+	})
+	_ = store.AddComment(ctx, Common.Comment{ID: "m2", ComplaintID: "c1", Role: Common.CommentByCustomer, Body: "Thanks"})
+	drain(t, n)
+	if len(sender.Messages()) != 3 {
+		t.Errorf("Expected no more messages, but got %+v", sender.Messages())
+	}
+}
+
+// fakeSMTP is a minimal SMTP server. It refuses recipients at reject.example.
+type fakeSMTP struct {
+	addr string
+	mu   sync.Mutex
+	data []string
+}
+
+func startFakeSMTP(t *testing.T) *fakeSMTP {
+	l, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatalf("Failed to listen: %v", err)
+	}
+	t.Cleanup(func() { l.Close() })
+	f := &fakeSMTP{addr: l.Addr().String()}
+	go func() {
+		for {
+			conn, err := l.Accept()
+			if err != nil {
+				return
+			}
+			go f.serve(conn)
+		}
+	}()
+	return f
+}
+
+func (f *fakeSMTP) serve(conn net.Conn) {
+	defer conn.Close()
+	tp := textproto.NewConn(conn)
+	tp.PrintfLine("220 fake ESMTP")
+	for {
+		line, err := tp.ReadLine()
+		if err != nil {
+			return
+		}
+		cmd := strings.ToUpper(strings.SplitN(line, " ", 2)[0])
+		switch {
+		case cmd == "EHLO" || cmd == "HELO":
+			tp.PrintfLine("250 fake")
+		case cmd == "RCPT" && strings.Contains(line, "reject.example"):
+			tp.PrintfLine("550 no such user")
+		case cmd == "MAIL" || cmd == "RCPT" || cmd == "RSET" || cmd == "NOOP":
+			tp.PrintfLine("250 OK")
+		case cmd == "DATA":
+			tp.PrintfLine("354 go ahead")
+			data, err := tp.ReadDotBytes()
+			if err != nil {
+				return
+			}
+			f.mu.Lock()
+			f.data = append(f.data, string(data))
+			f.mu.Unlock()
+			tp.PrintfLine("250 queued")
+		case cmd == "QUIT":
+			tp.PrintfLine("221 bye")
+			return
+		default:
+			tp.PrintfLine("502 not implemented")
+		}
+	}
+}
+
+func TestSMTPSender(t *testing.T) {
+	server := startFakeSMTP(t)
+	sender := &SMTPSender{Addr: server.addr, From: "Complaints <noreply@example.com>", Timeout: 2 * time.Second}
+	ctx := context.Background()
+
+	// Test case 1: The message is delivered with encoded headers
+	err := sender.Send(ctx, Message{To: "ada@example.com", Subject: "Résolu\r\nBcc: eve@example.com", Body: "Hello\n.\nBye\n"})
+	if err != nil {
+		t.Fatalf("Expected no error, but got %v", err)
+	}
+	server.mu.Lock()
+	data := server.data
+	server.mu.Unlock()
+	if len(data) != 1 {
+		t.Fatalf("Expected 1 message, but got %d", len(data))
+	}
+	msg, err := textproto.NewReader(bufio.NewReader(strings.NewReader(data[0]))).ReadMIMEHeader()
+	if err != nil {
+		t.Fatalf("Expected valid headers, but got %v", err)
+	}
+	if msg.Get("To") != "<ada@example.com>" || msg.Get("Bcc") != "" || !strings.HasPrefix(msg.Get("Subject"), "=?utf-8?q?") {
+		t.Errorf("Expected encoded headers, but got %v", msg)
+	}
+	if !strings.HasSuffix(data[0], "\nHello\n.\nBye\n") {
+		t.Errorf("Expected the body to survive dot-stuffing, but got %q", data[0])
+	}
+
+	// Test case 2: Rejected recipients and invalid addresses are permanent failures
+	if err := sender.Send(ctx, Message{To: "eve@reject.example"}); err == nil || Retryable(err) {
+		t.Errorf("Expected a permanent failure, but got %v", err)
+	}
+	if err := sender.Send(ctx, Message{To: "not an address"}); err == nil || Retryable(err) {
+		t.Errorf("Expected a permanent failure, but got %v", err)
+	}
+
+	// Test case 3: An unreachable server is worth retrying
+	sender.Addr = "127.0.0.1:1"
+	if err := sender.Send(ctx, Message{To: "ada@example.com"}); err == nil || !Retryable(err) {
+		t.Errorf("Expected a retryable failure, but got %v", err)
+	}
+}
@@ -0,0 +1,166 @@
+// Notify/Sender.go
+package Notify
+
+import (
+	"context"
+	"crypto/tls"
+	"errors"
+	"fmt"
+	"mime"
+	"net"
+	"net/mail"
+	"net/smtp"
+	"net/textproto"
+	"strings"
+	"sync"
+	"time"
+)
+
+// Message is a plain text email.
+type Message struct {
+	To      string
+	Subject string
+	Body    string
+}
+
+// Sender delivers messages.
+type Sender interface {
+	Send(ctx context.Context, msg Message) error
+}
+
+// permanentError marks a failure that sending again will not fix.
+type permanentError struct{ err error }
+
+func (e permanentError) Error() string { return e.err.Error() }
+func (e permanentError) Unwrap() error { return e.err }
+
+// Permanent marks err as a failure that retrying will not fix.
+func Permanent(err error) error {
+	return permanentError{err}
+}
+
+// Retryable reports whether a message that failed with err may be sent again.
+// Failures marked Permanent and permanent SMTP replies (5xx) are not.
+func Retryable(err error) bool {
+	var perm permanentError
+	if errors.As(err, &perm) {
+		return false
+	}
+	var reply *textproto.Error
+	return !errors.As(err, &reply) || reply.Code < 500
+}
+
+// DefaultSMTPTimeout bounds a whole SMTP conversation.
+const DefaultSMTPTimeout = 30 * time.Second
+
+// SMTPSender sends messages through an SMTP server. It upgrades the connection
+// with STARTTLS when the server offers it.
+type SMTPSender struct {
+	// Addr is the server's host:port.
+	Addr string
+	// From is the sender address, e.g. "Complaints <noreply@example.com>".
+	From string
+	// Auth authenticates with the server. It may be nil.
+	Auth smtp.Auth
+	// Timeout bounds each message; it defaults to DefaultSMTPTimeout.
+	Timeout time.Duration
+}
+
+// Send implements Sender.
+func (s *SMTPSender) Send(ctx context.Context, msg Message) error {
+	from, err := mail.ParseAddress(s.From)
+	if err != nil {
+		return Permanent(fmt.Errorf("invalid sender address: %w", err))
+	}
+	to, err := mail.ParseAddress(msg.To)
+	if err != nil {
+		return Permanent(fmt.Errorf("invalid recipient address: %w", err))
+	}
+
+	timeout := s.Timeout
+	if timeout <= 0 {
+		timeout = DefaultSMTPTimeout
+	}
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+	var d net.Dialer
+	conn, err := d.DialContext(ctx, "tcp", s.Addr)
+	if err != nil {
+		return err
+	}
+	deadline, _ := ctx.Deadline()
+	conn.SetDeadline(deadline)
+
+	host, _, _ := net.SplitHostPort(s.Addr)
+	c, err := smtp.NewClient(conn, host)
+	if err != nil {
+		conn.Close()
+		return err
+	}
+	defer c.Close()
+	if ok, _ := c.Extension("STARTTLS"); ok {
+		if err := c.StartTLS(&tls.Config{ServerName: host}); err != nil {
+			return err
+		}
+	}
+	if s.Auth != nil {
+		if err := c.Auth(s.Auth); err != nil {
+			return err
+		}
+	}
+	if err := c.Mail(from.Address); err != nil {
+		return err
+	}
+	if err := c.Rcpt(to.Address); err != nil {
+		return err
+	}
+	w, err := c.Data()
+	if err != nil {
+		return err
+	}
+	if _, err := w.Write(format(from, to, msg, time.Now())); err != nil {
+		return err
+	}
+	if err := w.Close(); err != nil {
+		return err
+	}
+	return c.Quit()
+}
+
+// format returns the message's headers and body. The subject is encoded, so
+// that a line break in it cannot add headers.
+func format(from, to *mail.Address, msg Message, date time.Time) []byte {
+	var b strings.Builder
+	fmt.Fprintf(&b, "From: %s\r\n", from)
+	fmt.Fprintf(&b, "To: %s\r\n", to)
+	fmt.Fprintf(&b, "Subject: %s\r\n", mime.QEncoding.Encode("utf-8", msg.Subject))
+	fmt.Fprintf(&b, "Date: %s\r\n", date.Format(time.RFC1123Z))
+	b.WriteString("MIME-Version: 1.0\r\n")
+	b.WriteString("Content-Type: text/plain; charset=utf-8\r\n")
+	b.WriteString("Content-Transfer-Encoding: 8bit\r\n\r\n")
+	// The DATA writer turns the body's line feeds into CRLF.
+	b.WriteString(msg.Body)
+	return []byte(b.String())
+}
+
+// MemorySender keeps the messages sent to it instead of delivering them. It is
+// meant for tests.
+type MemorySender struct {
+	mu       sync.Mutex
+	messages []Message
+}
+
+// Send implements Sender.
+func (m *MemorySender) Send(ctx context.Context, msg Message) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.messages = append(m.messages, msg)
+	return nil
+}
+
+// Messages returns the messages sent so far.
+func (m *MemorySender) Messages() []Message {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	return append([]Message(nil), m.messages...)
+}
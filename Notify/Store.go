@@ -0,0 +1,53 @@
+// Notify/Store.go
+package Notify
+
+import (
+	"complaint-portal/Common"
+	"complaint-portal/Storage"
+	"context"
+)
+
+// NotifyingStore is a Storage.Store that tells a Notifier about the complaints
+// submitted, the status changes and the staff comments written through it.
+type NotifyingStore struct {
+	Storage.Store
+	Notifier *Notifier
+}
+
+// NewNotifyingStore wraps store so that its complaint writes are notified by n.
+func NewNotifyingStore(store Storage.Store, n *Notifier) *NotifyingStore {
+	return &NotifyingStore{Store: store, Notifier: n}
+}
+
+func (s *NotifyingStore) CreateComplaint(ctx context.Context, complaint Common.Complaint) error {
+	if err := s.Store.CreateComplaint(ctx, complaint); err != nil {
+		return err
+	}
+	s.Notifier.Notify(Event{Kind: Submitted, Complaint: complaint})
+	return nil
+}
+
+func (s *NotifyingStore) UpdateComplaint(ctx context.Context, id string, update func(*Common.Complaint) error) (Common.Complaint, error) {
+	// The update may run more than once in a transaction; the status it last
+	// saw is the one replaced.
+	var from Common.ComplaintStatus
+	complaint, err := s.Store.UpdateComplaint(ctx, id, func(c *Common.Complaint) error {
+		from = c.CurrentStatus()
+		return update(c)
+	})
+	if err == nil && complaint.CurrentStatus() != from {
+		s.Notifier.Notify(Event{Kind: StatusChanged, Complaint: complaint, From: from})
+	}
+	return complaint, err
+}
+
+// AddComment notifies staff comments only: customers know what they wrote.
+func (s *NotifyingStore) AddComment(ctx context.Context, comment Common.Comment) error {
+	if err := s.Store.AddComment(ctx, comment); err != nil {
+		return err
+	}
+	if comment.Role == Common.CommentByStaff {
+		s.Notifier.Notify(Event{Kind: Commented, Comment: comment})
+	}
+	return nil
+}
@@ -0,0 +1,105 @@
+// Notify/Templates.go
+package Notify
+
+import (
+	"bytes"
+	"complaint-portal/Common"
+	"fmt"
+	"os"
+	"strings"
+	"text/template"
+)
+
+// Data is what message templates are executed with.
+type Data struct {
+	// User is the complaint's owner, to whom the message is sent.
+	User      Common.User
+	Complaint Common.Complaint
+	// From and To are the previous and current status of the complaint.
+	From, To Common.ComplaintStatus
+	// Note is the note left with the status change, if any.
+	Note string
+	// Comment is the new comment, for Commented.
+	Comment Common.Comment
+}
+
+// defaultTemplates define a subject and a body for every kind, named
+// "<kind>.subject" and "<kind>.body".
+const defaultTemplates = `
+{{define "submitted.subject"}}We received your complaint: {{.Complaint.Title}}{{end}}
+{{define "submitted.body"}}Hello {{.User.Name}},
+
+We received your complaint "{{.Complaint.Title}}" (reference {{.Complaint.ID}}).
+Our team will look into it and keep you posted by email.
+{{end}}
+
+{{define "status_changed.subject"}}Your complaint is now {{.To}}: {{.Complaint.Title}}{{end}}
+{{define "status_changed.body"}}Hello {{.User.Name}},
+
+Your complaint "{{.Complaint.Title}}" (reference {{.Complaint.ID}}) moved from {{.From}} to {{.To}}.
+{{- with .Note}}
+
+Note: {{.}}{{end}}
+{{end}}
+
+{{define "commented.subject"}}New comment on your complaint: {{.Complaint.Title}}{{end}}
+{{define "commented.body"}}Hello {{.User.Name}},
+
+Our team commented on your complaint "{{.Complaint.Title}}" (reference {{.Complaint.ID}}):
+
+{{.Comment.Body}}
+{{end}}
+`
+
+// Templates render notification messages.
+type Templates struct {
+	t *template.Template
+}
+
+// DefaultTemplates returns the built-in templates.
+func DefaultTemplates() *Templates {
+	t, err := ParseTemplates("")
+	if err != nil {
+		panic(err)
+	}
+	return t
+}
+
+// ParseTemplates returns the built-in templates, with those defined in text
+// replacing them. text uses the text/template syntax and defines templates
+// named like the built-in ones, e.g. {{define "submitted.subject"}}...{{end}}.
+func ParseTemplates(text string) (*Templates, error) {
+	t, err := template.New("").Parse(defaultTemplates)
+	if err != nil {
+		return nil, err
+	}
+	if t, err = t.Parse(text); err != nil {
+		return nil, err
+	}
+	return &Templates{t: t}, nil
+}
+
+// LoadTemplates reads templates overriding the built-in ones from a file; see
+// ParseTemplates.
+func LoadTemplates(path string) (*Templates, error) {
+	text, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	return ParseTemplates(string(text))
+}
+
+// Render returns the subject and body of a message of the given kind. The
+// subject is kept on one line.
+func (t *Templates) Render(kind Kind, data Data) (subject, body string, err error) {
+	var buf bytes.Buffer
+	if err := t.t.ExecuteTemplate(&buf, string(kind)+".subject", data); err != nil {
+		return "", "", fmt.Errorf("render %s subject: %w", kind, err)
+	}
+	subject = strings.Join(strings.Fields(buf.String()), " ")
+	buf.Reset()
+	if err := t.t.ExecuteTemplate(&buf, string(kind)+".body", data); err != nil {
+		return "", "", fmt.Errorf("render %s body: %w", kind, err)
+	}
+	return subject, buf.String(), nil
+}
@@ -0,0 +1,253 @@
+// Search/Index.go
+package Search
+
+import (
+	"complaint-portal/Common"
+	"complaint-portal/Storage"
+	"context"
+	"math"
+	"sort"
+	"strings"
+	"sync"
+)
+
+// BM25 parameters: k1 caps how much repeating a term raises the score, and b
+// how much longer complaints are penalised. These are the usual defaults.
+const (
+	bm25K1 = 1.2
+	bm25B  = 0.75
+)
+
+// titleWeight is how many times a word in the title counts, relative to a word
+// in the summary.
+const titleWeight = 2
+
+// Snippets are cut to snippetWords indexed words, starting up to snippetLead
+// words before the densest run of matches, and matches are wrapped in
+// HighlightStart and HighlightEnd.
+const (
+	snippetWords   = 20
+	snippetLead    = 3
+	HighlightStart = "**"
+	HighlightEnd   = "**"
+	ellipsis       = "…"
+)
+
+// Index is an in-memory inverted index of complaint titles and summaries,
+// ranked with BM25. It is safe for concurrent use.
+type Index struct {
+	mu       sync.RWMutex
+	docs     map[string]*document
+	postings map[string]map[string]int // term -> complaint ID -> weighted term frequency
+	totalLen int
+}
+
+// document is an indexed complaint.
+type document struct {
+	complaint Common.Complaint
+	terms     map[string]int
+	length    int
+}
+
+// Query is a search over the index.
+type Query struct {
+	Text string
+	// UserID, when set, keeps only that user's complaints.
+	UserID string
+	// Limit caps the number of results; 0 means no limit.
+	Limit int
+}
+
+// Result is a complaint matching a query.
+type Result struct {
+	Complaint Common.Complaint
+	Score     float64
+	// Snippet is the part of the summary, or of the title when the summary has
+	// no match, around the matched words.
+	Snippet string
+}
+
+// NewIndex returns an empty index.
+func NewIndex() *Index {
+	return &Index{docs: map[string]*document{}, postings: map[string]map[string]int{}}
+}
+
+// Build indexes every complaint in the store and returns how many there are.
+func (x *Index) Build(ctx context.Context, store Storage.Store) (int, error) {
+	complaints, err := store.ListComplaints(ctx)
+	if err != nil {
+		return 0, err
+	}
+	for _, c := range complaints {
+		x.Put(c)
+	}
+	return len(complaints), nil
+}
+
+// Put indexes a complaint, replacing any earlier version of it.
+func (x *Index) Put(c Common.Complaint) {
+	doc := &document{complaint: c, terms: map[string]int{}}
+	for _, t := range Tokenize(c.Title) {
+		doc.terms[t.Term] += titleWeight
+		doc.length += titleWeight
+	}
+	for _, t := range Tokenize(c.Summary) {
+		doc.terms[t.Term]++
+		doc.length++
+	}
+
+	x.mu.Lock()
+	defer x.mu.Unlock()
+	x.remove(c.ID)
+	x.docs[c.ID] = doc
+	x.totalLen += doc.length
+	for term, tf := range doc.terms {
+		if x.postings[term] == nil {
+			x.postings[term] = map[string]int{}
+		}
+		x.postings[term][c.ID] = tf
+	}
+}
+
+// Remove drops a complaint from the index. Unknown IDs are ignored.
+func (x *Index) Remove(id string) {
+	x.mu.Lock()
+	defer x.mu.Unlock()
+	x.remove(id)
+}
+
+// remove drops a complaint; x.mu must be held.
+func (x *Index) remove(id string) {
+	doc, ok := x.docs[id]
+	if !ok {
+		return
+	}
+	for term := range doc.terms {
+		delete(x.postings[term], id)
+		if len(x.postings[term]) == 0 {
+			delete(x.postings, term)
+		}
+	}
+	x.totalLen -= doc.length
+	delete(x.docs, id)
+}
+
+// Len returns the number of indexed complaints.
+func (x *Index) Len() int {
+	x.mu.RLock()
+	defer x.mu.RUnlock()
+	return len(x.docs)
+}
+
+// Search returns the complaints matching any word of the query, best first.
+// Complaints with the same score are returned newest first.
+func (x *Index) Search(q Query) []Result {
+	terms := map[string]bool{}
+	for _, t := range Tokenize(q.Text) {
+		terms[t.Term] = true
+	}
+
+	x.mu.RLock()
+	defer x.mu.RUnlock()
+	if len(x.docs) == 0 {
+		return nil
+	}
+	n := float64(len(x.docs))
+	avgLen := float64(x.totalLen) / n
+
+	scores := map[string]float64{}
+	for term := range terms {
+		postings := x.postings[term]
+		df := float64(len(postings))
+		idf := math.Log(1 + (n-df+0.5)/(df+0.5))
+		for id, tf := range postings {
+			doc := x.docs[id]
+			if q.UserID != "" && doc.complaint.UserID != q.UserID {
+				continue
+			}
+			f := float64(tf)
+			norm := bm25K1 * (1 - bm25B + bm25B*float64(doc.length)/avgLen)
+			scores[id] += idf * f * (bm25K1 + 1) / (f + norm)
+		}
+	}
+
+	results := make([]Result, 0, len(scores))
+	for id, score := range scores {
+		results = append(results, Result{Complaint: x.docs[id].complaint, Score: score})
+	}
+	sort.Slice(results, func(i, j int) bool {
+		a, b := results[i], results[j]
+		if a.Score != b.Score {
+			return a.Score > b.Score
+		}
+		if !a.Complaint.CreatedAt.Equal(b.Complaint.CreatedAt) {
+			return a.Complaint.CreatedAt.After(b.Complaint.CreatedAt)
+		}
+		return a.Complaint.ID < b.Complaint.ID
+	})
+	if q.Limit > 0 && len(results) > q.Limit {
+		results = results[:q.Limit]
+	}
+	for i := range results {
+		c := results[i].Complaint
+		if results[i].Snippet = snippet(c.Summary, terms); results[i].Snippet == "" {
+			results[i].Snippet = snippet(c.Title, terms)
+		}
+	}
+	return results
+}
+
+// snippet returns the part of text with the most matches of terms, with the
+// matched words highlighted, or "" if nothing in text matches.
+func snippet(text string, terms map[string]bool) string {
+	tokens := Tokenize(text)
+	best, bestCount := -1, 0
+	for i := range tokens {
+		if !terms[tokens[i].Term] {
+			continue
+		}
+		count := 0
+		for _, t := range tokens[i:min(i+snippetWords, len(tokens))] {
+			if terms[t.Term] {
+				count++
+			}
+		}
+		if count > bestCount {
+			best, bestCount = i, count
+		}
+	}
+	if best < 0 {
+		return ""
+	}
+
+	first := max(best-snippetLead, 0)
+	last := min(first+snippetWords, len(tokens)) - 1
+	from, to := tokens[first].Start, tokens[last].End
+	if first == 0 {
+		from = 0
+	}
+	if last == len(tokens)-1 {
+		to = len(text)
+	}
+
+	var sb strings.Builder
+	if from > 0 {
+		sb.WriteString(ellipsis)
+	}
+	pos := from
+	for _, t := range tokens[first : last+1] {
+		if !terms[t.Term] {
+			continue
+		}
+		sb.WriteString(text[pos:t.Start])
+		sb.WriteString(HighlightStart)
+		sb.WriteString(text[t.Start:t.End])
+		sb.WriteString(HighlightEnd)
+		pos = t.End
+	}
+	sb.WriteString(text[pos:to])
+	if to < len(text) {
+		sb.WriteString(ellipsis)
+	}
+	return strings.TrimSpace(sb.String())
+}
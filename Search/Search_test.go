@@ -0,0 +1,184 @@
+// Search/Search_test.go
+package Search
+
+import (
+	"complaint-portal/Common"
+	"complaint-portal/Storage"
+	"context"
+	"strings"
+	"testing"
+	"time"
+)
+
+// TestStem checks the stemmer against the Porter algorithm's reference output.
+func TestStem(t *testing.T) {
+	cases := map[string]string{
+		"caresses":       "caress",
+		"ponies":         "poni",
+		"cats":           "cat",
+		"feed":           "feed",
+		"agreed":         "agre",
+		"plastered":      "plaster",
+		"motoring":       "motor",
+		"sing":           "sing",
+		"hopping":        "hop",
+		"falling":        "fall",
+		"filing":         "file",
+		"happy":          "happi",
+		"relational":     "relat",
+		"generalization": "gener",
+		"hopeful":        "hope",
+		"adoption":       "adopt",
+		"controlling":    "control",
+		"charged":        "charg",
+		"charges":        "charg",
+		"charging":       "charg",
+		"is":             "is",
+		"café":           "café",
+	}
+	for word, want := range cases {
+		if got := Stem(word); got != want {
+			t.Errorf("Expected Stem(%q) to be %q, but got %q", word, want, got)
+		}
+	}
+}
+
+// TestTokenize checks that words are split, lower-cased, stemmed and located,
+// and that stop words are left out.
+func TestTokenize(t *testing.T) {
+	text := "The Router keeps dropping; Wi-Fi 5GHz is down!"
+	tokens := Tokenize(text)
+	var terms []string
+	for _, tok := range tokens {
+		terms = append(terms, tok.Term)
+	}
+	if got, want := strings.Join(terms, " "), "router keep drop wi fi 5ghz down"; got != want {
+		t.Errorf("Expected terms %q, but got %q", want, got)
+	}
+	if text[tokens[0].Start:tokens[0].End] != "Router" {
+		t.Errorf("Expected the first token to point at Router, but got %q", text[tokens[0].Start:tokens[0].End])
+	}
+}
+
+// TestIndex tests ranking, filtering, updates and snippets.
+func TestIndex(t *testing.T) {
+	now := time.Now()
+	index := NewIndex()
+	index.Put(Common.Complaint{ID: "c1", UserID: "u1", Title: "Billing error", Summary: "I was charged twice for the same order.", CreatedAt: now})
+	index.Put(Common.Complaint{ID: "c2", UserID: "u2", Title: "Late delivery", Summary: "The parcel arrived a week late and the box was damaged.", CreatedAt: now})
+	index.Put(Common.Complaint{ID: "c3", UserID: "u1", Title: "Damaged parcel", Summary: "Box crushed.", CreatedAt: now})
+
+	// Test case 1: Words match regardless of case and ending
+	results := index.Search(Query{Text: "CHARGES"})
+	if len(results) != 1 || results[0].Complaint.ID != "c1" || results[0].Score <= 0 {
+		t.Fatalf("Expected only c1 to match, but got %+v", results)
+	}
+
+	// Test case 2: A match in the title ranks above one in the summary
+	results = index.Search(Query{Text: "damaged"})
+	if len(results) != 2 || results[0].Complaint.ID != "c3" || results[1].Complaint.ID != "c2" {
+		t.Errorf("Expected c3 then c2, but got %+v", results)
+	}
+
+	// Test case 3: Complaints matching more words rank first
+	results = index.Search(Query{Text: "late damaged parcel"})
+	if len(results) != 2 || results[0].Complaint.ID != "c2" {
+		t.Errorf("Expected c2 first, but got %+v", results)
+	}
+
+	// Test case 4: The user filter and the limit are applied
+	if results := index.Search(Query{Text: "damaged", UserID: "u2"}); len(results) != 1 || results[0].Complaint.ID != "c2" {
+		t.Errorf("Expected only c2 for u2, but got %+v", results)
+	}
+	if results := index.Search(Query{Text: "damaged", Limit: 1}); len(results) != 1 {
+		t.Errorf("Expected 1 result, but got %d", len(results))
+	}
+
+	// Test case 5: Snippets highlight the matches, from the title when the summary has none
+	results = index.Search(Query{Text: "charged"})
+	if got, want := results[0].Snippet, "I was **charged** twice for the same order."; got != want {
+		t.Errorf("Expected snippet %q, but got %q", want, got)
+	}
+	results = index.Search(Query{Text: "billing"})
+	if got, want := results[0].Snippet, "**Billing** error"; got != want {
+		t.Errorf("Expected snippet %q, but got %q", want, got)
+	}
+
+	// Test case 6: Putting a complaint again replaces its words, and removing it drops them
+	index.Put(Common.Complaint{ID: "c1", UserID: "u1", Title: "Refund pending", CreatedAt: now})
+	if results := index.Search(Query{Text: "charged"}); len(results) != 0 {
+		t.Errorf("Expected the old words to be gone, but got %+v", results)
+	}
+	index.Remove("c1")
+	if results := index.Search(Query{Text: "refund"}); len(results) != 0 || index.Len() != 2 {
+		t.Errorf("Expected c1 to be removed, but got %+v and %d complaints", results, index.Len())
+	}
+
+	// Test case 7: Stop words alone match nothing
+	if results := index.Search(Query{Text: "the and"}); len(results) != 0 {
+		t.Errorf("Expected no results, but got %+v", results)
+	}
+}
+
+// TestSnippetWindow checks that long texts are cut around their matches.
+func TestSnippetWindow(t *testing.T) {
+	words := make([]string, 60)
+	for i := range words {
+		words[i] = "filler"
+	}
+	words[40] = "refund"
+	got := snippet(strings.Join(words, " "), map[string]bool{"refund": true})
+	if !strings.HasPrefix(got, ellipsis+"filler filler filler **refund**") || !strings.HasSuffix(got, ellipsis) {
+		t.Errorf("Expected a cut snippet starting 3 words before the match, but got %q", got)
+	}
+	if n := len(strings.Fields(got)); n != snippetWords {
+		t.Errorf("Expected %d words, but got %d", snippetWords, n)
+	}
+}
+
+// TestIndexedStore checks that complaints written through the store are indexed.
+func TestIndexedStore(t *testing.T) {
+	ctx := context.Background()
+	memory := Storage.NewMemoryStore()
+	memory.Reset()
+	if err := memory.CreateUser(ctx, Common.User{ID: "u1", Name: "Ada", Email: "ada@example.com"}); err != nil {
+		t.Fatalf("Failed to create user: %v", err)
+	}
+	if err := memory.CreateComplaint(ctx, Common.Complaint{ID: "c0", UserID: "u1", Title: "Existing outage"}); err != nil {
+		t.Fatalf("Failed to create complaint: %v", err)
+	}
+
+	index := NewIndex()
+	store := NewIndexedStore(memory, index)
+
+	// Test case 1: Build indexes what is already stored
+	if n, err := index.Build(ctx, store); err != nil || n != 1 {
+		t.Fatalf("Expected to index 1 complaint, but got %d (err: %v)", n, err)
+	}
+
+	// Test case 2: New and updated complaints are indexed
+	if err := store.CreateComplaint(ctx, Common.Complaint{ID: "c1", UserID: "u1", Title: "Noisy fan"}); err != nil {
+		t.Fatalf("Failed to create complaint: %v", err)
+	}
+	if results := index.Search(Query{Text: "fan"}); len(results) != 1 {
+		t.Errorf("Expected the new complaint to be found, but got %+v", results)
+	}
+	_, err := store.UpdateComplaint(ctx, "c1", func(c *Common.Complaint) error {
+		c.Resolved = true
+		return nil
+	})
+	if err != nil {
+		t.Fatalf("Failed to update complaint: %v", err)
+	}
+	if results := index.Search(Query{Text: "fan"}); len(results) != 1 || !results[0].Complaint.Resolved {
+		t.Errorf("Expected the updated complaint, but got %+v", results)
+	}
+
+	// Test case 3: Failed writes are not indexed
+	if err := store.CreateComplaint(ctx, Common.Complaint{ID: "c2", UserID: "nobody", Title: "Ghost"}); err == nil {
+		t.Fatalf("Expected an error for a missing owner")
+	}
+	if results := index.Search(Query{Text: "ghost"}); len(results) != 0 {
+		t.Errorf("Expected the failed complaint not to be indexed, but got %+v", results)
+	}
+}
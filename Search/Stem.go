@@ -0,0 +1,225 @@
+// Search/Stem.go
+package Search
+
+// Stem reduces an English word to its stem with the Porter algorithm, so that
+// "charged", "charges" and "charging" are all indexed as "charg". The word must
+// be lower case; words of two letters or less and words with letters outside
+// a-z are returned unchanged.
+func Stem(word string) string {
+	if len(word) <= 2 {
+		return word
+	}
+	for i := 0; i < len(word); i++ {
+		if word[i] < 'a' || word[i] > 'z' {
+			return word
+		}
+	}
+	z := &stemmer{b: []byte(word), k: len(word) - 1}
+	z.step1ab()
+	if z.k > 0 {
+		z.step1c()
+		z.step2()
+		z.step3()
+		z.step4()
+		z.step5()
+	}
+	return string(z.b[:z.k+1])
+}
+
+// stemmer holds a word being stemmed. b[:k+1] is the current word and, after a
+// successful call to ends, b[:j+1] is the part before the matched suffix.
+type stemmer struct {
+	b    []byte
+	k, j int
+}
+
+// cons reports whether b[i] is a consonant. A 'y' is one unless it follows a consonant.
+func (z *stemmer) cons(i int) bool {
+	switch z.b[i] {
+	case 'a', 'e', 'i', 'o', 'u':
+		return false
+	case 'y':
+		return i == 0 || !z.cons(i-1)
+	}
+	return true
+}
+
+// m counts the vowel-consonant sequences in b[:j+1], the m of [C](VC)^m[V].
+func (z *stemmer) m() int {
+	n, i := 0, 0
+	for ; i <= z.j && z.cons(i); i++ {
+	}
+	for {
+		for ; i <= z.j && !z.cons(i); i++ {
+		}
+		if i > z.j {
+			return n
+		}
+		for ; i <= z.j && z.cons(i); i++ {
+		}
+		n++
+	}
+}
+
+// vowelInStem reports whether b[:j+1] contains a vowel.
+func (z *stemmer) vowelInStem() bool {
+	for i := 0; i <= z.j; i++ {
+		if !z.cons(i) {
+			return true
+		}
+	}
+	return false
+}
+
+// doubleC reports whether b[i-1:i+1] is a double consonant.
+func (z *stemmer) doubleC(i int) bool {
+	return i >= 1 && z.b[i] == z.b[i-1] && z.cons(i)
+}
+
+// cvc reports whether b[i-2:i+1] is consonant-vowel-consonant and the last
+// consonant is not w, x or y, as in "hop" but not "snow".
+func (z *stemmer) cvc(i int) bool {
+	if i < 2 || !z.cons(i) || z.cons(i-1) || !z.cons(i-2) {
+		return false
+	}
+	ch := z.b[i]
+	return ch != 'w' && ch != 'x' && ch != 'y'
+}
+
+// ends reports whether the word ends with s, and if so sets j before it.
+func (z *stemmer) ends(s string) bool {
+	n := len(s)
+	if n > z.k+1 || string(z.b[z.k-n+1:z.k+1]) != s {
+		return false
+	}
+	z.j = z.k - n
+	return true
+}
+
+// setTo replaces b[j+1:k+1] with s.
+func (z *stemmer) setTo(s string) {
+	z.b = append(z.b[:z.j+1], s...)
+	z.k = z.j + len(s)
+}
+
+// replace replaces the suffix matched by ends with s if the stem before it has m > 0.
+func (z *stemmer) replace(s string) {
+	if z.m() > 0 {
+		z.setTo(s)
+	}
+}
+
+// step1ab removes plurals and -ed or -ing.
+func (z *stemmer) step1ab() {
+	if z.b[z.k] == 's' {
+		switch {
+		case z.ends("sses"):
+			z.k -= 2
+		case z.ends("ies"):
+			z.setTo("i")
+		case z.b[z.k-1] != 's':
+			z.k--
+		}
+	}
+	if z.ends("eed") {
+		if z.m() > 0 {
+			z.k--
+		}
+	} else if (z.ends("ed") || z.ends("ing")) && z.vowelInStem() {
+		z.k = z.j
+		switch {
+		case z.ends("at"):
+			z.setTo("ate")
+		case z.ends("bl"):
+			z.setTo("ble")
+		case z.ends("iz"):
+			z.setTo("ize")
+		case z.doubleC(z.k):
+			if ch := z.b[z.k]; ch != 'l' && ch != 's' && ch != 'z' {
+				z.k--
+			}
+		default:
+			z.j = z.k
+			if z.m() == 1 && z.cvc(z.k) {
+				z.setTo("e")
+			}
+		}
+	}
+}
+
+// step1c turns a final y into i when there is another vowel in the stem.
+func (z *stemmer) step1c() {
+	if z.ends("y") && z.vowelInStem() {
+		z.b[z.k] = 'i'
+	}
+}
+
+// step2Suffixes maps double suffixes to single ones. A word can end with at
+// most one of them, except where the longer one is listed first.
+var step2Suffixes = [][2]string{
+	{"ational", "ate"}, {"tional", "tion"}, {"enci", "ence"}, {"anci", "ance"},
+	{"izer", "ize"}, {"bli", "ble"}, {"alli", "al"}, {"entli", "ent"},
+	{"eli", "e"}, {"ousli", "ous"}, {"ization", "ize"}, {"ation", "ate"},
+	{"ator", "ate"}, {"alism", "al"}, {"iveness", "ive"}, {"fulness", "ful"},
+	{"ousness", "ous"}, {"aliti", "al"}, {"iviti", "ive"}, {"biliti", "ble"},
+	{"logi", "log"},
+}
+
+// step3Suffixes handles -ic-, -full, -ness and the like.
+var step3Suffixes = [][2]string{
+	{"icate", "ic"}, {"ative", ""}, {"alize", "al"}, {"iciti", "ic"},
+	{"ical", "ic"}, {"ful", ""}, {"ness", ""},
+}
+
+// step4Suffixes are removed when the stem before them has m > 1.
+var step4Suffixes = []string{
+	"al", "ance", "ence", "er", "ic", "able", "ible", "ant", "ement", "ment",
+	"ent", "ion", "ou", "ism", "ate", "iti", "ous", "ive", "ize",
+}
+
+func (z *stemmer) step2() {
+	z.replaceFirst(step2Suffixes)
+}
+
+func (z *stemmer) step3() {
+	z.replaceFirst(step3Suffixes)
+}
+
+// replaceFirst applies the first of the suffixes the word ends with.
+func (z *stemmer) replaceFirst(suffixes [][2]string) {
+	for _, s := range suffixes {
+		if z.ends(s[0]) {
+			z.replace(s[1])
+			return
+		}
+	}
+}
+
+func (z *stemmer) step4() {
+	for _, s := range step4Suffixes {
+		if !z.ends(s) {
+			continue
+		}
+		// -ion is only a suffix after s or t, as in "adoption" but not "onion".
+		if s == "ion" && (z.j < 0 || (z.b[z.j] != 's' && z.b[z.j] != 't')) {
+			continue
+		}
+		if z.m() > 1 {
+			z.k = z.j
+		}
+		return
+	}
+}
+
+// step5 removes a final e and turns a final ll into l, in long enough words.
+func (z *stemmer) step5() {
+	z.j = z.k
+	if z.b[z.k] == 'e' {
+		if a := z.m(); a > 1 || (a == 1 && !z.cvc(z.k-1)) {
+			z.k--
+		}
+	}
+	if z.b[z.k] == 'l' && z.doubleC(z.k) && z.m() > 1 {
+		z.k--
+	}
+}
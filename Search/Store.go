@@ -0,0 +1,37 @@
+// Search/Store.go
+package Search
+
+import (
+	"complaint-portal/Common"
+	"complaint-portal/Storage"
+	"context"
+)
+
+// IndexedStore is a Storage.Store that keeps an Index up to date with every
+// complaint created or updated through it. Writes made to the underlying store
+// directly, or by other server instances, are only picked up by Index.Build.
+type IndexedStore struct {
+	Storage.Store
+	Index *Index
+}
+
+// NewIndexedStore wraps store so that its complaint writes are indexed in index.
+func NewIndexedStore(store Storage.Store, index *Index) *IndexedStore {
+	return &IndexedStore{Store: store, Index: index}
+}
+
+func (s *IndexedStore) CreateComplaint(ctx context.Context, complaint Common.Complaint) error {
+	if err := s.Store.CreateComplaint(ctx, complaint); err != nil {
+		return err
+	}
+	s.Index.Put(complaint)
+	return nil
+}
+
+func (s *IndexedStore) UpdateComplaint(ctx context.Context, id string, update func(*Common.Complaint) error) (Common.Complaint, error) {
+	complaint, err := s.Store.UpdateComplaint(ctx, id, update)
+	if err == nil {
+		s.Index.Put(complaint)
+	}
+	return complaint, err
+}
@@ -0,0 +1,52 @@
+// Search/Tokenize.go
+package Search
+
+import (
+	"strings"
+	"unicode"
+	"unicode/utf8"
+)
+
+// Token is a word of a text as it is indexed.
+type Token struct {
+	// Term is the lower-cased, stemmed word.
+	Term string
+	// Start and End are the byte offsets of the word in the original text.
+	Start, End int
+}
+
+// stopWords are common English words left out of the index: they match most
+// complaints and would only add noise to the ranking.
+var stopWords = map[string]bool{
+	"a": true, "an": true, "and": true, "are": true, "as": true, "at": true,
+	"be": true, "but": true, "by": true, "for": true, "from": true, "has": true,
+	"have": true, "i": true, "in": true, "is": true, "it": true, "its": true,
+	"my": true, "no": true, "not": true, "of": true, "on": true, "or": true,
+	"so": true, "that": true, "the": true, "their": true, "then": true,
+	"there": true, "these": true, "they": true, "this": true, "to": true,
+	"was": true, "we": true, "were": true, "will": true, "with": true,
+}
+
+// Tokenize splits text into words at every character that is not a letter or
+// a digit, and returns those that are not stop words, stemmed.
+func Tokenize(text string) []Token {
+	var tokens []Token
+	start := -1
+	for i := 0; i <= len(text); {
+		r, size := utf8.RuneError, 1
+		if i < len(text) {
+			r, size = utf8.DecodeRuneInString(text[i:])
+		}
+		word := i < len(text) && (unicode.IsLetter(r) || unicode.IsDigit(r))
+		if word && start < 0 {
+			start = i
+		} else if !word && start >= 0 {
+			if lower := strings.ToLower(text[start:i]); !stopWords[lower] {
+				tokens = append(tokens, Token{Term: Stem(lower), Start: start, End: i})
+			}
+			start = -1
+		}
+		i += size
+	}
+	return tokens
+}
@@ -0,0 +1,638 @@
+// Storage/Firestore.go
+package Storage
+
+import (
+	"complaint-portal/Common"
+	"context"
+
+	"cloud.google.com/go/firestore"
+	"google.golang.org/api/iterator"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// FirestoreStore is a Store backed by Google Cloud Firestore.
+// Users and complaints are kept in their own collections, keyed by their IDs.
+type FirestoreStore struct {
+	client      *firestore.Client
+	collections Collections
+}
+
+// Collections names the Firestore collections a FirestoreStore keeps its
+// documents in, so that several deployments can share a project.
+type Collections struct {
+	Users      string
+	Complaints string
+	// Comments is a subcollection of each complaint document.
+	Comments string
+	Audit    string
+	Webhooks string
+	// Deliveries holds the deliveries of every webhook, which outlive it.
+	Deliveries string
+}
+
+// DefaultCollections are the collections used unless configured otherwise.
+var DefaultCollections = Collections{
+	Users:      Common.UsersCollection,
+	Complaints: Common.ComplaintsCollection,
+	Comments:   Common.CommentsCollection,
+	Audit:      Common.AuditCollection,
+	Webhooks:   Common.WebhooksCollection,
+	Deliveries: Common.DeliveriesCollection,
+}
+
+// NewFirestoreStore returns a Store that uses the given Firestore client and
+// collections.
+func NewFirestoreStore(client *firestore.Client, collections Collections) *FirestoreStore {
+	return &FirestoreStore{client: client, collections: collections}
+}
+
+func (f *FirestoreStore) users() *firestore.CollectionRef {
+	return f.client.Collection(f.collections.Users)
+}
+
+func (f *FirestoreStore) complaints() *firestore.CollectionRef {
+	return f.client.Collection(f.collections.Complaints)
+}
+
+// CreateUser uses the user's ID as the document ID in Firestore.
+func (f *FirestoreStore) CreateUser(ctx context.Context, user Common.User) error {
+	_, err := f.users().Doc(user.ID).Set(ctx, user)
+	return err
+}
+
+func (f *FirestoreStore) GetUser(ctx context.Context, id string) (Common.User, error) {
+	var user Common.User
+	doc, err := f.users().Doc(id).Get(ctx)
+	if err != nil {
+		return user, firestoreError(err)
+	}
+	err = doc.DataTo(&user)
+	return user, err
+}
+
+// GetUsers fetches every user in a single batched read.
+func (f *FirestoreStore) GetUsers(ctx context.Context, ids []string) (map[string]Common.User, error) {
+	result := make(map[string]Common.User, len(ids))
+	if len(ids) == 0 {
+		return result, nil
+	}
+	refs := make([]*firestore.DocumentRef, len(ids))
+	for i, id := range ids {
+		refs[i] = f.users().Doc(id)
+	}
+	docs, err := f.client.GetAll(ctx, refs)
+	if err != nil {
+		return nil, err
+	}
+	for _, doc := range docs {
+		if !doc.Exists() {
+			continue
+		}
+		var user Common.User
+		if err := doc.DataTo(&user); err != nil {
+			return nil, err
+		}
+		result[doc.Ref.ID] = user
+	}
+	return result, nil
+}
+
+func (f *FirestoreStore) FindUserByEmail(ctx context.Context, email string) (Common.User, error) {
+	return f.findUser(ctx, "Email", email)
+}
+
+func (f *FirestoreStore) FindUserBySecretHash(ctx context.Context, secretHash string) (Common.User, error) {
+	return f.findUser(ctx, "SecretCode", secretHash)
+}
+
+// findUser returns the first user whose field equals value.
+func (f *FirestoreStore) findUser(ctx context.Context, field string, value interface{}) (Common.User, error) {
+	var user Common.User
+	iter := f.users().Where(field, "==", value).Limit(1).Documents(ctx)
+	defer iter.Stop()
+	doc, err := iter.Next()
+	if err == iterator.Done {
+		return user, ErrNotFound
+	}
+	if err != nil {
+		return user, err
+	}
+	err = doc.DataTo(&user)
+	return user, err
+}
+
+func (f *FirestoreStore) ListUsers(ctx context.Context) ([]Common.User, error) {
+	iter := f.users().Documents(ctx)
+	defer iter.Stop()
+	var result []Common.User
+	for {
+		doc, err := iter.Next()
+		if err == iterator.Done {
+			return result, nil
+		}
+		if err != nil {
+			return nil, err
+		}
+		var u Common.User
+		if err := doc.DataTo(&u); err != nil {
+			return nil, err
+		}
+		result = append(result, u)
+	}
+}
+
+func (f *FirestoreStore) SetUserComplaints(ctx context.Context, userID string, complaintIDs []string) error {
+	_, err := f.users().Doc(userID).Update(ctx, []firestore.Update{
+		{Path: "Complaints", Value: complaintIDs},
+	})
+	return firestoreError(err)
+}
+
+func (f *FirestoreStore) SetUserSecretHash(ctx context.Context, userID string, secretHash string) error {
+	_, err := f.users().Doc(userID).Update(ctx, []firestore.Update{
+		{Path: "SecretCode", Value: secretHash},
+	})
+	return firestoreError(err)
+}
+
+func (f *FirestoreStore) UpdateUser(ctx context.Context, id string, update func(*Common.User) error) (Common.User, error) {
+	ref := f.users().Doc(id)
+	var user Common.User
+	err := f.client.RunTransaction(ctx, func(ctx context.Context, tx *firestore.Transaction) error {
+		doc, err := tx.Get(ref)
+		if err != nil {
+			return err
+		}
+		user = Common.User{}
+		if err := doc.DataTo(&user); err != nil {
+			return err
+		}
+		if err := update(&user); err != nil {
+			return err
+		}
+		return tx.Set(ref, user)
+	})
+	return user, firestoreError(err)
+}
+
+// CreateComplaint writes the complaint document and updates the owner's Complaints
+// array in one transaction, so a failure can no longer leave an orphan complaint behind.
+func (f *FirestoreStore) CreateComplaint(ctx context.Context, complaint Common.Complaint) error {
+	userRef := f.users().Doc(complaint.UserID)
+	complaintRef := f.complaints().Doc(complaint.ID)
+	err := f.client.RunTransaction(ctx, func(ctx context.Context, tx *firestore.Transaction) error {
+		// Reads must come before writes in a Firestore transaction.
+		if _, err := tx.Get(userRef); err != nil {
+			return err
+		}
+		if err := tx.Create(complaintRef, complaint); err != nil {
+			return err
+		}
+		return tx.Update(userRef, []firestore.Update{
+			{Path: "Complaints", Value: firestore.ArrayUnion(complaint.ID)},
+		})
+	})
+	return firestoreError(err)
+}
+
+func (f *FirestoreStore) GetComplaint(ctx context.Context, id string) (Common.Complaint, error) {
+	var complaint Common.Complaint
+	doc, err := f.complaints().Doc(id).Get(ctx)
+	if err != nil {
+		return complaint, firestoreError(err)
+	}
+	err = doc.DataTo(&complaint)
+	return complaint, err
+}
+
+func (f *FirestoreStore) ListComplaints(ctx context.Context) ([]Common.Complaint, error) {
+	return readComplaints(f.complaints().Documents(ctx))
+}
+
+func (f *FirestoreStore) ListUserComplaints(ctx context.Context, userID string) ([]Common.Complaint, error) {
+	return readComplaints(f.complaints().Where("UserID", "==", userID).Documents(ctx))
+}
+
+// QueryComplaints pages with Firestore query cursors, ordering by the sort key and
+// then by document ID. Combining filters with a sort needs the matching composite
+// indexes. Complaints written before the status lifecycle only have a Status
+// field once BackfillStatuses has run.
+func (f *FirestoreStore) QueryComplaints(ctx context.Context, q ComplaintQuery) ([]Common.Complaint, error) {
+	query := f.complaints().Query
+	if q.UserID != "" {
+		query = query.Where("UserID", "==", q.UserID)
+	}
+	if q.MinSeverity != 0 {
+		query = query.Where("Severity", ">=", q.MinSeverity)
+	}
+	if q.MaxSeverity != 0 {
+		query = query.Where("Severity", "<=", q.MaxSeverity)
+	}
+	if len(q.Statuses) > 0 {
+		query = query.Where("Status", "in", q.Statuses)
+	}
+	if q.Resolved != nil {
+		query = query.Where("Resolved", "==", *q.Resolved)
+	}
+	if !q.CreatedSince.IsZero() {
+		query = query.Where("CreatedAt", ">=", q.CreatedSince)
+	}
+	if !q.CreatedUntil.IsZero() {
+		query = query.Where("CreatedAt", "<", q.CreatedUntil)
+	}
+
+	direction := firestore.Asc
+	if q.Sort.descending() {
+		direction = firestore.Desc
+	}
+	field := "CreatedAt"
+	if q.Sort.bySeverity() {
+		field = "Severity"
+	}
+	query = query.OrderBy(field, direction).OrderBy(firestore.DocumentID, direction)
+	if q.After != nil {
+		var value interface{} = q.After.CreatedAt
+		if q.Sort.bySeverity() {
+			value = q.After.Severity
+		}
+		query = query.StartAfter(value, q.After.ID)
+	}
+	if q.Limit > 0 {
+		query = query.Limit(q.Limit)
+	}
+	return readComplaints(query.Documents(ctx))
+}
+
+// UpdateComplaint runs the read-modify-write in a Firestore transaction, which is
+// retried automatically if the document changes concurrently.
+func (f *FirestoreStore) UpdateComplaint(ctx context.Context, id string, update func(*Common.Complaint) error) (Common.Complaint, error) {
+	ref := f.complaints().Doc(id)
+	var complaint Common.Complaint
+	err := f.client.RunTransaction(ctx, func(ctx context.Context, tx *firestore.Transaction) error {
+		doc, err := tx.Get(ref)
+		if err != nil {
+			return err
+		}
+		complaint = Common.Complaint{}
+		if err := doc.DataTo(&complaint); err != nil {
+			return err
+		}
+		if err := update(&complaint); err != nil {
+			return err
+		}
+		return tx.Set(ref, complaint)
+	})
+	return complaint, firestoreError(err)
+}
+
+// AddComment stores the comment in the complaint's comments subcollection.
+// The complaint is read in the same transaction so that comments cannot be added
+// to a complaint that does not exist.
+func (f *FirestoreStore) AddComment(ctx context.Context, comment Common.Comment) error {
+	complaintRef := f.complaints().Doc(comment.ComplaintID)
+	commentRef := complaintRef.Collection(f.collections.Comments).Doc(comment.ID)
+	err := f.client.RunTransaction(ctx, func(ctx context.Context, tx *firestore.Transaction) error {
+		if _, err := tx.Get(complaintRef); err != nil {
+			return err
+		}
+		return tx.Create(commentRef, comment)
+	})
+	return firestoreError(err)
+}
+
+func (f *FirestoreStore) ListComments(ctx context.Context, complaintID string) ([]Common.Comment, error) {
+	iter := f.complaints().Doc(complaintID).Collection(f.collections.Comments).OrderBy("CreatedAt", firestore.Asc).Documents(ctx)
+	defer iter.Stop()
+	var result []Common.Comment
+	for {
+		doc, err := iter.Next()
+		if err == iterator.Done {
+			return result, nil
+		}
+		if err != nil {
+			return nil, err
+		}
+		var c Common.Comment
+		if err := doc.DataTo(&c); err != nil {
+			return nil, err
+		}
+		result = append(result, c)
+	}
+}
+
+// BackfillTimestamps sets CreatedAt and UpdatedAt on the users and complaints
+// written before they were recorded, from the create and update times Firestore
+// keeps for every document. Resolved complaints also get a ResolvedAt: the time of
+// their last move to Resolved or Closed, or their update time if they have no
+// history. Documents that already have a CreatedAt are skipped, so it is safe to
+// run on every start. It returns how many documents were updated.
+func (f *FirestoreStore) BackfillTimestamps(ctx context.Context) (int, error) {
+	updated := 0
+	users, err := f.users().Documents(ctx).GetAll()
+	if err != nil {
+		return updated, err
+	}
+	for _, doc := range users {
+		var user Common.User
+		if err := doc.DataTo(&user); err != nil {
+			return updated, err
+		}
+		if !user.CreatedAt.IsZero() {
+			continue
+		}
+		if _, err := doc.Ref.Update(ctx, []firestore.Update{
+			{Path: "CreatedAt", Value: doc.CreateTime.UTC()},
+			{Path: "UpdatedAt", Value: doc.UpdateTime.UTC()},
+		}); err != nil {
+			return updated, err
+		}
+		updated++
+	}
+
+	complaints, err := f.complaints().Documents(ctx).GetAll()
+	if err != nil {
+		return updated, err
+	}
+	for _, doc := range complaints {
+		var complaint Common.Complaint
+		if err := doc.DataTo(&complaint); err != nil {
+			return updated, err
+		}
+		if !complaint.CreatedAt.IsZero() {
+			continue
+		}
+		updates := []firestore.Update{
+			{Path: "CreatedAt", Value: doc.CreateTime.UTC()},
+			{Path: "UpdatedAt", Value: doc.UpdateTime.UTC()},
+		}
+		if complaint.Resolved && complaint.ResolvedAt.IsZero() {
+			resolvedAt := doc.UpdateTime.UTC()
+			for _, change := range complaint.StatusHistory {
+				if change.To == Common.StatusResolved || change.To == Common.StatusClosed {
+					resolvedAt = change.ChangedAt
+				}
+			}
+			updates = append(updates, firestore.Update{Path: "ResolvedAt", Value: resolvedAt})
+		}
+		if _, err := doc.Ref.Update(ctx, updates); err != nil {
+			return updated, err
+		}
+		updated++
+	}
+	return updated, nil
+}
+
+// BackfillStatuses sets Status on the complaints written before the status
+// lifecycle, from their Resolved flag as CurrentStatus reads it, so that status
+// filters find them as the other backends do. Complaints that have a Status are
+// skipped, so it is safe to run on every start. It returns how many complaints
+// were updated.
+func (f *FirestoreStore) BackfillStatuses(ctx context.Context) (int, error) {
+	updated := 0
+	complaints, err := f.complaints().Documents(ctx).GetAll()
+	if err != nil {
+		return updated, err
+	}
+	for _, doc := range complaints {
+		var complaint Common.Complaint
+		if err := doc.DataTo(&complaint); err != nil {
+			return updated, err
+		}
+		if complaint.Status != "" {
+			continue
+		}
+		if _, err := doc.Ref.Update(ctx, []firestore.Update{{Path: "Status", Value: complaint.CurrentStatus()}}); err != nil {
+			return updated, err
+		}
+		updated++
+	}
+	return updated, nil
+}
+
+// Ping reads a document that does not exist: getting NotFound back shows that
+// Firestore is reachable and the credentials are accepted.
+func (f *FirestoreStore) Ping(ctx context.Context) error {
+	_, err := f.client.Collection(f.collections.Users).Doc(pingDocument).Get(ctx)
+	if err == nil || status.Code(err) == codes.NotFound {
+		return nil
+	}
+	return err
+}
+
+// pingDocument is the ID read by Ping. User IDs are hex, so no user has it.
+const pingDocument = "_ping"
+
+// Close closes the underlying Firestore client.
+func (f *FirestoreStore) Close() error {
+	return f.client.Close()
+}
+
+func (f *FirestoreStore) AddAuditEntry(ctx context.Context, entry Common.AuditEntry) error {
+	_, err := f.client.Collection(f.collections.Audit).Doc(entry.ID).Create(ctx, entry)
+	return err
+}
+
+// ListAuditEntries needs composite indexes on ActorID and At, and on TargetID and At,
+// when filtering by actor or target.
+func (f *FirestoreStore) ListAuditEntries(ctx context.Context, filter AuditFilter) ([]Common.AuditEntry, error) {
+	query := f.client.Collection(f.collections.Audit).Query
+	if filter.ActorID != "" {
+		query = query.Where("ActorID", "==", filter.ActorID)
+	}
+	if filter.TargetID != "" {
+		query = query.Where("TargetID", "==", filter.TargetID)
+	}
+	if !filter.Since.IsZero() {
+		query = query.Where("At", ">=", filter.Since)
+	}
+	if !filter.Until.IsZero() {
+		query = query.Where("At", "<", filter.Until)
+	}
+	iter := query.OrderBy("At", firestore.Asc).Documents(ctx)
+	defer iter.Stop()
+	var result []Common.AuditEntry
+	for {
+		doc, err := iter.Next()
+		if err == iterator.Done {
+			return result, nil
+		}
+		if err != nil {
+			return nil, err
+		}
+		var entry Common.AuditEntry
+		if err := doc.DataTo(&entry); err != nil {
+			return nil, err
+		}
+		result = append(result, entry)
+	}
+}
+
+func (f *FirestoreStore) CreateWebhook(ctx context.Context, webhook Common.Webhook) error {
+	_, err := f.client.Collection(f.collections.Webhooks).Doc(webhook.ID).Create(ctx, webhook)
+	return err
+}
+
+func (f *FirestoreStore) GetWebhook(ctx context.Context, id string) (Common.Webhook, error) {
+	var webhook Common.Webhook
+	doc, err := f.client.Collection(f.collections.Webhooks).Doc(id).Get(ctx)
+	if err != nil {
+		return webhook, firestoreError(err)
+	}
+	err = doc.DataTo(&webhook)
+	return webhook, err
+}
+
+func (f *FirestoreStore) ListWebhooks(ctx context.Context) ([]Common.Webhook, error) {
+	iter := f.client.Collection(f.collections.Webhooks).OrderBy("CreatedAt", firestore.Asc).Documents(ctx)
+	defer iter.Stop()
+	var result []Common.Webhook
+	for {
+		doc, err := iter.Next()
+		if err == iterator.Done {
+			return result, nil
+		}
+		if err != nil {
+			return nil, err
+		}
+		var webhook Common.Webhook
+		if err := doc.DataTo(&webhook); err != nil {
+			return nil, err
+		}
+		result = append(result, webhook)
+	}
+}
+
+func (f *FirestoreStore) DeleteWebhook(ctx context.Context, id string) error {
+	_, err := f.client.Collection(f.collections.Webhooks).Doc(id).Delete(ctx, firestore.Exists)
+	return firestoreError(err)
+}
+
+func (f *FirestoreStore) AddWebhookDelivery(ctx context.Context, delivery Common.WebhookDelivery) error {
+	_, err := f.client.Collection(f.collections.Deliveries).Doc(delivery.ID).Create(ctx, delivery)
+	return err
+}
+
+func (f *FirestoreStore) GetWebhookDelivery(ctx context.Context, id string) (Common.WebhookDelivery, error) {
+	var delivery Common.WebhookDelivery
+	doc, err := f.client.Collection(f.collections.Deliveries).Doc(id).Get(ctx)
+	if err != nil {
+		return delivery, firestoreError(err)
+	}
+	err = doc.DataTo(&delivery)
+	return delivery, err
+}
+
+func (f *FirestoreStore) UpdateWebhookDelivery(ctx context.Context, id string, update func(*Common.WebhookDelivery) error) (Common.WebhookDelivery, error) {
+	ref := f.client.Collection(f.collections.Deliveries).Doc(id)
+	var delivery Common.WebhookDelivery
+	err := f.client.RunTransaction(ctx, func(ctx context.Context, tx *firestore.Transaction) error {
+		doc, err := tx.Get(ref)
+		if err != nil {
+			return err
+		}
+		delivery = Common.WebhookDelivery{}
+		if err := doc.DataTo(&delivery); err != nil {
+			return err
+		}
+		if err := update(&delivery); err != nil {
+			return err
+		}
+		return tx.Set(ref, delivery)
+	})
+	return delivery, firestoreError(err)
+}
+
+// ListWebhookDeliveries needs composite indexes on WebhookID and CreatedAt, and
+// on Status and CreatedAt, when filtering by webhook or status.
+func (f *FirestoreStore) ListWebhookDeliveries(ctx context.Context, filter DeliveryFilter) ([]Common.WebhookDelivery, error) {
+	query := f.client.Collection(f.collections.Deliveries).Query
+	if filter.WebhookID != "" {
+		query = query.Where("WebhookID", "==", filter.WebhookID)
+	}
+	if filter.Status != "" {
+		query = query.Where("Status", "==", filter.Status)
+	}
+	query = query.OrderBy("CreatedAt", firestore.Desc).OrderBy(firestore.DocumentID, firestore.Desc)
+	if filter.Limit > 0 {
+		query = query.Limit(filter.Limit)
+	}
+	iter := query.Documents(ctx)
+	defer iter.Stop()
+	var result []Common.WebhookDelivery
+	for {
+		doc, err := iter.Next()
+		if err == iterator.Done {
+			return result, nil
+		}
+		if err != nil {
+			return nil, err
+		}
+		var delivery Common.WebhookDelivery
+		if err := doc.DataTo(&delivery); err != nil {
+			return nil, err
+		}
+		result = append(result, delivery)
+	}
+}
+
+// WatchComplaints listens to the complaints collection and calls fn for every
+// complaint added or modified, including by other servers, with created set for
+// new ones. Complaints existing when it starts are not reported. It blocks until
+// ctx is done, returning nil, or until the listener fails.
+func (f *FirestoreStore) WatchComplaints(ctx context.Context, fn func(complaint Common.Complaint, created bool)) error {
+	it := f.complaints().Snapshots(ctx)
+	defer it.Stop()
+	initial := true
+	for {
+		snap, err := it.Next()
+		if ctx.Err() != nil {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+		if initial {
+			initial = false
+			continue
+		}
+		for _, change := range snap.Changes {
+			if change.Kind == firestore.DocumentRemoved {
+				continue
+			}
+			var c Common.Complaint
+			if err := change.Doc.DataTo(&c); err != nil {
+				return err
+			}
+			fn(c, change.Kind == firestore.DocumentAdded)
+		}
+	}
+}
+
+// readComplaints drains the iterator into a slice of complaints.
+func readComplaints(iter *firestore.DocumentIterator) ([]Common.Complaint, error) {
+	defer iter.Stop()
+	var result []Common.Complaint
+	for {
+		doc, err := iter.Next()
+		if err == iterator.Done {
+			return result, nil
+		}
+		if err != nil {
+			return nil, err
+		}
+		var c Common.Complaint
+		if err := doc.DataTo(&c); err != nil {
+			return nil, err
+		}
+		result = append(result, c)
+	}
+}
+
+// firestoreError translates Firestore's NotFound status into ErrNotFound.
+func firestoreError(err error) error {
+	if status.Code(err) == codes.NotFound {
+		return ErrNotFound
+	}
+	return err
+}
@@ -0,0 +1,370 @@
+// Storage/Memory.go
+package Storage
+
+import (
+	"complaint-portal/Common"
+	"context"
+	"sort"
+)
+
+// MemoryStore is a Store that keeps everything in the Common.Users and
+// Common.Complaints maps, guarded by Common.Mu. Data is lost when the process exits,
+// which makes it suitable for local development and tests.
+type MemoryStore struct{}
+
+// NewMemoryStore returns a Store backed by the in-process maps in Common.
+func NewMemoryStore() *MemoryStore {
+	return &MemoryStore{}
+}
+
+func (m *MemoryStore) CreateUser(ctx context.Context, user Common.User) error {
+	Common.Mu.Lock()
+	defer Common.Mu.Unlock()
+	Common.Users[user.ID] = copyUser(user)
+	return nil
+}
+
+func (m *MemoryStore) GetUser(ctx context.Context, id string) (Common.User, error) {
+	Common.Mu.Lock()
+	defer Common.Mu.Unlock()
+	user, ok := Common.Users[id]
+	if !ok {
+		return Common.User{}, ErrNotFound
+	}
+	return copyUser(user), nil
+}
+
+func (m *MemoryStore) GetUsers(ctx context.Context, ids []string) (map[string]Common.User, error) {
+	Common.Mu.Lock()
+	defer Common.Mu.Unlock()
+	result := make(map[string]Common.User, len(ids))
+	for _, id := range ids {
+		if user, ok := Common.Users[id]; ok {
+			result[id] = copyUser(user)
+		}
+	}
+	return result, nil
+}
+
+func (m *MemoryStore) FindUserByEmail(ctx context.Context, email string) (Common.User, error) {
+	return m.findUser(func(u Common.User) bool { return u.Email == email })
+}
+
+func (m *MemoryStore) FindUserBySecretHash(ctx context.Context, secretHash string) (Common.User, error) {
+	return m.findUser(func(u Common.User) bool { return u.SecretHash == secretHash })
+}
+
+// findUser returns the first user for which match returns true.
+func (m *MemoryStore) findUser(match func(Common.User) bool) (Common.User, error) {
+	Common.Mu.Lock()
+	defer Common.Mu.Unlock()
+	for _, user := range Common.Users {
+		if match(user) {
+			return copyUser(user), nil
+		}
+	}
+	return Common.User{}, ErrNotFound
+}
+
+func (m *MemoryStore) ListUsers(ctx context.Context) ([]Common.User, error) {
+	Common.Mu.Lock()
+	defer Common.Mu.Unlock()
+	var result []Common.User
+	for _, user := range Common.Users {
+		result = append(result, copyUser(user))
+	}
+	sort.Slice(result, func(i, j int) bool { return result[i].ID < result[j].ID })
+	return result, nil
+}
+
+func (m *MemoryStore) SetUserComplaints(ctx context.Context, userID string, complaintIDs []string) error {
+	Common.Mu.Lock()
+	defer Common.Mu.Unlock()
+	user, ok := Common.Users[userID]
+	if !ok {
+		return ErrNotFound
+	}
+	user.Complaints = append([]string{}, complaintIDs...)
+	Common.Users[userID] = user
+	return nil
+}
+
+func (m *MemoryStore) SetUserSecretHash(ctx context.Context, userID string, secretHash string) error {
+	Common.Mu.Lock()
+	defer Common.Mu.Unlock()
+	user, ok := Common.Users[userID]
+	if !ok {
+		return ErrNotFound
+	}
+	user.SecretHash = secretHash
+	Common.Users[userID] = user
+	return nil
+}
+
+func (m *MemoryStore) UpdateUser(ctx context.Context, id string, update func(*Common.User) error) (Common.User, error) {
+	Common.Mu.Lock()
+	defer Common.Mu.Unlock()
+	stored, ok := Common.Users[id]
+	if !ok {
+		return Common.User{}, ErrNotFound
+	}
+	user := copyUser(stored)
+	if err := update(&user); err != nil {
+		return Common.User{}, err
+	}
+	Common.Users[id] = copyUser(user)
+	return user, nil
+}
+
+// CreateComplaint holds Common.Mu across both writes, so no reader can observe
+// the complaint without its ID in the owner's list.
+func (m *MemoryStore) CreateComplaint(ctx context.Context, complaint Common.Complaint) error {
+	Common.Mu.Lock()
+	defer Common.Mu.Unlock()
+	user, ok := Common.Users[complaint.UserID]
+	if !ok {
+		return ErrNotFound
+	}
+	Common.Complaints[complaint.ID] = copyComplaint(complaint)
+	// Like Firestore's ArrayUnion, adding an ID twice is a no-op.
+	for _, id := range user.Complaints {
+		if id == complaint.ID {
+			return nil
+		}
+	}
+	user.Complaints = append(user.Complaints, complaint.ID)
+	Common.Users[user.ID] = user
+	return nil
+}
+
+func (m *MemoryStore) GetComplaint(ctx context.Context, id string) (Common.Complaint, error) {
+	Common.Mu.Lock()
+	defer Common.Mu.Unlock()
+	complaint, ok := Common.Complaints[id]
+	if !ok {
+		return Common.Complaint{}, ErrNotFound
+	}
+	return copyComplaint(complaint), nil
+}
+
+func (m *MemoryStore) ListComplaints(ctx context.Context) ([]Common.Complaint, error) {
+	return m.filterComplaints(func(c Common.Complaint) bool { return true }), nil
+}
+
+func (m *MemoryStore) ListUserComplaints(ctx context.Context, userID string) ([]Common.Complaint, error) {
+	return m.filterComplaints(func(c Common.Complaint) bool { return c.UserID == userID }), nil
+}
+
+func (m *MemoryStore) QueryComplaints(ctx context.Context, query ComplaintQuery) ([]Common.Complaint, error) {
+	result := m.filterComplaints(func(c Common.Complaint) bool {
+		return query.Matches(c) && (query.After == nil || query.Sort.Before(*query.After, CursorOf(c)))
+	})
+	sort.Slice(result, func(i, j int) bool { return query.Sort.Before(CursorOf(result[i]), CursorOf(result[j])) })
+	if query.Limit > 0 && len(result) > query.Limit {
+		result = result[:query.Limit]
+	}
+	return result, nil
+}
+
+// filterComplaints returns every complaint for which match returns true,
+// ordered by ID like a Firestore collection scan.
+func (m *MemoryStore) filterComplaints(match func(Common.Complaint) bool) []Common.Complaint {
+	Common.Mu.Lock()
+	defer Common.Mu.Unlock()
+	var result []Common.Complaint
+	for _, complaint := range Common.Complaints {
+		if match(complaint) {
+			result = append(result, copyComplaint(complaint))
+		}
+	}
+	sort.Slice(result, func(i, j int) bool { return result[i].ID < result[j].ID })
+	return result
+}
+
+func (m *MemoryStore) UpdateComplaint(ctx context.Context, id string, update func(*Common.Complaint) error) (Common.Complaint, error) {
+	Common.Mu.Lock()
+	defer Common.Mu.Unlock()
+	complaint, ok := Common.Complaints[id]
+	if !ok {
+		return Common.Complaint{}, ErrNotFound
+	}
+	complaint = copyComplaint(complaint)
+	if err := update(&complaint); err != nil {
+		return Common.Complaint{}, err
+	}
+	Common.Complaints[id] = complaint
+	return copyComplaint(complaint), nil
+}
+
+func (m *MemoryStore) AddComment(ctx context.Context, comment Common.Comment) error {
+	Common.Mu.Lock()
+	defer Common.Mu.Unlock()
+	if _, ok := Common.Complaints[comment.ComplaintID]; !ok {
+		return ErrNotFound
+	}
+	Common.Comments[comment.ComplaintID] = append(Common.Comments[comment.ComplaintID], comment)
+	return nil
+}
+
+func (m *MemoryStore) ListComments(ctx context.Context, complaintID string) ([]Common.Comment, error) {
+	Common.Mu.Lock()
+	defer Common.Mu.Unlock()
+	result := append([]Common.Comment(nil), Common.Comments[complaintID]...)
+	sort.SliceStable(result, func(i, j int) bool { return result[i].CreatedAt.Before(result[j].CreatedAt) })
+	return result, nil
+}
+
+func (m *MemoryStore) AddAuditEntry(ctx context.Context, entry Common.AuditEntry) error {
+	Common.Mu.Lock()
+	defer Common.Mu.Unlock()
+	entry.Changes = append([]Common.AuditChange(nil), entry.Changes...)
+	Common.AuditLog = append(Common.AuditLog, entry)
+	return nil
+}
+
+func (m *MemoryStore) ListAuditEntries(ctx context.Context, filter AuditFilter) ([]Common.AuditEntry, error) {
+	Common.Mu.Lock()
+	defer Common.Mu.Unlock()
+	var result []Common.AuditEntry
+	for _, entry := range Common.AuditLog {
+		if filter.Matches(entry) {
+			entry.Changes = append([]Common.AuditChange(nil), entry.Changes...)
+			result = append(result, entry)
+		}
+	}
+	sort.SliceStable(result, func(i, j int) bool { return result[i].At.Before(result[j].At) })
+	return result, nil
+}
+
+func (m *MemoryStore) CreateWebhook(ctx context.Context, webhook Common.Webhook) error {
+	Common.Mu.Lock()
+	defer Common.Mu.Unlock()
+	webhook.Events = append([]string(nil), webhook.Events...)
+	Common.Webhooks[webhook.ID] = webhook
+	return nil
+}
+
+func (m *MemoryStore) GetWebhook(ctx context.Context, id string) (Common.Webhook, error) {
+	Common.Mu.Lock()
+	defer Common.Mu.Unlock()
+	webhook, ok := Common.Webhooks[id]
+	if !ok {
+		return Common.Webhook{}, ErrNotFound
+	}
+	webhook.Events = append([]string(nil), webhook.Events...)
+	return webhook, nil
+}
+
+func (m *MemoryStore) ListWebhooks(ctx context.Context) ([]Common.Webhook, error) {
+	Common.Mu.Lock()
+	defer Common.Mu.Unlock()
+	result := make([]Common.Webhook, 0, len(Common.Webhooks))
+	for _, webhook := range Common.Webhooks {
+		webhook.Events = append([]string(nil), webhook.Events...)
+		result = append(result, webhook)
+	}
+	sort.Slice(result, func(i, j int) bool {
+		if !result[i].CreatedAt.Equal(result[j].CreatedAt) {
+			return result[i].CreatedAt.Before(result[j].CreatedAt)
+		}
+		return result[i].ID < result[j].ID
+	})
+	return result, nil
+}
+
+func (m *MemoryStore) DeleteWebhook(ctx context.Context, id string) error {
+	Common.Mu.Lock()
+	defer Common.Mu.Unlock()
+	if _, ok := Common.Webhooks[id]; !ok {
+		return ErrNotFound
+	}
+	delete(Common.Webhooks, id)
+	return nil
+}
+
+func (m *MemoryStore) AddWebhookDelivery(ctx context.Context, delivery Common.WebhookDelivery) error {
+	Common.Mu.Lock()
+	defer Common.Mu.Unlock()
+	Common.WebhookDeliveries[delivery.ID] = delivery
+	return nil
+}
+
+func (m *MemoryStore) GetWebhookDelivery(ctx context.Context, id string) (Common.WebhookDelivery, error) {
+	Common.Mu.Lock()
+	defer Common.Mu.Unlock()
+	delivery, ok := Common.WebhookDeliveries[id]
+	if !ok {
+		return delivery, ErrNotFound
+	}
+	return delivery, nil
+}
+
+func (m *MemoryStore) UpdateWebhookDelivery(ctx context.Context, id string, update func(*Common.WebhookDelivery) error) (Common.WebhookDelivery, error) {
+	Common.Mu.Lock()
+	defer Common.Mu.Unlock()
+	delivery, ok := Common.WebhookDeliveries[id]
+	if !ok {
+		return delivery, ErrNotFound
+	}
+	if err := update(&delivery); err != nil {
+		return Common.WebhookDelivery{}, err
+	}
+	Common.WebhookDeliveries[id] = delivery
+	return delivery, nil
+}
+
+func (m *MemoryStore) ListWebhookDeliveries(ctx context.Context, filter DeliveryFilter) ([]Common.WebhookDelivery, error) {
+	Common.Mu.Lock()
+	defer Common.Mu.Unlock()
+	var result []Common.WebhookDelivery
+	for _, delivery := range Common.WebhookDeliveries {
+		if filter.Matches(delivery) {
+			result = append(result, delivery)
+		}
+	}
+	sort.Slice(result, func(i, j int) bool {
+		if !result[i].CreatedAt.Equal(result[j].CreatedAt) {
+			return result[i].CreatedAt.After(result[j].CreatedAt)
+		}
+		return result[i].ID > result[j].ID
+	})
+	if filter.Limit > 0 && len(result) > filter.Limit {
+		result = result[:filter.Limit]
+	}
+	return result, nil
+}
+
+// Ping always succeeds: the maps are always there.
+func (m *MemoryStore) Ping(ctx context.Context) error {
+	return nil
+}
+
+// Close is a no-op; the maps live as long as the process.
+func (m *MemoryStore) Close() error {
+	return nil
+}
+
+// Reset removes every user, complaint, comment, audit entry, webhook and delivery.
+// It is mainly useful in tests.
+func (m *MemoryStore) Reset() {
+	Common.Mu.Lock()
+	defer Common.Mu.Unlock()
+	Common.Users = make(map[string]Common.User)
+	Common.Complaints = make(map[string]Common.Complaint)
+	Common.Comments = make(map[string][]Common.Comment)
+	Common.AuditLog = nil
+	Common.Webhooks = make(map[string]Common.Webhook)
+	Common.WebhookDeliveries = make(map[string]Common.WebhookDelivery)
+}
+
+// copyUser returns a copy of the user that does not share its Complaints slice with the map.
+func copyUser(user Common.User) Common.User {
+	user.Complaints = append([]string{}, user.Complaints...)
+	return user
+}
+
+// copyComplaint returns a copy of the complaint that does not share its history with the map.
+func copyComplaint(complaint Common.Complaint) Common.Complaint {
+	complaint.StatusHistory = append([]Common.StatusChange(nil), complaint.StatusHistory...)
+	return complaint
+}
@@ -0,0 +1,107 @@
+// Storage/Query.go
+package Storage
+
+import (
+	"complaint-portal/Common"
+	"time"
+)
+
+// ComplaintSort is the order of a complaint listing. Ties are broken by ID, in
+// the same direction, so every complaint has a stable position.
+type ComplaintSort int
+
+const (
+	NewestFirst ComplaintSort = iota
+	OldestFirst
+	HighestSeverityFirst
+	LowestSeverityFirst
+)
+
+// descending reports whether the sort goes from high to low values.
+func (s ComplaintSort) descending() bool {
+	return s == NewestFirst || s == HighestSeverityFirst
+}
+
+// bySeverity reports whether the sort key is the severity rather than the creation time.
+func (s ComplaintSort) bySeverity() bool {
+	return s == HighestSeverityFirst || s == LowestSeverityFirst
+}
+
+// ComplaintCursor is the position of a complaint in a listing: the values of
+// every sort key, so that the next page can start right after it.
+type ComplaintCursor struct {
+	ID        string
+	CreatedAt time.Time
+	Severity  int
+}
+
+// CursorOf returns the position of the complaint.
+func CursorOf(c Common.Complaint) ComplaintCursor {
+	return ComplaintCursor{ID: c.ID, CreatedAt: c.CreatedAt, Severity: c.Severity}
+}
+
+// ComplaintQuery selects and orders a page of complaints. Zero fields do not filter.
+type ComplaintQuery struct {
+	UserID      string
+	MinSeverity int
+	MaxSeverity int
+	// Statuses keeps the complaints in any of these statuses.
+	Statuses []Common.ComplaintStatus
+	// Resolved, when set, keeps only resolved or only unresolved complaints.
+	Resolved *bool
+	// CreatedSince is the earliest creation time kept, and CreatedUntil the first
+	// time past the range.
+	CreatedSince time.Time
+	CreatedUntil time.Time
+
+	Sort ComplaintSort
+	// After, when set, starts the listing right after that position.
+	After *ComplaintCursor
+	// Limit is the most complaints returned; 0 returns them all.
+	Limit int
+}
+
+// Matches reports whether the query's filters keep the complaint.
+func (q ComplaintQuery) Matches(c Common.Complaint) bool {
+	if q.UserID != "" && c.UserID != q.UserID {
+		return false
+	}
+	if (q.MinSeverity != 0 && c.Severity < q.MinSeverity) || (q.MaxSeverity != 0 && c.Severity > q.MaxSeverity) {
+		return false
+	}
+	if q.Resolved != nil && c.Resolved != *q.Resolved {
+		return false
+	}
+	if (!q.CreatedSince.IsZero() && c.CreatedAt.Before(q.CreatedSince)) || (!q.CreatedUntil.IsZero() && !c.CreatedAt.Before(q.CreatedUntil)) {
+		return false
+	}
+	if len(q.Statuses) == 0 {
+		return true
+	}
+	for _, s := range q.Statuses {
+		if c.CurrentStatus() == s {
+			return true
+		}
+	}
+	return false
+}
+
+// Before reports whether a complaint at position a is listed before one at b.
+func (s ComplaintSort) Before(a, b ComplaintCursor) bool {
+	var cmp int
+	if s.bySeverity() {
+		cmp = a.Severity - b.Severity
+	} else {
+		cmp = a.CreatedAt.Compare(b.CreatedAt)
+	}
+	if cmp == 0 && a.ID != b.ID {
+		cmp = 1
+		if a.ID < b.ID {
+			cmp = -1
+		}
+	}
+	if s.descending() {
+		return cmp > 0
+	}
+	return cmp < 0
+}
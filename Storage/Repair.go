@@ -0,0 +1,91 @@
+// Storage/Repair.go
+package Storage
+
+import (
+	"complaint-portal/Common"
+	"context"
+	"fmt"
+)
+
+// RepairReport describes the inconsistencies found between users' Complaints lists
+// and the complaints collection.
+type RepairReport struct {
+	// Orphans are complaints whose ID is missing from their owner's Complaints list.
+	// Repair adds them back to the list.
+	Orphans []string
+	// Dangling maps a user ID to the IDs in its Complaints list that point to a missing
+	// complaint or to a complaint owned by someone else. Repair removes them.
+	Dangling map[string][]string
+	// Ownerless are complaints whose owner does not exist. They cannot be repaired
+	// automatically and are only reported.
+	Ownerless []string
+	// UsersUpdated is the number of users whose list was (or, in a dry run, would be) rewritten.
+	UsersUpdated int
+}
+
+// Repair reconciles every user's Complaints list with the complaints collection.
+// This fixes the damage left by the old, non-atomic SubmitComplaint. With dryRun set,
+// the report is computed but nothing is written.
+func Repair(ctx context.Context, store Store, dryRun bool) (RepairReport, error) {
+	report := RepairReport{Dangling: map[string][]string{}}
+
+	users, err := store.ListUsers(ctx)
+	if err != nil {
+		return report, fmt.Errorf("listing users: %w", err)
+	}
+	complaints, err := store.ListComplaints(ctx)
+	if err != nil {
+		return report, fmt.Errorf("listing complaints: %w", err)
+	}
+
+	owners := make(map[string]string, len(complaints))
+	byUser := make(map[string][]Common.Complaint)
+	for _, c := range complaints {
+		owners[c.ID] = c.UserID
+		byUser[c.UserID] = append(byUser[c.UserID], c)
+	}
+
+	known := make(map[string]bool, len(users))
+	for _, user := range users {
+		known[user.ID] = true
+
+		// Keep the IDs that really belong to the user, in their original order.
+		fixed := []string{}
+		listed := map[string]bool{}
+		changed := false
+		for _, id := range user.Complaints {
+			if owners[id] != user.ID || listed[id] {
+				report.Dangling[user.ID] = append(report.Dangling[user.ID], id)
+				changed = true
+				continue
+			}
+			listed[id] = true
+			fixed = append(fixed, id)
+		}
+		for _, c := range byUser[user.ID] {
+			if !listed[c.ID] {
+				report.Orphans = append(report.Orphans, c.ID)
+				fixed = append(fixed, c.ID)
+				changed = true
+			}
+		}
+
+		if !changed {
+			continue
+		}
+		report.UsersUpdated++
+		if dryRun {
+			continue
+		}
+		if err := store.SetUserComplaints(ctx, user.ID, fixed); err != nil {
+			return report, fmt.Errorf("updating user %s: %w", user.ID, err)
+		}
+	}
+
+	for _, c := range complaints {
+		if !known[c.UserID] {
+			report.Ownerless = append(report.Ownerless, c.ID)
+		}
+	}
+	return report, nil
+}
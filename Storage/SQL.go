@@ -0,0 +1,836 @@
+// Storage/SQL.go
+package Storage
+
+import (
+	"complaint-portal/Common"
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	_ "modernc.org/sqlite" // pure-Go SQLite driver, registered as "sqlite"
+)
+
+// SQLStore is a Store backed by an embedded SQLite database.
+// It needs no cgo and no cloud services, which makes it the backend of choice
+// for self-hosted deployments.
+type SQLStore struct {
+	db *sql.DB
+}
+
+// OpenSQLite opens (or creates) the SQLite database at path and migrates its schema.
+// Use ":memory:" for a throwaway database.
+func OpenSQLite(path string) (*SQLStore, error) {
+	dsn := "file:" + path + "?_pragma=foreign_keys(1)&_pragma=busy_timeout(5000)&_pragma=journal_mode(WAL)"
+	db, err := sql.Open("sqlite", dsn)
+	if err != nil {
+		return nil, err
+	}
+	// SQLite allows a single writer; one connection avoids SQLITE_BUSY errors
+	// and keeps ":memory:" databases from being split across connections.
+	db.SetMaxOpenConns(1)
+
+	store, err := NewSQLStore(db)
+	if err != nil {
+		db.Close()
+		return nil, err
+	}
+	return store, nil
+}
+
+// NewSQLStore returns a Store using the given database, after migrating its schema.
+func NewSQLStore(db *sql.DB) (*SQLStore, error) {
+	if err := migrate(context.Background(), db); err != nil {
+		return nil, fmt.Errorf("migrating database: %w", err)
+	}
+	return &SQLStore{db: db}, nil
+}
+
+func (sq *SQLStore) CreateUser(ctx context.Context, user Common.User) error {
+	tx, err := sq.db.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	_, err = tx.ExecContext(ctx, `INSERT INTO users (id, secret_code, name, email, role, recovery_hash, recovery_expires_at, session_generation, created_at, updated_at) VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?)`,
+		user.ID, user.SecretHash, user.Name, user.Email, user.EffectiveRole(), user.RecoveryHash, toNanos(user.RecoveryExpiresAt), user.SessionGeneration, toNanos(user.CreatedAt), toNanos(user.UpdatedAt))
+	if err != nil {
+		return err
+	}
+	for _, complaintID := range user.Complaints {
+		if err := addUserComplaint(ctx, tx, user.ID, complaintID); err != nil {
+			return err
+		}
+	}
+	return tx.Commit()
+}
+
+func (sq *SQLStore) GetUser(ctx context.Context, id string) (Common.User, error) {
+	return findUser(ctx, sq.db, "id", id)
+}
+
+func (sq *SQLStore) FindUserByEmail(ctx context.Context, email string) (Common.User, error) {
+	return findUser(ctx, sq.db, "email", email)
+}
+
+// FindUserBySecretHash looks in the secret_code column, which holds hashes since
+// the codes stopped being stored.
+func (sq *SQLStore) FindUserBySecretHash(ctx context.Context, secretHash string) (Common.User, error) {
+	return findUser(ctx, sq.db, "secret_code", secretHash)
+}
+
+// userColumns is the column list scanned by scanUser.
+const userColumns = `id, secret_code, name, email, role, recovery_hash, recovery_expires_at, session_generation, created_at, updated_at`
+
+// scanUser reads a user without its complaint list.
+func scanUser(row scanner) (Common.User, error) {
+	var user Common.User
+	var recoveryExpiresAt, createdAt, updatedAt int64
+	err := row.Scan(&user.ID, &user.SecretHash, &user.Name, &user.Email, &user.Role, &user.RecoveryHash, &recoveryExpiresAt, &user.SessionGeneration, &createdAt, &updatedAt)
+	user.RecoveryExpiresAt = fromNanos(recoveryExpiresAt)
+	user.CreatedAt = fromNanos(createdAt)
+	user.UpdatedAt = fromNanos(updatedAt)
+	return user, err
+}
+
+// findUser returns the user whose column equals value, with its complaint list.
+// column is always one of the constants above, never user input.
+func findUser(ctx context.Context, q querier, column string, value string) (Common.User, error) {
+	user, err := scanUser(q.QueryRowContext(ctx, `SELECT `+userColumns+` FROM users WHERE `+column+` = ? LIMIT 1`, value))
+	if errors.Is(err, sql.ErrNoRows) {
+		return user, ErrNotFound
+	}
+	if err != nil {
+		return user, err
+	}
+
+	rows, err := q.QueryContext(ctx, `SELECT complaint_id FROM user_complaints WHERE user_id = ? ORDER BY seq`, user.ID)
+	if err != nil {
+		return user, err
+	}
+	defer rows.Close()
+	user.Complaints = []string{}
+	for rows.Next() {
+		var complaintID string
+		if err := rows.Scan(&complaintID); err != nil {
+			return user, err
+		}
+		user.Complaints = append(user.Complaints, complaintID)
+	}
+	return user, rows.Err()
+}
+
+// GetUsers reads the users, then their complaint lists, in two queries.
+func (sq *SQLStore) GetUsers(ctx context.Context, ids []string) (map[string]Common.User, error) {
+	result := make(map[string]Common.User, len(ids))
+	if len(ids) == 0 {
+		return result, nil
+	}
+	placeholders := make([]string, len(ids))
+	args := make([]interface{}, len(ids))
+	for i, id := range ids {
+		placeholders[i] = "?"
+		args[i] = id
+	}
+	in := "(" + strings.Join(placeholders, ",") + ")"
+
+	rows, err := sq.db.QueryContext(ctx, `SELECT `+userColumns+` FROM users WHERE id IN `+in, args...)
+	if err != nil {
+		return nil, err
+	}
+	for rows.Next() {
+		user, err := scanUser(rows)
+		if err != nil {
+			rows.Close()
+			return nil, err
+		}
+		user.Complaints = []string{}
+		result[user.ID] = user
+	}
+	rows.Close()
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	rows, err = sq.db.QueryContext(ctx, `SELECT user_id, complaint_id FROM user_complaints WHERE user_id IN `+in+` ORDER BY user_id, seq`, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	for rows.Next() {
+		var userID, complaintID string
+		if err := rows.Scan(&userID, &complaintID); err != nil {
+			return nil, err
+		}
+		user := result[userID]
+		user.Complaints = append(user.Complaints, complaintID)
+		result[userID] = user
+	}
+	return result, rows.Err()
+}
+
+func (sq *SQLStore) ListUsers(ctx context.Context) ([]Common.User, error) {
+	rows, err := sq.db.QueryContext(ctx, `SELECT id FROM users ORDER BY id`)
+	if err != nil {
+		return nil, err
+	}
+	var ids []string
+	for rows.Next() {
+		var id string
+		if err := rows.Scan(&id); err != nil {
+			rows.Close()
+			return nil, err
+		}
+		ids = append(ids, id)
+	}
+	rows.Close()
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	// The store uses a single connection, so the rows must be closed before
+	// the per-user queries run.
+	var result []Common.User
+	for _, id := range ids {
+		user, err := sq.GetUser(ctx, id)
+		if err != nil {
+			return nil, err
+		}
+		result = append(result, user)
+	}
+	return result, nil
+}
+
+func (sq *SQLStore) SetUserComplaints(ctx context.Context, userID string, complaintIDs []string) error {
+	tx, err := sq.db.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	if err := requireUser(ctx, tx, userID); err != nil {
+		return err
+	}
+	if _, err := tx.ExecContext(ctx, `DELETE FROM user_complaints WHERE user_id = ?`, userID); err != nil {
+		return err
+	}
+	for _, complaintID := range complaintIDs {
+		if err := addUserComplaint(ctx, tx, userID, complaintID); err != nil {
+			return err
+		}
+	}
+	return tx.Commit()
+}
+
+func (sq *SQLStore) SetUserSecretHash(ctx context.Context, userID string, secretHash string) error {
+	res, err := sq.db.ExecContext(ctx, `UPDATE users SET secret_code = ? WHERE id = ?`, secretHash, userID)
+	if err != nil {
+		return err
+	}
+	n, err := res.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if n == 0 {
+		return ErrNotFound
+	}
+	return nil
+}
+
+// UpdateUser saves every column of the user; the complaint list is left alone.
+func (sq *SQLStore) UpdateUser(ctx context.Context, id string, update func(*Common.User) error) (Common.User, error) {
+	tx, err := sq.db.BeginTx(ctx, nil)
+	if err != nil {
+		return Common.User{}, err
+	}
+	defer tx.Rollback()
+
+	user, err := findUser(ctx, tx, "id", id)
+	if err != nil {
+		return Common.User{}, err
+	}
+	if err := update(&user); err != nil {
+		return Common.User{}, err
+	}
+
+	_, err = tx.ExecContext(ctx, `UPDATE users SET secret_code = ?, name = ?, email = ?, role = ?, recovery_hash = ?, recovery_expires_at = ?, session_generation = ?, created_at = ?, updated_at = ? WHERE id = ?`,
+		user.SecretHash, user.Name, user.Email, user.EffectiveRole(), user.RecoveryHash, toNanos(user.RecoveryExpiresAt), user.SessionGeneration, toNanos(user.CreatedAt), toNanos(user.UpdatedAt), id)
+	if err != nil {
+		return Common.User{}, err
+	}
+	return user, tx.Commit()
+}
+
+// requireUser returns ErrNotFound if no user has the given ID.
+func requireUser(ctx context.Context, tx *sql.Tx, userID string) error {
+	var exists int
+	err := tx.QueryRowContext(ctx, `SELECT 1 FROM users WHERE id = ?`, userID).Scan(&exists)
+	if errors.Is(err, sql.ErrNoRows) {
+		return ErrNotFound
+	}
+	return err
+}
+
+// execer is implemented by both *sql.DB and *sql.Tx.
+type execer interface {
+	ExecContext(ctx context.Context, query string, args ...interface{}) (sql.Result, error)
+}
+
+// addUserComplaint behaves like Firestore's ArrayUnion: adding an ID twice is a no-op.
+func addUserComplaint(ctx context.Context, db execer, userID, complaintID string) error {
+	_, err := db.ExecContext(ctx, `INSERT OR IGNORE INTO user_complaints (user_id, complaint_id) VALUES (?, ?)`, userID, complaintID)
+	return err
+}
+
+// CreateComplaint inserts the complaint and links it to its owner in one transaction.
+func (sq *SQLStore) CreateComplaint(ctx context.Context, complaint Common.Complaint) error {
+	tx, err := sq.db.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	if err := requireUser(ctx, tx, complaint.UserID); err != nil {
+		return err
+	}
+	_, err = tx.ExecContext(ctx, `INSERT INTO complaints (id, user_id, title, summary, severity, resolved, status, created_at, updated_at, resolved_at) VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?)`,
+		complaint.ID, complaint.UserID, complaint.Title, complaint.Summary, complaint.Severity, complaint.Resolved, complaint.Status,
+		toNanos(complaint.CreatedAt), toNanos(complaint.UpdatedAt), toNanos(complaint.ResolvedAt))
+	if err != nil {
+		return err
+	}
+	if err := appendStatusHistory(ctx, tx, complaint.ID, 0, complaint.StatusHistory); err != nil {
+		return err
+	}
+	if err := addUserComplaint(ctx, tx, complaint.UserID, complaint.ID); err != nil {
+		return err
+	}
+	return tx.Commit()
+}
+
+// complaintColumns is the column list scanned by scanComplaint.
+const complaintColumns = `id, user_id, title, summary, severity, resolved, status, created_at, updated_at, resolved_at`
+
+// scanner is implemented by both *sql.Row and *sql.Rows.
+type scanner interface {
+	Scan(dest ...interface{}) error
+}
+
+// querier is implemented by both *sql.DB and *sql.Tx.
+type querier interface {
+	execer
+	QueryContext(ctx context.Context, query string, args ...interface{}) (*sql.Rows, error)
+	QueryRowContext(ctx context.Context, query string, args ...interface{}) *sql.Row
+}
+
+func scanComplaint(row scanner) (Common.Complaint, error) {
+	var c Common.Complaint
+	var createdAt, updatedAt, resolvedAt int64
+	err := row.Scan(&c.ID, &c.UserID, &c.Title, &c.Summary, &c.Severity, &c.Resolved, &c.Status, &createdAt, &updatedAt, &resolvedAt)
+	c.CreatedAt = fromNanos(createdAt)
+	c.UpdatedAt = fromNanos(updatedAt)
+	c.ResolvedAt = fromNanos(resolvedAt)
+	return c, err
+}
+
+func (sq *SQLStore) GetComplaint(ctx context.Context, id string) (Common.Complaint, error) {
+	return getComplaint(ctx, sq.db, id)
+}
+
+// getComplaint loads one complaint with its status history.
+func getComplaint(ctx context.Context, q querier, id string) (Common.Complaint, error) {
+	complaints, err := queryComplaints(ctx, q, `SELECT `+complaintColumns+` FROM complaints WHERE id = ?`, id)
+	if err != nil {
+		return Common.Complaint{}, err
+	}
+	if len(complaints) == 0 {
+		return Common.Complaint{}, ErrNotFound
+	}
+	return complaints[0], nil
+}
+
+func (sq *SQLStore) ListComplaints(ctx context.Context) ([]Common.Complaint, error) {
+	return queryComplaints(ctx, sq.db, `SELECT `+complaintColumns+` FROM complaints ORDER BY id`)
+}
+
+func (sq *SQLStore) ListUserComplaints(ctx context.Context, userID string) ([]Common.Complaint, error) {
+	return queryComplaints(ctx, sq.db, `SELECT `+complaintColumns+` FROM complaints WHERE user_id = ? ORDER BY id`, userID)
+}
+
+func (sq *SQLStore) QueryComplaints(ctx context.Context, q ComplaintQuery) ([]Common.Complaint, error) {
+	var conditions []string
+	var args []interface{}
+	if q.UserID != "" {
+		conditions = append(conditions, "user_id = ?")
+		args = append(args, q.UserID)
+	}
+	if q.MinSeverity != 0 {
+		conditions = append(conditions, "severity >= ?")
+		args = append(args, q.MinSeverity)
+	}
+	if q.MaxSeverity != 0 {
+		conditions = append(conditions, "severity <= ?")
+		args = append(args, q.MaxSeverity)
+	}
+	if len(q.Statuses) > 0 {
+		placeholders := make([]string, len(q.Statuses))
+		for i, s := range q.Statuses {
+			placeholders[i] = "?"
+			args = append(args, s)
+		}
+		conditions = append(conditions, "status IN ("+strings.Join(placeholders, ",")+")")
+	}
+	if q.Resolved != nil {
+		conditions = append(conditions, "resolved = ?")
+		args = append(args, *q.Resolved)
+	}
+	if !q.CreatedSince.IsZero() {
+		conditions = append(conditions, "created_at >= ?")
+		args = append(args, toNanos(q.CreatedSince))
+	}
+	if !q.CreatedUntil.IsZero() {
+		conditions = append(conditions, "created_at < ?")
+		args = append(args, toNanos(q.CreatedUntil))
+	}
+
+	column, direction, after := "created_at", "ASC", ">"
+	if q.Sort.bySeverity() {
+		column = "severity"
+	}
+	if q.Sort.descending() {
+		direction, after = "DESC", "<"
+	}
+	if q.After != nil {
+		conditions = append(conditions, "("+column+", id) "+after+" (?, ?)")
+		if q.Sort.bySeverity() {
+			args = append(args, q.After.Severity, q.After.ID)
+		} else {
+			args = append(args, toNanos(q.After.CreatedAt), q.After.ID)
+		}
+	}
+
+	query := `SELECT ` + complaintColumns + ` FROM complaints`
+	if len(conditions) > 0 {
+		query += " WHERE " + strings.Join(conditions, " AND ")
+	}
+	query += " ORDER BY " + column + " " + direction + ", id " + direction
+	if q.Limit > 0 {
+		query += " LIMIT ?"
+		args = append(args, q.Limit)
+	}
+	return queryComplaints(ctx, sq.db, query, args...)
+}
+
+// queryComplaints runs the query, scans every row into a complaint and attaches
+// the status history of the complaints found.
+func queryComplaints(ctx context.Context, q querier, query string, args ...interface{}) ([]Common.Complaint, error) {
+	rows, err := q.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, err
+	}
+	var result []Common.Complaint
+	index := map[string]int{}
+	for rows.Next() {
+		c, err := scanComplaint(rows)
+		if err != nil {
+			rows.Close()
+			return nil, err
+		}
+		index[c.ID] = len(result)
+		result = append(result, c)
+	}
+	rows.Close()
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	if len(result) == 0 {
+		return result, nil
+	}
+
+	// Fetch the histories in one query rather than one per complaint.
+	var placeholders []string
+	var ids []interface{}
+	for _, c := range result {
+		placeholders = append(placeholders, "?")
+		ids = append(ids, c.ID)
+	}
+	rows, err = q.QueryContext(ctx, `SELECT complaint_id, from_status, to_status, changed_by, changed_at, note
+		FROM complaint_status_history WHERE complaint_id IN (`+strings.Join(placeholders, ",")+`) ORDER BY complaint_id, seq`, ids...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	for rows.Next() {
+		var id string
+		var change Common.StatusChange
+		var changedAt int64
+		if err := rows.Scan(&id, &change.From, &change.To, &change.ChangedBy, &changedAt, &change.Note); err != nil {
+			return nil, err
+		}
+		change.ChangedAt = fromNanos(changedAt)
+		c := &result[index[id]]
+		c.StatusHistory = append(c.StatusHistory, change)
+	}
+	return result, rows.Err()
+}
+
+// UpdateComplaint runs the read-modify-write in one transaction. The status history
+// is append-only, so only entries added by update are inserted.
+func (sq *SQLStore) UpdateComplaint(ctx context.Context, id string, update func(*Common.Complaint) error) (Common.Complaint, error) {
+	tx, err := sq.db.BeginTx(ctx, nil)
+	if err != nil {
+		return Common.Complaint{}, err
+	}
+	defer tx.Rollback()
+
+	complaint, err := getComplaint(ctx, tx, id)
+	if err != nil {
+		return Common.Complaint{}, err
+	}
+	saved := len(complaint.StatusHistory)
+	if err := update(&complaint); err != nil {
+		return Common.Complaint{}, err
+	}
+
+	_, err = tx.ExecContext(ctx, `UPDATE complaints SET title = ?, summary = ?, severity = ?, resolved = ?, status = ?, created_at = ?, updated_at = ?, resolved_at = ? WHERE id = ?`,
+		complaint.Title, complaint.Summary, complaint.Severity, complaint.Resolved, complaint.Status,
+		toNanos(complaint.CreatedAt), toNanos(complaint.UpdatedAt), toNanos(complaint.ResolvedAt), id)
+	if err != nil {
+		return Common.Complaint{}, err
+	}
+	if len(complaint.StatusHistory) > saved {
+		if err := appendStatusHistory(ctx, tx, id, saved, complaint.StatusHistory[saved:]); err != nil {
+			return Common.Complaint{}, err
+		}
+	}
+	return complaint, tx.Commit()
+}
+
+// appendStatusHistory inserts the changes, numbering them from seq onwards.
+func appendStatusHistory(ctx context.Context, db execer, complaintID string, seq int, changes []Common.StatusChange) error {
+	for i, change := range changes {
+		_, err := db.ExecContext(ctx, `INSERT INTO complaint_status_history (complaint_id, seq, from_status, to_status, changed_by, changed_at, note) VALUES (?, ?, ?, ?, ?, ?, ?)`,
+			complaintID, seq+i, change.From, change.To, change.ChangedBy, toNanos(change.ChangedAt), change.Note)
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// toNanos stores a time as Unix nanoseconds; the zero time is stored as 0.
+func toNanos(t time.Time) int64 {
+	if t.IsZero() {
+		return 0
+	}
+	return t.UnixNano()
+}
+
+// fromNanos is the inverse of toNanos.
+func fromNanos(n int64) time.Time {
+	if n == 0 {
+		return time.Time{}
+	}
+	return time.Unix(0, n).UTC()
+}
+
+func (sq *SQLStore) AddComment(ctx context.Context, comment Common.Comment) error {
+	tx, err := sq.db.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	var exists int
+	err = tx.QueryRowContext(ctx, `SELECT 1 FROM complaints WHERE id = ?`, comment.ComplaintID).Scan(&exists)
+	if errors.Is(err, sql.ErrNoRows) {
+		return ErrNotFound
+	}
+	if err != nil {
+		return err
+	}
+	_, err = tx.ExecContext(ctx, `INSERT INTO comments (id, complaint_id, author_id, role, body, created_at) VALUES (?, ?, ?, ?, ?, ?)`,
+		comment.ID, comment.ComplaintID, comment.AuthorID, comment.Role, comment.Body, toNanos(comment.CreatedAt))
+	if err != nil {
+		return err
+	}
+	return tx.Commit()
+}
+
+func (sq *SQLStore) AddAuditEntry(ctx context.Context, entry Common.AuditEntry) error {
+	tx, err := sq.db.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	_, err = tx.ExecContext(ctx, `INSERT INTO audit_log (id, at, actor_id, action, target_id, method, client_addr) VALUES (?, ?, ?, ?, ?, ?, ?)`,
+		entry.ID, toNanos(entry.At), entry.ActorID, entry.Action, entry.TargetID, entry.Method, entry.ClientAddr)
+	if err != nil {
+		return err
+	}
+	for i, change := range entry.Changes {
+		_, err := tx.ExecContext(ctx, `INSERT INTO audit_changes (entry_id, seq, field, before_value, after_value) VALUES (?, ?, ?, ?, ?)`,
+			entry.ID, i, change.Field, change.Before, change.After)
+		if err != nil {
+			return err
+		}
+	}
+	return tx.Commit()
+}
+
+func (sq *SQLStore) ListAuditEntries(ctx context.Context, filter AuditFilter) ([]Common.AuditEntry, error) {
+	where, args := auditWhere(filter)
+	rows, err := sq.db.QueryContext(ctx, `SELECT id, at, actor_id, action, target_id, method, client_addr
+		FROM audit_log `+where+` ORDER BY at, rowid`, args...)
+	if err != nil {
+		return nil, err
+	}
+	var result []Common.AuditEntry
+	index := map[string]int{}
+	for rows.Next() {
+		var e Common.AuditEntry
+		var at int64
+		if err := rows.Scan(&e.ID, &at, &e.ActorID, &e.Action, &e.TargetID, &e.Method, &e.ClientAddr); err != nil {
+			rows.Close()
+			return nil, err
+		}
+		e.At = fromNanos(at)
+		index[e.ID] = len(result)
+		result = append(result, e)
+	}
+	rows.Close()
+	if err := rows.Err(); err != nil || len(result) == 0 {
+		return result, err
+	}
+
+	// Fetch the changes of every selected entry in one query, with the same filter.
+	rows, err = sq.db.QueryContext(ctx, `SELECT c.entry_id, c.field, c.before_value, c.after_value
+		FROM audit_changes c JOIN audit_log ON audit_log.id = c.entry_id `+where+` ORDER BY c.entry_id, c.seq`, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	for rows.Next() {
+		var id string
+		var change Common.AuditChange
+		if err := rows.Scan(&id, &change.Field, &change.Before, &change.After); err != nil {
+			return nil, err
+		}
+		e := &result[index[id]]
+		e.Changes = append(e.Changes, change)
+	}
+	return result, rows.Err()
+}
+
+// auditWhere returns the WHERE clause on audit_log selecting the entries matched by filter.
+func auditWhere(filter AuditFilter) (string, []interface{}) {
+	var conditions []string
+	var args []interface{}
+	if filter.ActorID != "" {
+		conditions = append(conditions, "audit_log.actor_id = ?")
+		args = append(args, filter.ActorID)
+	}
+	if filter.TargetID != "" {
+		conditions = append(conditions, "audit_log.target_id = ?")
+		args = append(args, filter.TargetID)
+	}
+	if !filter.Since.IsZero() {
+		conditions = append(conditions, "audit_log.at >= ?")
+		args = append(args, toNanos(filter.Since))
+	}
+	if !filter.Until.IsZero() {
+		conditions = append(conditions, "audit_log.at < ?")
+		args = append(args, toNanos(filter.Until))
+	}
+	if len(conditions) == 0 {
+		return "", nil
+	}
+	return "WHERE " + strings.Join(conditions, " AND "), args
+}
+
+func (sq *SQLStore) ListComments(ctx context.Context, complaintID string) ([]Common.Comment, error) {
+	rows, err := sq.db.QueryContext(ctx, `SELECT id, complaint_id, author_id, role, body, created_at
+		FROM comments WHERE complaint_id = ? ORDER BY created_at, rowid`, complaintID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var result []Common.Comment
+	for rows.Next() {
+		var c Common.Comment
+		var createdAt int64
+		if err := rows.Scan(&c.ID, &c.ComplaintID, &c.AuthorID, &c.Role, &c.Body, &createdAt); err != nil {
+			return nil, err
+		}
+		c.CreatedAt = fromNanos(createdAt)
+		result = append(result, c)
+	}
+	return result, rows.Err()
+}
+
+// Ping runs a trivial query, which fails if the database file cannot be read.
+func (sq *SQLStore) Ping(ctx context.Context) error {
+	var one int
+	return sq.db.QueryRowContext(ctx, `SELECT 1`).Scan(&one)
+}
+
+// Close closes the database.
+func (sq *SQLStore) Close() error {
+	return sq.db.Close()
+}
+
+// Reset removes every user, complaint, comment, audit entry, webhook and delivery. It is mainly useful in tests.
+func (sq *SQLStore) Reset() error {
+	_, err := sq.db.Exec(`DELETE FROM audit_changes; DELETE FROM audit_log; DELETE FROM comments; DELETE FROM complaint_status_history; DELETE FROM user_complaints; DELETE FROM complaints; DELETE FROM users; DELETE FROM webhook_deliveries; DELETE FROM webhooks;`)
+	return err
+}
+
+func (sq *SQLStore) CreateWebhook(ctx context.Context, webhook Common.Webhook) error {
+	_, err := sq.db.ExecContext(ctx, `INSERT INTO webhooks (id, url, events, secret, created_by, created_at) VALUES (?, ?, ?, ?, ?, ?)`,
+		webhook.ID, webhook.URL, strings.Join(webhook.Events, " "), webhook.Secret, webhook.CreatedBy, toNanos(webhook.CreatedAt))
+	return err
+}
+
+// webhookColumns is the column list scanned by scanWebhook.
+const webhookColumns = `id, url, events, secret, created_by, created_at`
+
+func scanWebhook(row scanner) (Common.Webhook, error) {
+	var w Common.Webhook
+	var events string
+	var createdAt int64
+	err := row.Scan(&w.ID, &w.URL, &events, &w.Secret, &w.CreatedBy, &createdAt)
+	w.Events = strings.Fields(events)
+	w.CreatedAt = fromNanos(createdAt)
+	return w, err
+}
+
+func (sq *SQLStore) GetWebhook(ctx context.Context, id string) (Common.Webhook, error) {
+	webhook, err := scanWebhook(sq.db.QueryRowContext(ctx, `SELECT `+webhookColumns+` FROM webhooks WHERE id = ?`, id))
+	if errors.Is(err, sql.ErrNoRows) {
+		return Common.Webhook{}, ErrNotFound
+	}
+	return webhook, err
+}
+
+func (sq *SQLStore) ListWebhooks(ctx context.Context) ([]Common.Webhook, error) {
+	rows, err := sq.db.QueryContext(ctx, `SELECT `+webhookColumns+` FROM webhooks ORDER BY created_at, id`)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var result []Common.Webhook
+	for rows.Next() {
+		webhook, err := scanWebhook(rows)
+		if err != nil {
+			return nil, err
+		}
+		result = append(result, webhook)
+	}
+	return result, rows.Err()
+}
+
+func (sq *SQLStore) DeleteWebhook(ctx context.Context, id string) error {
+	res, err := sq.db.ExecContext(ctx, `DELETE FROM webhooks WHERE id = ?`, id)
+	if err != nil {
+		return err
+	}
+	if n, err := res.RowsAffected(); err != nil {
+		return err
+	} else if n == 0 {
+		return ErrNotFound
+	}
+	return nil
+}
+
+func (sq *SQLStore) AddWebhookDelivery(ctx context.Context, d Common.WebhookDelivery) error {
+	_, err := sq.db.ExecContext(ctx, `INSERT INTO webhook_deliveries (id, webhook_id, event, payload, status, attempts, response_code, error, created_at, last_attempt_at) VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?)`,
+		d.ID, d.WebhookID, d.Event, d.Payload, d.Status, d.Attempts, d.ResponseCode, d.Error, toNanos(d.CreatedAt), toNanos(d.LastAttemptAt))
+	return err
+}
+
+// deliveryColumns is the column list scanned by scanDelivery.
+const deliveryColumns = `id, webhook_id, event, payload, status, attempts, response_code, error, created_at, last_attempt_at`
+
+func scanDelivery(row scanner) (Common.WebhookDelivery, error) {
+	var d Common.WebhookDelivery
+	var createdAt, lastAttemptAt int64
+	err := row.Scan(&d.ID, &d.WebhookID, &d.Event, &d.Payload, &d.Status, &d.Attempts, &d.ResponseCode, &d.Error, &createdAt, &lastAttemptAt)
+	d.CreatedAt = fromNanos(createdAt)
+	d.LastAttemptAt = fromNanos(lastAttemptAt)
+	return d, err
+}
+
+func (sq *SQLStore) GetWebhookDelivery(ctx context.Context, id string) (Common.WebhookDelivery, error) {
+	return getDelivery(ctx, sq.db, id)
+}
+
+func getDelivery(ctx context.Context, q querier, id string) (Common.WebhookDelivery, error) {
+	d, err := scanDelivery(q.QueryRowContext(ctx, `SELECT `+deliveryColumns+` FROM webhook_deliveries WHERE id = ?`, id))
+	if errors.Is(err, sql.ErrNoRows) {
+		return Common.WebhookDelivery{}, ErrNotFound
+	}
+	return d, err
+}
+
+func (sq *SQLStore) UpdateWebhookDelivery(ctx context.Context, id string, update func(*Common.WebhookDelivery) error) (Common.WebhookDelivery, error) {
+	tx, err := sq.db.BeginTx(ctx, nil)
+	if err != nil {
+		return Common.WebhookDelivery{}, err
+	}
+	defer tx.Rollback()
+
+	d, err := getDelivery(ctx, tx, id)
+	if err != nil {
+		return Common.WebhookDelivery{}, err
+	}
+	if err := update(&d); err != nil {
+		return Common.WebhookDelivery{}, err
+	}
+	_, err = tx.ExecContext(ctx, `UPDATE webhook_deliveries SET status = ?, attempts = ?, response_code = ?, error = ?, last_attempt_at = ? WHERE id = ?`,
+		d.Status, d.Attempts, d.ResponseCode, d.Error, toNanos(d.LastAttemptAt), id)
+	if err != nil {
+		return Common.WebhookDelivery{}, err
+	}
+	return d, tx.Commit()
+}
+
+func (sq *SQLStore) ListWebhookDeliveries(ctx context.Context, filter DeliveryFilter) ([]Common.WebhookDelivery, error) {
+	var conditions []string
+	var args []interface{}
+	if filter.WebhookID != "" {
+		conditions = append(conditions, "webhook_id = ?")
+		args = append(args, filter.WebhookID)
+	}
+	if filter.Status != "" {
+		conditions = append(conditions, "status = ?")
+		args = append(args, filter.Status)
+	}
+	query := `SELECT ` + deliveryColumns + ` FROM webhook_deliveries`
+	if len(conditions) > 0 {
+		query += " WHERE " + strings.Join(conditions, " AND ")
+	}
+	query += " ORDER BY created_at DESC, id DESC"
+	if filter.Limit > 0 {
+		query += " LIMIT ?"
+		args = append(args, filter.Limit)
+	}
+
+	rows, err := sq.db.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var result []Common.WebhookDelivery
+	for rows.Next() {
+		d, err := scanDelivery(rows)
+		if err != nil {
+			return nil, err
+		}
+		result = append(result, d)
+	}
+	return result, rows.Err()
+}
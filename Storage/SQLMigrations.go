@@ -0,0 +1,181 @@
+// Storage/SQLMigrations.go
+package Storage
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+)
+
+// sqlMigrations holds the schema of the SQL backend, one step per entry.
+// Steps are applied in order and recorded in schema_migrations, so a step must
+// never be edited once released: append a new one instead.
+var sqlMigrations = []string{
+	// 1: users, complaints and the per-user complaint list.
+	`CREATE TABLE users (
+		id          TEXT PRIMARY KEY,
+		secret_code TEXT NOT NULL,
+		name        TEXT NOT NULL,
+		email       TEXT NOT NULL
+	);
+	CREATE UNIQUE INDEX idx_users_email ON users(email);
+	CREATE UNIQUE INDEX idx_users_secret_code ON users(secret_code);
+
+	CREATE TABLE complaints (
+		id       TEXT PRIMARY KEY,
+		user_id  TEXT NOT NULL,
+		title    TEXT NOT NULL,
+		summary  TEXT NOT NULL,
+		severity INTEGER NOT NULL,
+		resolved INTEGER NOT NULL DEFAULT 0
+	);
+	CREATE INDEX idx_complaints_user_id ON complaints(user_id);
+
+	-- Mirrors the User.Complaints array of the Firestore documents, so it may
+	-- reference complaints that do not exist.
+	CREATE TABLE user_complaints (
+		seq          INTEGER PRIMARY KEY AUTOINCREMENT,
+		user_id      TEXT NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+		complaint_id TEXT NOT NULL,
+		UNIQUE (user_id, complaint_id)
+	);`,
+
+	// 2: complaint status lifecycle. Existing rows derive their status from resolved.
+	`ALTER TABLE complaints ADD COLUMN status TEXT NOT NULL DEFAULT '';
+	UPDATE complaints SET status = CASE resolved WHEN 1 THEN 'Resolved' ELSE 'Open' END;
+
+	CREATE TABLE complaint_status_history (
+		complaint_id TEXT NOT NULL REFERENCES complaints(id) ON DELETE CASCADE,
+		seq          INTEGER NOT NULL,
+		from_status  TEXT NOT NULL,
+		to_status    TEXT NOT NULL,
+		changed_by   TEXT NOT NULL,
+		changed_at   INTEGER NOT NULL, -- Unix nanoseconds, UTC
+		note         TEXT NOT NULL,
+		PRIMARY KEY (complaint_id, seq)
+	);`,
+
+	// 3: comment threads.
+	`CREATE TABLE comments (
+		id           TEXT PRIMARY KEY,
+		complaint_id TEXT NOT NULL REFERENCES complaints(id) ON DELETE CASCADE,
+		author_id    TEXT NOT NULL,
+		role         TEXT NOT NULL,
+		body         TEXT NOT NULL,
+		created_at   INTEGER NOT NULL -- Unix nanoseconds, UTC
+	);
+	CREATE INDEX idx_comments_complaint_id ON comments(complaint_id, created_at);`,
+
+	// 4: user roles. Existing users are customers.
+	`ALTER TABLE users ADD COLUMN role TEXT NOT NULL DEFAULT 'customer';`,
+
+	// 5: secret code recovery and the audit log.
+	`ALTER TABLE users ADD COLUMN recovery_hash TEXT NOT NULL DEFAULT '';
+	ALTER TABLE users ADD COLUMN recovery_expires_at INTEGER NOT NULL DEFAULT 0; -- Unix nanoseconds, UTC
+
+	CREATE TABLE audit_log (
+		id        TEXT PRIMARY KEY,
+		at        INTEGER NOT NULL, -- Unix nanoseconds, UTC
+		actor_id  TEXT NOT NULL,
+		action    TEXT NOT NULL,
+		target_id TEXT NOT NULL,
+		details   TEXT NOT NULL
+	);
+	CREATE INDEX idx_audit_log_at ON audit_log(at);`,
+
+	// 6: audit entries of every mutating call, with the fields they changed.
+	// The free-form details are replaced by the changes.
+	`ALTER TABLE audit_log DROP COLUMN details;
+	ALTER TABLE audit_log ADD COLUMN method TEXT NOT NULL DEFAULT '';
+	ALTER TABLE audit_log ADD COLUMN client_addr TEXT NOT NULL DEFAULT '';
+	CREATE INDEX idx_audit_log_actor_id ON audit_log(actor_id, at);
+	CREATE INDEX idx_audit_log_target_id ON audit_log(target_id, at);
+
+	CREATE TABLE audit_changes (
+		entry_id     TEXT NOT NULL REFERENCES audit_log(id) ON DELETE CASCADE,
+		seq          INTEGER NOT NULL,
+		field        TEXT NOT NULL,
+		before_value TEXT NOT NULL,
+		after_value  TEXT NOT NULL,
+		PRIMARY KEY (entry_id, seq)
+	);`,
+
+	// 7: creation, update and resolution times, in Unix nanoseconds (0 when unknown).
+	// Existing complaints take them from their status history; existing users have
+	// no such record and keep 0.
+	`ALTER TABLE users ADD COLUMN created_at INTEGER NOT NULL DEFAULT 0;
+	ALTER TABLE users ADD COLUMN updated_at INTEGER NOT NULL DEFAULT 0;
+	ALTER TABLE complaints ADD COLUMN created_at INTEGER NOT NULL DEFAULT 0;
+	ALTER TABLE complaints ADD COLUMN updated_at INTEGER NOT NULL DEFAULT 0;
+	ALTER TABLE complaints ADD COLUMN resolved_at INTEGER NOT NULL DEFAULT 0;
+
+	UPDATE complaints SET
+		created_at = COALESCE((SELECT MIN(changed_at) FROM complaint_status_history h WHERE h.complaint_id = complaints.id), 0),
+		updated_at = COALESCE((SELECT MAX(changed_at) FROM complaint_status_history h WHERE h.complaint_id = complaints.id), 0),
+		resolved_at = CASE resolved WHEN 1 THEN COALESCE((SELECT MAX(changed_at) FROM complaint_status_history h
+			WHERE h.complaint_id = complaints.id AND h.to_status IN ('Resolved', 'Closed')), 0) ELSE 0 END;`,
+
+	// 8: indexes for the paged complaint listings.
+	`CREATE INDEX idx_complaints_created_at ON complaints(created_at, id);
+	CREATE INDEX idx_complaints_severity ON complaints(severity, id);
+	CREATE INDEX idx_complaints_user_created_at ON complaints(user_id, created_at, id);`,
+
+	// 9: webhooks and their delivery log. Event types are stored space-separated.
+	// Deliveries outlive their webhook.
+	`CREATE TABLE webhooks (
+		id         TEXT PRIMARY KEY,
+		url        TEXT NOT NULL,
+		events     TEXT NOT NULL,
+		secret     TEXT NOT NULL,
+		created_by TEXT NOT NULL,
+		created_at INTEGER NOT NULL
+	);
+	CREATE TABLE webhook_deliveries (
+		id              TEXT PRIMARY KEY,
+		webhook_id      TEXT NOT NULL,
+		event           TEXT NOT NULL,
+		payload         TEXT NOT NULL,
+		status          TEXT NOT NULL,
+		attempts        INTEGER NOT NULL,
+		response_code   INTEGER NOT NULL,
+		error           TEXT NOT NULL,
+		created_at      INTEGER NOT NULL,
+		last_attempt_at INTEGER NOT NULL
+	);
+	CREATE INDEX idx_webhook_deliveries_created_at ON webhook_deliveries(created_at, id);
+	CREATE INDEX idx_webhook_deliveries_webhook ON webhook_deliveries(webhook_id, created_at, id);`,
+
+	// 10: the generation of a user's sessions, bumped to end them all.
+	`ALTER TABLE users ADD COLUMN session_generation INTEGER NOT NULL DEFAULT 0;`,
+}
+
+// migrate brings the database schema up to date.
+func migrate(ctx context.Context, db *sql.DB) error {
+	if _, err := db.ExecContext(ctx, `CREATE TABLE IF NOT EXISTS schema_migrations (version INTEGER PRIMARY KEY)`); err != nil {
+		return fmt.Errorf("creating schema_migrations: %w", err)
+	}
+
+	var current int
+	if err := db.QueryRowContext(ctx, `SELECT COALESCE(MAX(version), 0) FROM schema_migrations`).Scan(&current); err != nil {
+		return fmt.Errorf("reading schema version: %w", err)
+	}
+
+	for version := current + 1; version <= len(sqlMigrations); version++ {
+		tx, err := db.BeginTx(ctx, nil)
+		if err != nil {
+			return err
+		}
+		if _, err := tx.ExecContext(ctx, sqlMigrations[version-1]); err != nil {
+			tx.Rollback()
+			return fmt.Errorf("applying migration %d: %w", version, err)
+		}
+		if _, err := tx.ExecContext(ctx, `INSERT INTO schema_migrations (version) VALUES (?)`, version); err != nil {
+			tx.Rollback()
+			return fmt.Errorf("recording migration %d: %w", version, err)
+		}
+		if err := tx.Commit(); err != nil {
+			return fmt.Errorf("committing migration %d: %w", version, err)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,166 @@
+// Storage/Storage.go
+package Storage
+
+import (
+	"complaint-portal/Common"
+	"context"
+	"errors"
+	"fmt"
+	"time"
+)
+
+// ErrNotFound is returned by a Store when the requested user or complaint does not exist.
+var ErrNotFound = errors.New("not found")
+
+// Store is the persistence layer used by the ComplaintService.
+// Every backend (Firestore, in-memory, ...) must implement it with the same behavior.
+type Store interface {
+	// CreateUser saves a new user. The caller is responsible for generating the ID.
+	CreateUser(ctx context.Context, user Common.User) error
+	// GetUser returns the user with the given ID, or ErrNotFound.
+	GetUser(ctx context.Context, id string) (Common.User, error)
+	// GetUsers returns the users with the given IDs, keyed by ID, in as few round
+	// trips as the backend allows. IDs without a user are left out of the map.
+	GetUsers(ctx context.Context, ids []string) (map[string]Common.User, error)
+	// FindUserByEmail returns the user registered with the given email, or ErrNotFound.
+	FindUserByEmail(ctx context.Context, email string) (Common.User, error)
+	// FindUserBySecretHash returns the user whose secret code has the given hash, or ErrNotFound.
+	FindUserBySecretHash(ctx context.Context, secretHash string) (Common.User, error)
+	// ListUsers returns every user.
+	ListUsers(ctx context.Context) ([]Common.User, error)
+	// SetUserComplaints replaces the user's Complaints list. It is used to repair
+	// lists that have drifted from the complaints collection.
+	SetUserComplaints(ctx context.Context, userID string, complaintIDs []string) error
+	// SetUserSecretHash replaces the hash of the user's secret code.
+	// It returns ErrNotFound if the user does not exist.
+	SetUserSecretHash(ctx context.Context, userID string, secretHash string) error
+	// UpdateUser reads the user, applies update to it and saves the result,
+	// atomically with respect to other writers. If update returns an error, nothing
+	// is saved and that error is returned unchanged. It returns ErrNotFound if the
+	// user does not exist. update must not change the ID or the Complaints list.
+	UpdateUser(ctx context.Context, id string, update func(*Common.User) error) (Common.User, error)
+
+	// CreateComplaint saves a new complaint and appends its ID to the owner's Complaints
+	// list as a single atomic operation: either both writes happen or neither does.
+	// It returns ErrNotFound if the owner does not exist.
+	// The caller is responsible for generating the ID.
+	CreateComplaint(ctx context.Context, complaint Common.Complaint) error
+	// GetComplaint returns the complaint with the given ID, or ErrNotFound.
+	GetComplaint(ctx context.Context, id string) (Common.Complaint, error)
+	// ListComplaints returns every complaint.
+	ListComplaints(ctx context.Context) ([]Common.Complaint, error)
+	// ListUserComplaints returns every complaint submitted by the given user.
+	ListUserComplaints(ctx context.Context, userID string) ([]Common.Complaint, error)
+	// QueryComplaints returns the complaints kept by the query's filters, in its
+	// order, starting after its cursor and up to its limit.
+	QueryComplaints(ctx context.Context, query ComplaintQuery) ([]Common.Complaint, error)
+	// UpdateComplaint reads the complaint, applies update to it and saves the result,
+	// atomically with respect to other writers. If update returns an error, nothing is
+	// saved and that error is returned unchanged. It returns ErrNotFound if the
+	// complaint does not exist.
+	UpdateComplaint(ctx context.Context, id string, update func(*Common.Complaint) error) (Common.Complaint, error)
+
+	// AddComment appends a comment to its complaint's thread.
+	// It returns ErrNotFound if the complaint does not exist.
+	AddComment(ctx context.Context, comment Common.Comment) error
+	// ListComments returns the complaint's thread, oldest comment first.
+	ListComments(ctx context.Context, complaintID string) ([]Common.Comment, error)
+
+	// AddAuditEntry appends an entry to the audit log.
+	AddAuditEntry(ctx context.Context, entry Common.AuditEntry) error
+	// ListAuditEntries returns the audit entries selected by filter, oldest first.
+	// Entries are never updated or deleted.
+	ListAuditEntries(ctx context.Context, filter AuditFilter) ([]Common.AuditEntry, error)
+
+	// CreateWebhook saves a new webhook. The caller is responsible for generating the ID.
+	CreateWebhook(ctx context.Context, webhook Common.Webhook) error
+	// GetWebhook returns the webhook with the given ID, or ErrNotFound.
+	GetWebhook(ctx context.Context, id string) (Common.Webhook, error)
+	// ListWebhooks returns every webhook, oldest first.
+	ListWebhooks(ctx context.Context) ([]Common.Webhook, error)
+	// DeleteWebhook removes a webhook, keeping its deliveries. It returns
+	// ErrNotFound if the webhook does not exist.
+	DeleteWebhook(ctx context.Context, id string) error
+
+	// AddWebhookDelivery saves a new delivery. The caller is responsible for generating the ID.
+	AddWebhookDelivery(ctx context.Context, delivery Common.WebhookDelivery) error
+	// GetWebhookDelivery returns the delivery with the given ID, or ErrNotFound.
+	GetWebhookDelivery(ctx context.Context, id string) (Common.WebhookDelivery, error)
+	// UpdateWebhookDelivery reads the delivery, applies update to it and saves the
+	// result, like UpdateComplaint. It returns ErrNotFound if the delivery does not exist.
+	UpdateWebhookDelivery(ctx context.Context, id string, update func(*Common.WebhookDelivery) error) (Common.WebhookDelivery, error)
+	// ListWebhookDeliveries returns the deliveries selected by filter, newest first.
+	ListWebhookDeliveries(ctx context.Context, filter DeliveryFilter) ([]Common.WebhookDelivery, error)
+
+	// Ping checks that the store can serve requests. It backs the readiness
+	// reported by the health service.
+	Ping(ctx context.Context) error
+	// Close releases any resources held by the store.
+	Close() error
+}
+
+// AuditFilter selects audit entries. Empty fields match every entry.
+type AuditFilter struct {
+	ActorID  string
+	TargetID string
+	// Since is the earliest time selected, and Until the first time past the range.
+	Since time.Time
+	Until time.Time
+}
+
+// Matches reports whether the filter selects the entry.
+func (f AuditFilter) Matches(entry Common.AuditEntry) bool {
+	return (f.ActorID == "" || entry.ActorID == f.ActorID) &&
+		(f.TargetID == "" || entry.TargetID == f.TargetID) &&
+		(f.Since.IsZero() || !entry.At.Before(f.Since)) &&
+		(f.Until.IsZero() || entry.At.Before(f.Until))
+}
+
+// DeliveryFilter selects webhook deliveries. Empty fields match every delivery.
+type DeliveryFilter struct {
+	WebhookID string
+	Status    Common.DeliveryStatus
+	// Limit is the most deliveries returned; 0 means no limit.
+	Limit int
+}
+
+// Matches reports whether the filter selects the delivery, ignoring Limit.
+func (f DeliveryFilter) Matches(d Common.WebhookDelivery) bool {
+	return (f.WebhookID == "" || d.WebhookID == f.WebhookID) &&
+		(f.Status == "" || d.Status == f.Status)
+}
+
+// Supported values for the storage backend setting.
+const (
+	BackendFirestore = "firestore"
+	BackendMemory    = "memory"
+	BackendSQLite    = "sqlite"
+)
+
+// Options selects and configures the storage backend.
+type Options struct {
+	Backend string
+	// SQLitePath is the database file used by the SQLite backend.
+	SQLitePath string
+	// Collections are the collections used by the Firestore backend. The zero
+	// value stands for DefaultCollections.
+	Collections Collections
+}
+
+// Open returns the Store for the configured backend.
+// The Firestore backend uses Common.FirestoreClient, so Common.InitFirebase must be called first.
+func Open(opts Options) (Store, error) {
+	switch opts.Backend {
+	case BackendFirestore:
+		if opts.Collections == (Collections{}) {
+			opts.Collections = DefaultCollections
+		}
+		return NewFirestoreStore(Common.FirestoreClient, opts.Collections), nil
+	case BackendMemory:
+		return NewMemoryStore(), nil
+	case BackendSQLite:
+		return OpenSQLite(opts.SQLitePath)
+	default:
+		return nil, fmt.Errorf("unknown storage backend %q", opts.Backend)
+	}
+}
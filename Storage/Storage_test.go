@@ -0,0 +1,508 @@
+// Storage/Storage_test.go
+package Storage
+
+import (
+	"complaint-portal/Common"
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"strings"
+	"testing"
+	"time"
+)
+
+// runStoreTests exercises the behavior every Store implementation must share.
+func runStoreTests(t *testing.T, store Store) {
+	ctx := context.Background()
+
+	created := time.Date(2024, 12, 31, 8, 0, 0, 7, time.UTC)
+	user := Common.User{ID: "u1", SecretHash: "secret-1", Name: "Store User", Email: "store@example.com", Complaints: []string{}, CreatedAt: created, UpdatedAt: created}
+	if err := store.CreateUser(ctx, user); err != nil {
+		t.Fatalf("Expected no error creating user, but got: %v", err)
+	}
+
+	// Users can be found by ID, email and secret code.
+	if got, err := store.GetUser(ctx, "u1"); err != nil || got.Name != user.Name || !got.CreatedAt.Equal(created) || !got.UpdatedAt.Equal(created) {
+		t.Errorf("Expected GetUser to return '%s' with its timestamps, got %+v (err: %v)", user.Name, got, err)
+	}
+	if got, err := store.FindUserByEmail(ctx, user.Email); err != nil || got.ID != user.ID {
+		t.Errorf("Expected FindUserByEmail to return user '%s', got '%s' (err: %v)", user.ID, got.ID, err)
+	}
+	if got, err := store.FindUserBySecretHash(ctx, user.SecretHash); err != nil || got.ID != user.ID {
+		t.Errorf("Expected FindUserBySecretHash to return user '%s', got '%s' (err: %v)", user.ID, got.ID, err)
+	}
+
+	// Users can be fetched in a batch, which leaves out the missing ones.
+	if got, err := store.GetUsers(ctx, []string{"u1", "missing", "u1"}); err != nil || len(got) != 1 || got["u1"].Email != user.Email || !got["u1"].CreatedAt.Equal(created) {
+		t.Errorf("Expected GetUsers to return only u1, but got %+v (err: %v)", got, err)
+	}
+	if got, err := store.GetUsers(ctx, nil); err != nil || len(got) != 0 {
+		t.Errorf("Expected no users for no IDs, but got %+v (err: %v)", got, err)
+	}
+
+	// Missing users are reported as ErrNotFound.
+	if _, err := store.GetUser(ctx, "missing"); !errors.Is(err, ErrNotFound) {
+		t.Errorf("Expected ErrNotFound for a missing user, but got: %v", err)
+	}
+	if _, err := store.FindUserBySecretHash(ctx, "wrong"); !errors.Is(err, ErrNotFound) {
+		t.Errorf("Expected ErrNotFound for an unknown secret code, but got: %v", err)
+	}
+
+	// Secret hashes can be replaced, and the old one stops matching.
+	if err := store.SetUserSecretHash(ctx, user.ID, "secret-1b"); err != nil {
+		t.Fatalf("Expected no error setting the secret hash, but got: %v", err)
+	}
+	if got, err := store.FindUserBySecretHash(ctx, "secret-1b"); err != nil || got.ID != user.ID {
+		t.Errorf("Expected the new secret hash to find user '%s', got '%s' (err: %v)", user.ID, got.ID, err)
+	}
+	if _, err := store.FindUserBySecretHash(ctx, user.SecretHash); !errors.Is(err, ErrNotFound) {
+		t.Errorf("Expected the old secret hash to stop matching, but got: %v", err)
+	}
+	if err := store.SetUserSecretHash(ctx, "missing", "x"); !errors.Is(err, ErrNotFound) {
+		t.Errorf("Expected ErrNotFound setting the secret hash of a missing user, but got: %v", err)
+	}
+
+	// Complaints are stored and linked to their owner in the same operation.
+	other := Common.User{ID: "u2", SecretHash: "secret-2", Name: "Other User", Email: "other@example.com", Complaints: []string{}}
+	if err := store.CreateUser(ctx, other); err != nil {
+		t.Fatalf("Expected no error creating user, but got: %v", err)
+	}
+	for _, c := range []Common.Complaint{
+		{ID: "c1", Title: "First", Severity: 2, UserID: "u1"},
+		{ID: "c2", Title: "Second", Severity: 4, UserID: "u1", CreatedAt: created, UpdatedAt: created},
+		{ID: "c3", Title: "Other", Severity: 1, UserID: "u2"},
+	} {
+		if err := store.CreateComplaint(ctx, c); err != nil {
+			t.Fatalf("Expected no error creating complaint %s, but got: %v", c.ID, err)
+		}
+	}
+	if got, _ := store.GetUser(ctx, "u1"); len(got.Complaints) != 2 || got.Complaints[0] != "c1" || got.Complaints[1] != "c2" {
+		t.Errorf("Expected user to list complaints [c1 c2], but got %v", got.Complaints)
+	}
+	if got, _ := store.GetUsers(ctx, []string{"u1", "u2"}); len(got["u1"].Complaints) != 2 || got["u1"].Complaints[1] != "c2" || len(got["u2"].Complaints) != 1 {
+		t.Errorf("Expected GetUsers to return the complaint lists, but got %+v", got)
+	}
+
+	// A complaint for a missing user is rejected and nothing is written.
+	if err := store.CreateComplaint(ctx, Common.Complaint{ID: "c4", UserID: "missing"}); !errors.Is(err, ErrNotFound) {
+		t.Errorf("Expected ErrNotFound creating a complaint for a missing user, but got: %v", err)
+	}
+	if _, err := store.GetComplaint(ctx, "c4"); !errors.Is(err, ErrNotFound) {
+		t.Errorf("Expected the rejected complaint not to be stored, but got: %v", err)
+	}
+
+	all, err := store.ListComplaints(ctx)
+	if err != nil || len(all) != 3 {
+		t.Errorf("Expected 3 complaints in total, got %d (err: %v)", len(all), err)
+	}
+	mine, err := store.ListUserComplaints(ctx, "u1")
+	if err != nil || len(mine) != 2 {
+		t.Errorf("Expected 2 complaints for u1, got %d (err: %v)", len(mine), err)
+	}
+
+	// Updates are saved together with the status history they add.
+	at := time.Date(2025, 1, 2, 3, 4, 5, 6, time.UTC)
+	for _, to := range []Common.ComplaintStatus{Common.StatusInProgress, Common.StatusResolved} {
+		_, err := store.UpdateComplaint(ctx, "c2", func(c *Common.Complaint) error {
+			c.SetStatus(to, "agent", "note", at)
+			return nil
+		})
+		if err != nil {
+			t.Fatalf("Expected no error updating complaint, but got: %v", err)
+		}
+	}
+	got, _ := store.GetComplaint(ctx, "c2")
+	if !got.Resolved || got.Status != Common.StatusResolved || len(got.StatusHistory) != 2 {
+		t.Errorf("Expected complaint c2 to be resolved with 2 history entries, but got %+v", got)
+	} else if !got.CreatedAt.Equal(created) || !got.UpdatedAt.Equal(at) || !got.ResolvedAt.Equal(at) {
+		t.Errorf("Expected complaint c2 to keep its timestamps, but got %+v", got)
+	} else if h := got.StatusHistory[1]; h.From != Common.StatusInProgress || h.ChangedBy != "agent" || !h.ChangedAt.Equal(at) {
+		t.Errorf("Expected the last history entry to be recorded as written, but got %+v", h)
+	}
+
+	// A failing update saves nothing and returns its error unchanged.
+	errAbort := errors.New("abort")
+	_, err = store.UpdateComplaint(ctx, "c1", func(c *Common.Complaint) error {
+		c.Title = "Changed"
+		return errAbort
+	})
+	if !errors.Is(err, errAbort) {
+		t.Errorf("Expected the update's error to be returned, but got: %v", err)
+	}
+	if got, _ := store.GetComplaint(ctx, "c1"); got.Title != "First" {
+		t.Errorf("Expected an aborted update to leave the title unchanged, but got '%s'", got.Title)
+	}
+	if _, err := store.UpdateComplaint(ctx, "missing", func(c *Common.Complaint) error { return nil }); !errors.Is(err, ErrNotFound) {
+		t.Errorf("Expected ErrNotFound updating a missing complaint, but got: %v", err)
+	}
+
+	// Comments are returned oldest first, and need an existing complaint.
+	for i, body := range []string{"second", "first"} {
+		comment := Common.Comment{ID: "m" + body, ComplaintID: "c1", AuthorID: "u1", Role: Common.CommentByCustomer, Body: body, CreatedAt: at.Add(-time.Duration(i) * time.Minute)}
+		if err := store.AddComment(ctx, comment); err != nil {
+			t.Fatalf("Expected no error adding a comment, but got: %v", err)
+		}
+	}
+	if err := store.AddComment(ctx, Common.Comment{ID: "m3", ComplaintID: "missing"}); !errors.Is(err, ErrNotFound) {
+		t.Errorf("Expected ErrNotFound commenting on a missing complaint, but got: %v", err)
+	}
+	comments, err := store.ListComments(ctx, "c1")
+	if err != nil || len(comments) != 2 || comments[0].Body != "first" || !comments[1].CreatedAt.Equal(at) {
+		t.Errorf("Expected the thread [first second], but got %+v (err: %v)", comments, err)
+	}
+	if comments, _ := store.ListComments(ctx, "c2"); len(comments) != 0 {
+		t.Errorf("Expected no comments on c2, but got %d", len(comments))
+	}
+
+	// Repair fixes lists that drifted from the complaints collection.
+	if err := store.SetUserComplaints(ctx, "u1", []string{"c1", "gone", "c3"}); err != nil {
+		t.Fatalf("Expected no error setting the complaint list, but got: %v", err)
+	}
+	report, err := Repair(ctx, store, true)
+	if err != nil {
+		t.Fatalf("Expected no error in a dry run repair, but got: %v", err)
+	}
+	if len(report.Orphans) != 1 || len(report.Dangling["u1"]) != 2 || report.UsersUpdated != 1 {
+		t.Errorf("Expected 1 orphan and 2 dangling IDs on 1 user, but got %+v", report)
+	}
+	if got, _ := store.GetUser(ctx, "u1"); len(got.Complaints) != 3 {
+		t.Errorf("Expected a dry run to leave the list untouched, but got %v", got.Complaints)
+	}
+	if _, err := Repair(ctx, store, false); err != nil {
+		t.Fatalf("Expected no error repairing, but got: %v", err)
+	}
+	if got, _ := store.GetUser(ctx, "u1"); len(got.Complaints) != 2 || got.Complaints[0] != "c1" || got.Complaints[1] != "c2" {
+		t.Errorf("Expected the repaired list to be [c1 c2], but got %v", got.Complaints)
+	}
+	if report, _ := Repair(ctx, store, true); report.UsersUpdated != 0 {
+		t.Errorf("Expected nothing left to repair, but got %+v", report)
+	}
+
+	// UpdateUser saves the changes, keeps the complaint list, and saves nothing on error.
+	updated, err := store.UpdateUser(ctx, "u1", func(u *Common.User) error {
+		u.RecoveryHash = "recovery"
+		u.RecoveryExpiresAt = at
+		u.SessionGeneration = 2
+		u.Role = Common.RoleAgent
+		u.UpdatedAt = at
+		return nil
+	})
+	if err != nil || updated.RecoveryHash != "recovery" {
+		t.Fatalf("Expected no error updating the user, but got: %v", err)
+	}
+	gotUser, _ := store.GetUser(ctx, "u1")
+	if gotUser.RecoveryHash != "recovery" || !gotUser.RecoveryExpiresAt.Equal(at) || gotUser.Role != Common.RoleAgent || gotUser.SessionGeneration != 2 || len(gotUser.Complaints) != 2 ||
+		!gotUser.CreatedAt.Equal(created) || !gotUser.UpdatedAt.Equal(at) {
+		t.Errorf("Expected the update to be saved with the complaint list intact, but got %+v", gotUser)
+	}
+	if _, err := store.UpdateUser(ctx, "u1", func(u *Common.User) error {
+		u.RecoveryHash = ""
+		return errAbort
+	}); !errors.Is(err, errAbort) {
+		t.Errorf("Expected the update's error to be returned, but got: %v", err)
+	}
+	if got, _ := store.GetUser(ctx, "u1"); got.RecoveryHash != "recovery" {
+		t.Errorf("Expected an aborted update to save nothing, but got %+v", got)
+	}
+	if _, err := store.UpdateUser(ctx, "missing", func(*Common.User) error { return nil }); !errors.Is(err, ErrNotFound) {
+		t.Errorf("Expected ErrNotFound updating a missing user, but got: %v", err)
+	}
+
+	// Audit entries are listed oldest first, with their changes, and can be filtered.
+	for i, action := range []string{"third", "second", "first"} {
+		entry := Common.AuditEntry{ID: "a" + action, At: at.Add(-time.Duration(i) * time.Minute), ActorID: "u1", Action: action, TargetID: "u2",
+			Method: "/m", ClientAddr: "192.0.2.1", Changes: []Common.AuditChange{{Field: "Name", Before: "", After: action}, {Field: "Role", Before: "", After: "agent"}}}
+		if i == 2 {
+			entry.ActorID = "u2"
+		}
+		if err := store.AddAuditEntry(ctx, entry); err != nil {
+			t.Fatalf("Expected no error adding an audit entry, but got: %v", err)
+		}
+	}
+	entries, err := store.ListAuditEntries(ctx, AuditFilter{})
+	if err != nil || len(entries) != 3 || entries[0].Action != "first" || !entries[2].At.Equal(at) || entries[2].TargetID != "u2" || entries[2].ClientAddr != "192.0.2.1" {
+		t.Errorf("Expected the audit log [first second third], but got %+v (err: %v)", entries, err)
+	}
+	if len(entries) == 3 && (len(entries[1].Changes) != 2 || entries[1].Changes[0] != (Common.AuditChange{Field: "Name", After: "second"}) || entries[1].Changes[1].Field != "Role") {
+		t.Errorf("Expected the changes to be kept in order, but got %+v", entries[1].Changes)
+	}
+	for _, tc := range []struct {
+		filter AuditFilter
+		want   string
+	}{
+		{AuditFilter{ActorID: "u1"}, "second,third"},
+		{AuditFilter{ActorID: "u2", TargetID: "u2"}, "first"},
+		{AuditFilter{TargetID: "u1"}, ""},
+		{AuditFilter{Since: at.Add(-time.Minute)}, "second,third"},
+		{AuditFilter{Since: at.Add(-2 * time.Minute), Until: at}, "first,second"},
+	} {
+		entries, err := store.ListAuditEntries(ctx, tc.filter)
+		var actions []string
+		for _, e := range entries {
+			actions = append(actions, e.Action)
+		}
+		if err != nil || strings.Join(actions, ",") != tc.want {
+			t.Errorf("Expected %+v to select [%s], but got %v (err: %v)", tc.filter, tc.want, actions, err)
+		}
+	}
+}
+
+// runQueryTests exercises QueryComplaints on complaints of a user of their own.
+func runQueryTests(t *testing.T, store Store) {
+	ctx := context.Background()
+	if err := store.CreateUser(ctx, Common.User{ID: "uq", SecretHash: "secret-q", Name: "Query User", Email: "query@example.com"}); err != nil {
+		t.Fatalf("Expected no error creating user, but got: %v", err)
+	}
+	base := time.Date(2024, 2, 1, 0, 0, 0, 0, time.UTC)
+	// q1..q5 are created a day apart, with severities 3, 1, 3, 5, 3.
+	for i, severity := range []int{3, 1, 3, 5, 3} {
+		c := Common.Complaint{ID: fmt.Sprintf("q%d", i+1), Title: "Q", Severity: severity, UserID: "uq", CreatedAt: base.Add(time.Duration(i) * 24 * time.Hour)}
+		c.SetStatus(Common.StatusOpen, "uq", "", c.CreatedAt)
+		if i == 1 {
+			c.SetStatus(Common.StatusResolved, "agent", "", c.CreatedAt)
+		}
+		if i == 2 {
+			c.SetStatus(Common.StatusInProgress, "agent", "", c.CreatedAt)
+		}
+		if err := store.CreateComplaint(ctx, c); err != nil {
+			t.Fatalf("Expected no error creating complaint %s, but got: %v", c.ID, err)
+		}
+	}
+	ids := func(q ComplaintQuery) string {
+		t.Helper()
+		q.UserID = "uq"
+		complaints, err := store.QueryComplaints(ctx, q)
+		if err != nil {
+			t.Fatalf("Expected no error querying %+v, but got: %v", q, err)
+		}
+		var result []string
+		for _, c := range complaints {
+			result = append(result, c.ID)
+		}
+		return strings.Join(result, ",")
+	}
+	resolved, unresolved := true, false
+
+	for _, tc := range []struct {
+		name  string
+		query ComplaintQuery
+		want  string
+	}{
+		{"newest first by default", ComplaintQuery{}, "q5,q4,q3,q2,q1"},
+		{"oldest first", ComplaintQuery{Sort: OldestFirst}, "q1,q2,q3,q4,q5"},
+		{"severity ties broken by ID", ComplaintQuery{Sort: HighestSeverityFirst}, "q4,q5,q3,q1,q2"},
+		{"lowest severity first", ComplaintQuery{Sort: LowestSeverityFirst}, "q2,q1,q3,q5,q4"},
+		{"limited", ComplaintQuery{Limit: 2}, "q5,q4"},
+		{"after a cursor", ComplaintQuery{Sort: HighestSeverityFirst, After: &ComplaintCursor{ID: "q5", Severity: 3}, Limit: 2}, "q3,q1"},
+		{"after a cursor by time", ComplaintQuery{Sort: OldestFirst, After: &ComplaintCursor{ID: "q2", CreatedAt: base.Add(24 * time.Hour)}}, "q3,q4,q5"},
+		{"severity range", ComplaintQuery{MinSeverity: 2, MaxSeverity: 4, Sort: OldestFirst}, "q1,q3,q5"},
+		{"statuses", ComplaintQuery{Statuses: []Common.ComplaintStatus{Common.StatusResolved, Common.StatusInProgress}}, "q3,q2"},
+		{"resolved", ComplaintQuery{Resolved: &resolved}, "q2"},
+		{"unresolved", ComplaintQuery{Resolved: &unresolved, MinSeverity: 4}, "q4"},
+		{"created range", ComplaintQuery{CreatedSince: base.Add(24 * time.Hour), CreatedUntil: base.Add(3 * 24 * time.Hour), Sort: OldestFirst}, "q2,q3"},
+	} {
+		if got := ids(tc.query); got != tc.want {
+			t.Errorf("%s: expected [%s], but got [%s]", tc.name, tc.want, got)
+		}
+	}
+
+	// Paging through every complaint visits each one once, in order.
+	var pages []string
+	query := ComplaintQuery{UserID: "uq", Sort: HighestSeverityFirst, Limit: 2}
+	for {
+		page, err := store.QueryComplaints(ctx, query)
+		if err != nil || len(page) == 0 {
+			break
+		}
+		for _, c := range page {
+			pages = append(pages, c.ID)
+		}
+		last := CursorOf(page[len(page)-1])
+		query.After = &last
+	}
+	if got := strings.Join(pages, ","); got != "q4,q5,q3,q1,q2" {
+		t.Errorf("Expected pages to list q4,q5,q3,q1,q2, but got %s", got)
+	}
+}
+
+// runWebhookTests exercises the webhooks and their delivery log.
+func runWebhookTests(t *testing.T, store Store) {
+	ctx := context.Background()
+	at := time.Date(2026, 3, 1, 12, 0, 0, 5, time.UTC)
+
+	// Webhooks are listed oldest first, with their events, and can be deleted.
+	for i, id := range []string{"w2", "w1"} {
+		webhook := Common.Webhook{ID: id, URL: "https://example.com/" + id, Events: []string{"complaint.created", "complaint.resolved"},
+			Secret: "s-" + id, CreatedBy: "admin", CreatedAt: at.Add(-time.Duration(i) * time.Minute)}
+		if err := store.CreateWebhook(ctx, webhook); err != nil {
+			t.Fatalf("Expected no error creating a webhook, but got: %v", err)
+		}
+	}
+	webhooks, err := store.ListWebhooks(ctx)
+	if err != nil || len(webhooks) != 2 || webhooks[0].ID != "w1" || len(webhooks[0].Events) != 2 || webhooks[0].Secret != "s-w1" || !webhooks[1].CreatedAt.Equal(at) {
+		t.Errorf("Expected webhooks [w1 w2], but got %+v (err: %v)", webhooks, err)
+	}
+	if got, err := store.GetWebhook(ctx, "w2"); err != nil || got.URL != "https://example.com/w2" || got.Events[1] != "complaint.resolved" {
+		t.Errorf("Expected webhook w2, but got %+v (err: %v)", got, err)
+	}
+	if err := store.DeleteWebhook(ctx, "w2"); err != nil {
+		t.Errorf("Expected no error deleting a webhook, but got: %v", err)
+	}
+	if _, err := store.GetWebhook(ctx, "w2"); !errors.Is(err, ErrNotFound) {
+		t.Errorf("Expected ErrNotFound for a deleted webhook, but got: %v", err)
+	}
+	if err := store.DeleteWebhook(ctx, "w2"); !errors.Is(err, ErrNotFound) {
+		t.Errorf("Expected ErrNotFound deleting a missing webhook, but got: %v", err)
+	}
+
+	// Deliveries are listed newest first, can be filtered and limited, and updated.
+	for i, id := range []string{"d1", "d2", "d3"} {
+		d := Common.WebhookDelivery{ID: id, WebhookID: "w1", Event: "complaint.created", Payload: `{"n":` + fmt.Sprint(i) + `}`,
+			Status: Common.DeliveryPending, CreatedAt: at.Add(time.Duration(i) * time.Second)}
+		if id == "d3" {
+			d.WebhookID = "w2"
+		}
+		if err := store.AddWebhookDelivery(ctx, d); err != nil {
+			t.Fatalf("Expected no error adding a delivery, but got: %v", err)
+		}
+	}
+	updated, err := store.UpdateWebhookDelivery(ctx, "d1", func(d *Common.WebhookDelivery) error {
+		d.Status, d.Attempts, d.ResponseCode, d.Error, d.LastAttemptAt = Common.DeliveryFailed, 3, 500, "server error", at
+		return nil
+	})
+	if err != nil || updated.Status != Common.DeliveryFailed || updated.Payload != `{"n":0}` {
+		t.Errorf("Expected the updated delivery, but got %+v (err: %v)", updated, err)
+	}
+	if got, err := store.GetWebhookDelivery(ctx, "d1"); err != nil || got.Attempts != 3 || got.ResponseCode != 500 || got.Error != "server error" || !got.LastAttemptAt.Equal(at) {
+		t.Errorf("Expected the update to be saved, but got %+v (err: %v)", got, err)
+	}
+	if _, err := store.UpdateWebhookDelivery(ctx, "missing", func(*Common.WebhookDelivery) error { return nil }); !errors.Is(err, ErrNotFound) {
+		t.Errorf("Expected ErrNotFound updating a missing delivery, but got: %v", err)
+	}
+	for _, tc := range []struct {
+		filter DeliveryFilter
+		want   string
+	}{
+		{DeliveryFilter{}, "d3,d2,d1"},
+		{DeliveryFilter{WebhookID: "w1"}, "d2,d1"},
+		{DeliveryFilter{Status: Common.DeliveryPending}, "d3,d2"},
+		{DeliveryFilter{WebhookID: "w1", Status: Common.DeliveryFailed}, "d1"},
+		{DeliveryFilter{Limit: 1}, "d3"},
+	} {
+		deliveries, err := store.ListWebhookDeliveries(ctx, tc.filter)
+		var ids []string
+		for _, d := range deliveries {
+			ids = append(ids, d.ID)
+		}
+		if err != nil || strings.Join(ids, ",") != tc.want {
+			t.Errorf("Expected %+v to select [%s], but got %v (err: %v)", tc.filter, tc.want, ids, err)
+		}
+	}
+}
+
+// TestMemoryStore runs the shared store tests against the in-memory backend.
+func TestMemoryStore(t *testing.T) {
+	store := NewMemoryStore()
+	store.Reset()
+	defer store.Reset()
+	runStoreTests(t, store)
+	runQueryTests(t, store)
+	runWebhookTests(t, store)
+}
+
+// TestSQLStore runs the shared store tests against an in-memory SQLite database.
+func TestSQLStore(t *testing.T) {
+	store, err := OpenSQLite(":memory:")
+	if err != nil {
+		t.Fatalf("Failed to open SQLite store: %v", err)
+	}
+	defer store.Close()
+	runStoreTests(t, store)
+	runQueryTests(t, store)
+	runWebhookTests(t, store)
+}
+
+// TestSQLStoreMigrations checks that reopening a database does not reapply migrations.
+func TestSQLStoreMigrations(t *testing.T) {
+	path := t.TempDir() + "/complaints.db"
+	store, err := OpenSQLite(path)
+	if err != nil {
+		t.Fatalf("Failed to open SQLite store: %v", err)
+	}
+	ctx := context.Background()
+	if err := store.CreateUser(ctx, Common.User{ID: "u1", SecretHash: "s1", Name: "N", Email: "e@example.com"}); err != nil {
+		t.Fatalf("Expected no error creating user, but got: %v", err)
+	}
+	store.Close()
+
+	store, err = OpenSQLite(path)
+	if err != nil {
+		t.Fatalf("Expected reopening the database to succeed, but got: %v", err)
+	}
+	defer store.Close()
+	if _, err := store.GetUser(ctx, "u1"); err != nil {
+		t.Errorf("Expected user to survive reopening the database, but got: %v", err)
+	}
+}
+
+// TestSQLStorePing checks that Ping reports whether the database can be queried.
+func TestSQLStorePing(t *testing.T) {
+	store, err := OpenSQLite(":memory:")
+	if err != nil {
+		t.Fatalf("Failed to open SQLite store: %v", err)
+	}
+	if err := store.Ping(context.Background()); err != nil {
+		t.Errorf("Expected an open database to answer, but got: %v", err)
+	}
+	store.Close()
+	if err := store.Ping(context.Background()); err == nil {
+		t.Error("Expected a closed database not to answer")
+	}
+}
+
+// TestSQLStoreTimestampBackfill checks that complaints stored before timestamps
+// existed get them from their status history.
+func TestSQLStoreTimestampBackfill(t *testing.T) {
+	db, err := sql.Open("sqlite", ":memory:")
+	if err != nil {
+		t.Fatalf("Failed to open database: %v", err)
+	}
+	db.SetMaxOpenConns(1)
+	defer db.Close()
+
+	// Build the schema as it was before migration 7, with one resolved complaint.
+	for version, migration := range sqlMigrations[:6] {
+		if _, err := db.Exec(migration); err != nil {
+			t.Fatalf("Failed to apply migration %d: %v", version+1, err)
+		}
+	}
+	opened := time.Date(2023, 6, 1, 9, 0, 0, 0, time.UTC)
+	resolved := opened.Add(48 * time.Hour)
+	_, err = db.Exec(`CREATE TABLE schema_migrations (version INTEGER PRIMARY KEY);
+		INSERT INTO schema_migrations (version) VALUES (1), (2), (3), (4), (5), (6);
+		INSERT INTO users (id, secret_code, name, email) VALUES ('u1', 's1', 'Old', 'old@example.com');
+		INSERT INTO complaints (id, user_id, title, summary, severity, resolved, status) VALUES ('c1', 'u1', 'Old', '', 1, 1, 'Resolved'), ('c2', 'u1', 'Legacy', '', 1, 0, 'Open');
+		INSERT INTO complaint_status_history (complaint_id, seq, from_status, to_status, changed_by, changed_at, note)
+			VALUES ('c1', 0, 'Open', 'Open', 'u1', ?, ''), ('c1', 1, 'Open', 'Resolved', 'a1', ?, '');`,
+		opened.UnixNano(), resolved.UnixNano())
+	if err != nil {
+		t.Fatalf("Failed to seed the old schema: %v", err)
+	}
+
+	store, err := NewSQLStore(db)
+	if err != nil {
+		t.Fatalf("Expected the migration to succeed, but got: %v", err)
+	}
+	ctx := context.Background()
+	got, err := store.GetComplaint(ctx, "c1")
+	if err != nil || !got.CreatedAt.Equal(opened) || !got.UpdatedAt.Equal(resolved) || !got.ResolvedAt.Equal(resolved) {
+		t.Errorf("Expected c1 to be created at %v and resolved at %v, but got %+v (err: %v)", opened, resolved, got, err)
+	}
+	if got, _ := store.GetComplaint(ctx, "c2"); !got.CreatedAt.IsZero() || !got.ResolvedAt.IsZero() {
+		t.Errorf("Expected a complaint without history to keep unknown timestamps, but got %+v", got)
+	}
+	if user, err := store.GetUser(ctx, "u1"); err != nil || !user.CreatedAt.IsZero() {
+		t.Errorf("Expected the old user to be readable with an unknown creation time, but got %+v (err: %v)", user, err)
+	}
+}
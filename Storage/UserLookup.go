@@ -0,0 +1,56 @@
+// Storage/UserLookup.go
+package Storage
+
+import (
+	"complaint-portal/Common"
+	"context"
+)
+
+// UserLookup resolves user IDs for the duration of one request or export. Each Load reads
+// only the IDs it has not seen yet, in a single batch, and remembers the ones
+// that were not found so they are not read again.
+type UserLookup struct {
+	store   Store
+	users   map[string]Common.User
+	missing map[string]bool
+}
+
+// NewUserLookup returns an empty lookup reading from store.
+func NewUserLookup(store Store) *UserLookup {
+	return &UserLookup{store: store, users: map[string]Common.User{}, missing: map[string]bool{}}
+}
+
+// Load reads the given users that are not cached yet.
+func (l *UserLookup) Load(ctx context.Context, ids []string) error {
+	var pending []string
+	seen := map[string]bool{}
+	for _, id := range ids {
+		if _, ok := l.users[id]; ok || l.missing[id] || seen[id] {
+			continue
+		}
+		seen[id] = true
+		pending = append(pending, id)
+	}
+	if len(pending) == 0 {
+		return nil
+	}
+
+	found, err := l.store.GetUsers(ctx, pending)
+	if err != nil {
+		return err
+	}
+	for _, id := range pending {
+		if user, ok := found[id]; ok {
+			l.users[id] = user
+		} else {
+			l.missing[id] = true
+		}
+	}
+	return nil
+}
+
+// Get returns a loaded user, or false if it does not exist or was never loaded.
+func (l *UserLookup) Get(id string) (Common.User, bool) {
+	user, ok := l.users[id]
+	return user, ok
+}
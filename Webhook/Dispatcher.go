@@ -0,0 +1,365 @@
+// Webhook/Dispatcher.go
+package Webhook
+
+import (
+	"bytes"
+	"complaint-portal/Common"
+	"complaint-portal/Events"
+	"complaint-portal/Storage"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"log/slog"
+	"net/http"
+	"strconv"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+// Options tune a Dispatcher.
+type Options struct {
+	// Workers is how many deliveries are attempted at once.
+	Workers int
+	// MaxAttempts is how many times a delivery is attempted before it fails.
+	MaxAttempts int
+	// Backoff is the wait before the first retry, doubled for each next one up
+	// to MaxBackoff.
+	Backoff    time.Duration
+	MaxBackoff time.Duration
+	// Timeout bounds each attempt.
+	Timeout time.Duration
+}
+
+// DefaultOptions retry a delivery for about an hour.
+var DefaultOptions = Options{Workers: 4, MaxAttempts: 8, Backoff: 30 * time.Second, MaxBackoff: 30 * time.Minute, Timeout: 10 * time.Second}
+
+// maxErrorLength bounds the error, or response body, kept with a failed attempt.
+const maxErrorLength = 512
+
+// ErrWebhookDeleted is the error of deliveries whose webhook was deleted.
+var ErrWebhookDeleted = errors.New("webhook deleted")
+
+// Dispatcher delivers complaint events to the webhooks subscribed to them.
+// Every delivery is recorded in the store's delivery log before it is
+// attempted, and updated after each attempt; failed attempts are retried with
+// exponential backoff.
+type Dispatcher struct {
+	store  Storage.Store
+	client *http.Client
+	opts   Options
+
+	queue    chan string
+	done     chan struct{}
+	stop     sync.Once
+	workers  sync.WaitGroup
+	inFlight int64
+}
+
+// NewDispatcher returns a Dispatcher reading webhooks from and logging
+// deliveries to store, and posting them with client. Options left zero take
+// their DefaultOptions value. Its workers run until Close is called.
+func NewDispatcher(store Storage.Store, client *http.Client, opts Options) *Dispatcher {
+	if opts.Workers <= 0 {
+		opts.Workers = DefaultOptions.Workers
+	}
+	if opts.MaxAttempts <= 0 {
+		opts.MaxAttempts = DefaultOptions.MaxAttempts
+	}
+	if opts.Timeout <= 0 {
+		opts.Timeout = DefaultOptions.Timeout
+	}
+	// Without a wait between them, retries would hammer a failing receiver.
+	if opts.Backoff <= 0 {
+		opts.Backoff = DefaultOptions.Backoff
+	}
+	if opts.MaxBackoff <= 0 {
+		opts.MaxBackoff = max(DefaultOptions.MaxBackoff, opts.Backoff)
+	}
+	d := &Dispatcher{
+		store:  store,
+		client: client,
+		opts:   opts,
+		queue:  make(chan string),
+		done:   make(chan struct{}),
+	}
+	d.workers.Add(opts.Workers)
+	for i := 0; i < opts.Workers; i++ {
+		go d.work()
+	}
+	return d
+}
+
+// Listen delivers the events published on bus from now until Close is called.
+// A subscription dropped for falling behind is resumed where it stopped.
+func (d *Dispatcher) Listen(bus *Events.Bus) {
+	ctx, cancel := context.WithCancel(context.Background())
+	go func() {
+		<-d.done
+		cancel()
+	}()
+	sub, _ := bus.Subscribe(Events.Filter{}, "")
+	go func() {
+		token := ""
+		for {
+			for {
+				e, err := sub.Next(ctx)
+				if err != nil {
+					break
+				}
+				token = e.Token
+				if err := d.Dispatch(ctx, eventTypes[e.Type], e.Complaint, e.At); err != nil {
+					slog.Error(Common.LogWebhookDispatchFailed, Common.LogKeyComplaint, e.Complaint.ID, Common.LogKeyError, err)
+				}
+			}
+			sub.Close()
+			if ctx.Err() != nil {
+				return
+			}
+			var err error
+			if sub, err = bus.Subscribe(Events.Filter{}, token); err != nil {
+				// The events missed are gone; carry on from now.
+				slog.Error(Common.LogWebhookEventsLost, Common.LogKeyError, err)
+				token = ""
+				sub, _ = bus.Subscribe(Events.Filter{}, "")
+			}
+		}
+	}()
+}
+
+// Dispatch records a delivery of the event to every webhook subscribed to its
+// type, and queues them. A delivery that cannot be recorded does not keep the
+// others from being made; the errors are returned joined.
+func (d *Dispatcher) Dispatch(ctx context.Context, event string, complaint Common.Complaint, at time.Time) error {
+	webhooks, err := d.store.ListWebhooks(ctx)
+	if err != nil {
+		return err
+	}
+	payload, err := json.Marshal(Payload{ID: Common.GenerateID(), Type: event, CreatedAt: at.UTC(), Complaint: toComplaint(complaint)})
+	if err != nil {
+		return err
+	}
+	var errs []error
+	for _, webhook := range webhooks {
+		if !Subscribed(webhook, event) {
+			continue
+		}
+		delivery := Common.WebhookDelivery{
+			ID:        Common.GenerateID(),
+			WebhookID: webhook.ID,
+			Event:     event,
+			Payload:   string(payload),
+			Status:    Common.DeliveryPending,
+			CreatedAt: time.Now().UTC(),
+		}
+		if err := d.store.AddWebhookDelivery(ctx, delivery); err != nil {
+			errs = append(errs, fmt.Errorf("webhook %s: %w", webhook.ID, err))
+			continue
+		}
+		d.enqueue(delivery.ID)
+	}
+	return errors.Join(errs...)
+}
+
+// Redeliver records a new delivery of the payload of an earlier one, to the
+// same webhook, and queues it. It returns ErrWebhookDeleted if the webhook no
+// longer exists, and Storage.ErrNotFound if the delivery does not.
+func (d *Dispatcher) Redeliver(ctx context.Context, deliveryID string) (Common.WebhookDelivery, error) {
+	previous, err := d.store.GetWebhookDelivery(ctx, deliveryID)
+	if err != nil {
+		return Common.WebhookDelivery{}, err
+	}
+	if _, err := d.store.GetWebhook(ctx, previous.WebhookID); errors.Is(err, Storage.ErrNotFound) {
+		return Common.WebhookDelivery{}, ErrWebhookDeleted
+	} else if err != nil {
+		return Common.WebhookDelivery{}, err
+	}
+	delivery := Common.WebhookDelivery{
+		ID:        Common.GenerateID(),
+		WebhookID: previous.WebhookID,
+		Event:     previous.Event,
+		Payload:   previous.Payload,
+		Status:    Common.DeliveryPending,
+		CreatedAt: time.Now().UTC(),
+	}
+	if err := d.store.AddWebhookDelivery(ctx, delivery); err != nil {
+		return Common.WebhookDelivery{}, err
+	}
+	d.enqueue(delivery.ID)
+	return delivery, nil
+}
+
+// ResumePending queues the deliveries left pending by a previous process, and
+// returns how many there were.
+func (d *Dispatcher) ResumePending(ctx context.Context) (int, error) {
+	pending, err := d.store.ListWebhookDeliveries(ctx, Storage.DeliveryFilter{Status: Common.DeliveryPending})
+	if err != nil {
+		return 0, err
+	}
+	for i := len(pending) - 1; i >= 0; i-- {
+		d.enqueue(pending[i].ID)
+	}
+	return len(pending), nil
+}
+
+// enqueue hands a delivery to the workers. Deliveries queued after Close stay
+// pending in the log.
+func (d *Dispatcher) enqueue(id string) {
+	atomic.AddInt64(&d.inFlight, 1)
+	go func() {
+		select {
+		case d.queue <- id:
+		case <-d.done:
+			atomic.AddInt64(&d.inFlight, -1)
+		}
+	}()
+}
+
+// Pending returns how many deliveries are queued, being attempted or waiting
+// for a retry.
+func (d *Dispatcher) Pending() int {
+	return int(atomic.LoadInt64(&d.inFlight))
+}
+
+// Drain waits until every delivery queued so far has succeeded or failed, or
+// until ctx is done.
+func (d *Dispatcher) Drain(ctx context.Context) error {
+	ticker := time.NewTicker(10 * time.Millisecond)
+	defer ticker.Stop()
+	for d.Pending() > 0 {
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-ticker.C:
+		}
+	}
+	return nil
+}
+
+// Close stops listening and waits for the attempts in progress. Deliveries
+// still waiting stay pending in the log; see ResumePending.
+func (d *Dispatcher) Close() {
+	d.stop.Do(func() {
+		close(d.done)
+		d.workers.Wait()
+	})
+}
+
+func (d *Dispatcher) work() {
+	defer d.workers.Done()
+	for {
+		select {
+		case <-d.done:
+			return
+		case id := <-d.queue:
+			d.attempt(id)
+		}
+	}
+}
+
+// attempt posts a delivery once and records the outcome, scheduling a retry
+// if it failed and attempts remain.
+func (d *Dispatcher) attempt(id string) {
+	ctx := context.Background()
+	delivery, err := d.store.GetWebhookDelivery(ctx, id)
+	if err != nil {
+		slog.Error(Common.LogWebhookAttemptFailed, Common.LogKeyDelivery, id, Common.LogKeyError, err)
+		atomic.AddInt64(&d.inFlight, -1)
+		return
+	}
+
+	code, sendErr := 0, ErrWebhookDeleted
+	webhook, err := d.store.GetWebhook(ctx, delivery.WebhookID)
+	if err == nil {
+		code, sendErr = d.post(ctx, webhook, delivery)
+	} else if !errors.Is(err, Storage.ErrNotFound) {
+		sendErr = err
+	}
+
+	retry := false
+	delivery, err = d.store.UpdateWebhookDelivery(ctx, id, func(dl *Common.WebhookDelivery) error {
+		dl.Attempts++
+		dl.LastAttemptAt = time.Now().UTC()
+		dl.ResponseCode = code
+		dl.Error = ""
+		switch {
+		case sendErr == nil:
+			dl.Status = Common.DeliverySucceeded
+		case errors.Is(sendErr, ErrWebhookDeleted) || dl.Attempts >= d.opts.MaxAttempts:
+			dl.Status = Common.DeliveryFailed
+			dl.Error = truncate(sendErr.Error())
+		default:
+			dl.Status = Common.DeliveryPending
+			dl.Error = truncate(sendErr.Error())
+			retry = true
+		}
+		return nil
+	})
+	if err != nil {
+		slog.Error(Common.LogWebhookAttemptFailed, Common.LogKeyDelivery, id, Common.LogKeyError, err)
+		atomic.AddInt64(&d.inFlight, -1)
+		return
+	}
+	if !retry {
+		atomic.AddInt64(&d.inFlight, -1)
+		return
+	}
+	time.AfterFunc(d.backoff(delivery.Attempts), func() {
+		select {
+		case d.queue <- id:
+		case <-d.done:
+			atomic.AddInt64(&d.inFlight, -1)
+		}
+	})
+}
+
+// backoff returns the wait after the given number of failed attempts.
+func (d *Dispatcher) backoff(attempts int) time.Duration {
+	wait := d.opts.Backoff
+	for i := 1; i < attempts; i++ {
+		wait *= 2
+		if wait >= d.opts.MaxBackoff {
+			return d.opts.MaxBackoff
+		}
+	}
+	return wait
+}
+
+// post sends the delivery and returns the response status. Any status but 2xx
+// is an error.
+func (d *Dispatcher) post(ctx context.Context, webhook Common.Webhook, delivery Common.WebhookDelivery) (int, error) {
+	ctx, cancel := context.WithTimeout(ctx, d.opts.Timeout)
+	defer cancel()
+	body := []byte(delivery.Payload)
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, webhook.URL, bytes.NewReader(body))
+	if err != nil {
+		return 0, err
+	}
+	timestamp := time.Now().Unix()
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("User-Agent", "complaint-portal-webhooks")
+	req.Header.Set(HeaderEvent, delivery.Event)
+	req.Header.Set(HeaderDelivery, delivery.ID)
+	req.Header.Set(HeaderTimestamp, strconv.FormatInt(timestamp, 10))
+	req.Header.Set(HeaderSignature, Sign(webhook.Secret, timestamp, body))
+
+	res, err := d.client.Do(req)
+	if err != nil {
+		return 0, err
+	}
+	defer res.Body.Close()
+	excerpt, _ := io.ReadAll(io.LimitReader(res.Body, maxErrorLength))
+	if res.StatusCode < 200 || res.StatusCode > 299 {
+		return res.StatusCode, fmt.Errorf("%s: %s", res.Status, excerpt)
+	}
+	return res.StatusCode, nil
+}
+
+func truncate(s string) string {
+	if len(s) > maxErrorLength {
+		return s[:maxErrorLength]
+	}
+	return s
+}
@@ -0,0 +1,178 @@
+// Webhook/Webhook.go
+package Webhook
+
+import (
+	"complaint-portal/Common"
+	"complaint-portal/Events"
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Event types a webhook can subscribe to.
+const (
+	EventCreated = "complaint.created"
+	// EventUpdated is sent for every change that does not resolve the complaint.
+	EventUpdated  = "complaint.updated"
+	EventResolved = "complaint.resolved"
+)
+
+// EventTypes lists every event type.
+var EventTypes = []string{EventCreated, EventUpdated, EventResolved}
+
+// eventTypes maps the bus's event types to webhook event types.
+var eventTypes = map[Events.Type]string{
+	Events.Created:  EventCreated,
+	Events.Updated:  EventUpdated,
+	Events.Resolved: EventResolved,
+}
+
+// Headers of every delivery.
+const (
+	HeaderEvent     = "X-Webhook-Event"
+	HeaderDelivery  = "X-Webhook-Delivery"
+	HeaderTimestamp = "X-Webhook-Timestamp"
+	// HeaderSignature is "sha256=" followed by the hex HMAC-SHA256, keyed with
+	// the webhook's secret, of the timestamp header, a dot, and the body.
+	HeaderSignature = "X-Webhook-Signature"
+)
+
+var (
+	// ErrInvalidURL is returned for a webhook URL that is not an absolute
+	// http or https URL.
+	ErrInvalidURL = errors.New("webhook URL must be an absolute http or https URL")
+	// ErrInvalidEvents is returned for a webhook without events or with an
+	// unknown one.
+	ErrInvalidEvents = errors.New("webhook events must be one or more of " + strings.Join(EventTypes, ", "))
+	// ErrInvalidSignature is returned by Verify for a request not signed with
+	// the secret.
+	ErrInvalidSignature = errors.New("invalid webhook signature")
+	// ErrStaleTimestamp is returned by Verify for a request signed too long ago.
+	ErrStaleTimestamp = errors.New("webhook timestamp outside the tolerance")
+)
+
+// Validate checks a webhook's URL and events, and removes duplicate events.
+func Validate(webhook *Common.Webhook) error {
+	u, err := url.Parse(webhook.URL)
+	if err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+		return ErrInvalidURL
+	}
+	if len(webhook.Events) == 0 {
+		return ErrInvalidEvents
+	}
+	var events []string
+	seen := map[string]bool{}
+	for _, e := range webhook.Events {
+		if !validEvent(e) {
+			return ErrInvalidEvents
+		}
+		if !seen[e] {
+			seen[e] = true
+			events = append(events, e)
+		}
+	}
+	webhook.Events = events
+	return nil
+}
+
+func validEvent(name string) bool {
+	for _, e := range EventTypes {
+		if e == name {
+			return true
+		}
+	}
+	return false
+}
+
+// Subscribed reports whether the webhook receives events of the given type.
+func Subscribed(webhook Common.Webhook, event string) bool {
+	for _, e := range webhook.Events {
+		if e == event {
+			return true
+		}
+	}
+	return false
+}
+
+// GenerateSecret returns a new random signing secret.
+func GenerateSecret() string {
+	b := make([]byte, 32)
+	rand.Read(b)
+	return "whsec_" + hex.EncodeToString(b)
+}
+
+// Sign returns the signature header value of a body sent at timestamp, in Unix seconds.
+func Sign(secret string, timestamp int64, body []byte) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write([]byte(strconv.FormatInt(timestamp, 10)))
+	mac.Write([]byte("."))
+	mac.Write(body)
+	return "sha256=" + hex.EncodeToString(mac.Sum(nil))
+}
+
+// Verify checks the signature of a delivery received at now, for receivers.
+// Deliveries whose timestamp is more than tolerance away from now are refused,
+// so that a captured request cannot be replayed later.
+func Verify(secret string, header http.Header, body []byte, now time.Time, tolerance time.Duration) error {
+	timestamp, err := strconv.ParseInt(header.Get(HeaderTimestamp), 10, 64)
+	if err != nil {
+		return ErrInvalidSignature
+	}
+	if !hmac.Equal([]byte(header.Get(HeaderSignature)), []byte(Sign(secret, timestamp, body))) {
+		return ErrInvalidSignature
+	}
+	if d := now.Sub(time.Unix(timestamp, 0)); d > tolerance || d < -tolerance {
+		return fmt.Errorf("%w: sent at %d", ErrStaleTimestamp, timestamp)
+	}
+	return nil
+}
+
+// Payload is the JSON body of a delivery. ID identifies the event, and is the
+// same in every delivery of it, so that receivers can ignore redeliveries.
+type Payload struct {
+	ID        string    `json:"id"`
+	Type      string    `json:"type"`
+	CreatedAt time.Time `json:"created_at"`
+	Complaint Complaint `json:"complaint"`
+}
+
+// Complaint is a complaint as sent in payloads.
+type Complaint struct {
+	ID         string     `json:"id"`
+	UserID     string     `json:"user_id"`
+	Title      string     `json:"title"`
+	Summary    string     `json:"summary"`
+	Severity   int        `json:"severity"`
+	Status     string     `json:"status"`
+	Resolved   bool       `json:"resolved"`
+	CreatedAt  time.Time  `json:"created_at"`
+	UpdatedAt  time.Time  `json:"updated_at"`
+	ResolvedAt *time.Time `json:"resolved_at,omitempty"`
+}
+
+func toComplaint(c Common.Complaint) Complaint {
+	out := Complaint{
+		ID:        c.ID,
+		UserID:    c.UserID,
+		Title:     c.Title,
+		Summary:   c.Summary,
+		Severity:  c.Severity,
+		Status:    string(c.CurrentStatus()),
+		Resolved:  c.Resolved,
+		CreatedAt: c.CreatedAt.UTC(),
+		UpdatedAt: c.UpdatedAt.UTC(),
+	}
+	if !c.ResolvedAt.IsZero() {
+		at := c.ResolvedAt.UTC()
+		out.ResolvedAt = &at
+	}
+	return out
+}
@@ -0,0 +1,320 @@
+// Webhook/Webhook_test.go
+package Webhook
+
+import (
+	"complaint-portal/Common"
+	"complaint-portal/Events"
+	"complaint-portal/Storage"
+	"context"
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"sync"
+	"testing"
+	"time"
+)
+
+// testOptions retry quickly.
+var testOptions = Options{Workers: 2, MaxAttempts: 3, Backoff: time.Millisecond}
+
+// receiver is a webhook endpoint answering with the given status codes in turn,
+// then 200, and recording the requests it verified.
+type receiver struct {
+	*httptest.Server
+	secret string
+
+	mu       sync.Mutex
+	codes    []int
+	requests []*http.Request
+	bodies   [][]byte
+}
+
+func newReceiver(t *testing.T, secret string, codes ...int) *receiver {
+	r := &receiver{secret: secret, codes: codes}
+	r.Server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		body, _ := io.ReadAll(req.Body)
+		if err := Verify(r.secret, req.Header, body, time.Now(), time.Minute); err != nil {
+			http.Error(w, err.Error(), http.StatusUnauthorized)
+			return
+		}
+		r.mu.Lock()
+		defer r.mu.Unlock()
+		r.requests = append(r.requests, req)
+		r.bodies = append(r.bodies, body)
+		if len(r.codes) > 0 {
+			code := r.codes[0]
+			r.codes = r.codes[1:]
+			http.Error(w, "try again", code)
+		}
+	}))
+	t.Cleanup(r.Close)
+	return r
+}
+
+func (r *receiver) received() int {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return len(r.requests)
+}
+
+// newTestStore returns a store with a webhook posting to url.
+func newTestStore(t *testing.T, url, secret string, events ...string) *Storage.MemoryStore {
+	store := Storage.NewMemoryStore()
+	store.Reset()
+	t.Cleanup(store.Reset)
+	webhook := Common.Webhook{ID: "w1", URL: url, Events: events, Secret: secret, CreatedAt: time.Now()}
+	if err := store.CreateWebhook(context.Background(), webhook); err != nil {
+		t.Fatalf("Failed to create webhook: %v", err)
+	}
+	return store
+}
+
+// drain waits for the dispatcher to be idle.
+func drain(t *testing.T, d *Dispatcher) {
+	t.Helper()
+	ctx, cancel := context.WithTimeout(context.Background(), 2*time.Second)
+	defer cancel()
+	if err := d.Drain(ctx); err != nil {
+		t.Fatalf("Expected the deliveries to finish, but %d are pending", d.Pending())
+	}
+}
+
+// deliveries returns the delivery log of the store, newest first.
+func deliveries(t *testing.T, store Storage.Store) []Common.WebhookDelivery {
+	t.Helper()
+	list, err := store.ListWebhookDeliveries(context.Background(), Storage.DeliveryFilter{})
+	if err != nil {
+		t.Fatalf("Failed to list deliveries: %v", err)
+	}
+	return list
+}
+
+func TestSignature(t *testing.T) {
+	body := []byte(`{"id":"e1"}`)
+	now := time.Unix(1700000000, 0)
+	header := http.Header{}
+	header.Set(HeaderTimestamp, strconv.FormatInt(now.Unix(), 10))
+	header.Set(HeaderSignature, Sign("secret", now.Unix(), body))
+
+	// Test case 1: A delivery signed with the secret is verified
+	if err := Verify("secret", header, body, now.Add(time.Second), time.Minute); err != nil {
+		t.Errorf("Expected the signature to verify, but got %v", err)
+	}
+
+	// Test case 2: Another secret, body or timestamp does not verify
+	if err := Verify("other", header, body, now, time.Minute); !errors.Is(err, ErrInvalidSignature) {
+		t.Errorf("Expected ErrInvalidSignature for another secret, but got %v", err)
+	}
+	if err := Verify("secret", header, []byte(`{"id":"e2"}`), now, time.Minute); !errors.Is(err, ErrInvalidSignature) {
+		t.Errorf("Expected ErrInvalidSignature for another body, but got %v", err)
+	}
+	forged := header.Clone()
+	forged.Set(HeaderTimestamp, strconv.FormatInt(now.Unix()+1, 10))
+	if err := Verify("secret", forged, body, now, time.Minute); !errors.Is(err, ErrInvalidSignature) {
+		t.Errorf("Expected ErrInvalidSignature for another timestamp, but got %v", err)
+	}
+
+	// Test case 3: A delivery signed too long ago is refused
+	if err := Verify("secret", header, body, now.Add(time.Hour), time.Minute); !errors.Is(err, ErrStaleTimestamp) {
+		t.Errorf("Expected ErrStaleTimestamp, but got %v", err)
+	}
+}
+
+func TestValidate(t *testing.T) {
+	// Test case 1: Duplicate events are removed
+	webhook := Common.Webhook{URL: "https://example.com/hook", Events: []string{EventResolved, EventCreated, EventResolved}}
+	if err := Validate(&webhook); err != nil || len(webhook.Events) != 2 || webhook.Events[0] != EventResolved {
+		t.Errorf("Expected the two events in order, but got %v (err: %v)", webhook.Events, err)
+	}
+
+	// Test case 2: URLs must be absolute http(s) URLs, and events known
+	for _, w := range []Common.Webhook{
+		{URL: "/hook", Events: []string{EventCreated}},
+		{URL: "mailto:ops@example.com", Events: []string{EventCreated}},
+		{URL: "https://example.com", Events: []string{"complaint.deleted"}},
+		{URL: "https://example.com"},
+	} {
+		if err := Validate(&w); err == nil {
+			t.Errorf("Expected an error for %+v", w)
+		}
+	}
+
+	// Test case 3: Secrets are random
+	if a, b := GenerateSecret(), GenerateSecret(); a == b || len(a) != len("whsec_")+64 {
+		t.Errorf("Expected two different secrets, but got %q and %q", a, b)
+	}
+}
+
+func TestDispatcher(t *testing.T) {
+	ctx := context.Background()
+	r := newReceiver(t, "secret", http.StatusInternalServerError)
+	store := newTestStore(t, r.URL, "secret", EventCreated, EventResolved)
+	d := NewDispatcher(store, r.Client(), testOptions)
+	defer d.Close()
+	complaint := Common.Complaint{ID: "c1", UserID: "u1", Title: "No signal", Severity: 2}
+
+	// Test case 1: An event is retried after a failed attempt, and its delivery logged
+	if err := d.Dispatch(ctx, EventCreated, complaint, time.Now()); err != nil {
+		t.Fatalf("Expected no error dispatching, but got %v", err)
+	}
+	drain(t, d)
+	if r.received() != 2 {
+		t.Fatalf("Expected 2 attempts, but got %d", r.received())
+	}
+	log := deliveries(t, store)
+	if len(log) != 1 || log[0].Status != Common.DeliverySucceeded || log[0].Attempts != 2 || log[0].ResponseCode != http.StatusOK || log[0].Error != "" {
+		t.Fatalf("Expected a delivery succeeded on the second attempt, but got %+v", log)
+	}
+	req := r.requests[1]
+	if req.Header.Get(HeaderEvent) != EventCreated || req.Header.Get(HeaderDelivery) != log[0].ID {
+		t.Errorf("Expected the event and delivery headers, but got %v", req.Header)
+	}
+	var payload Payload
+	if err := json.Unmarshal(r.bodies[1], &payload); err != nil || payload.Type != EventCreated || payload.Complaint.Title != "No signal" || payload.Complaint.Status != string(Common.StatusOpen) {
+		t.Errorf("Expected the complaint's payload, but got %+v (err: %v)", payload, err)
+	}
+
+	// Test case 2: Events the webhook is not subscribed to are not delivered
+	d.Dispatch(ctx, EventUpdated, complaint, time.Now())
+	drain(t, d)
+	if len(deliveries(t, store)) != 1 {
+		t.Errorf("Expected no delivery of an unsubscribed event, but got %+v", deliveries(t, store))
+	}
+
+	// Test case 3: Redelivering sends the same payload as a new delivery
+	again, err := d.Redeliver(ctx, log[0].ID)
+	if err != nil {
+		t.Fatalf("Expected no error redelivering, but got %v", err)
+	}
+	drain(t, d)
+	if again.ID == log[0].ID || r.received() != 3 || string(r.bodies[2]) != string(r.bodies[1]) {
+		t.Errorf("Expected the same payload sent again, but got %s", r.bodies[len(r.bodies)-1])
+	}
+	if _, err := d.Redeliver(ctx, "missing"); !errors.Is(err, Storage.ErrNotFound) {
+		t.Errorf("Expected ErrNotFound for an unknown delivery, but got %v", err)
+	}
+}
+
+func TestDispatcherFailures(t *testing.T) {
+	ctx := context.Background()
+	r := newReceiver(t, "secret", http.StatusBadGateway, http.StatusBadGateway, http.StatusBadGateway)
+	store := newTestStore(t, r.URL, "secret", EventCreated)
+	d := NewDispatcher(store, r.Client(), testOptions)
+	defer d.Close()
+
+	// Test case 1: A delivery fails after MaxAttempts, with the last response recorded
+	d.Dispatch(ctx, EventCreated, Common.Complaint{ID: "c1"}, time.Now())
+	drain(t, d)
+	log := deliveries(t, store)
+	if len(log) != 1 || log[0].Status != Common.DeliveryFailed || log[0].Attempts != 3 || log[0].ResponseCode != http.StatusBadGateway || log[0].Error == "" {
+		t.Fatalf("Expected a delivery failed after 3 attempts, but got %+v", log)
+	}
+
+	// Test case 2: Deliveries to a deleted webhook fail without being sent
+	d.Dispatch(ctx, EventCreated, Common.Complaint{ID: "c2"}, time.Now())
+	store.DeleteWebhook(ctx, "w1")
+	drain(t, d)
+	log = deliveries(t, store)
+	if r.received() != 3 || log[0].Status != Common.DeliveryFailed || log[0].Error != ErrWebhookDeleted.Error() {
+		t.Errorf("Expected the delivery to fail unsent, but got %+v", log[0])
+	}
+	if _, err := d.Redeliver(ctx, log[1].ID); !errors.Is(err, ErrWebhookDeleted) {
+		t.Errorf("Expected ErrWebhookDeleted redelivering, but got %v", err)
+	}
+}
+
+func TestNewDispatcherDefaults(t *testing.T) {
+	store := Storage.NewMemoryStore()
+
+	// Test case 1: Zero options take the defaults, backoff included
+	d := NewDispatcher(store, http.DefaultClient, Options{})
+	defer d.Close()
+	if d.opts != DefaultOptions {
+		t.Errorf("Expected the default options, but got %+v", d.opts)
+	}
+	if d.backoff(1) != DefaultOptions.Backoff || d.backoff(100) != DefaultOptions.MaxBackoff {
+		t.Errorf("Expected retries to wait from %v up to %v, but got %v and %v", DefaultOptions.Backoff, DefaultOptions.MaxBackoff, d.backoff(1), d.backoff(100))
+	}
+
+	// Test case 2: A backoff above the default maximum is capped at itself
+	long := NewDispatcher(store, http.DefaultClient, Options{Backoff: time.Hour})
+	defer long.Close()
+	if long.backoff(1) != time.Hour || long.backoff(10) != time.Hour {
+		t.Errorf("Expected retries to wait an hour, but got %v and %v", long.backoff(1), long.backoff(10))
+	}
+}
+
+// failingStore fails to record the deliveries of one webhook.
+type failingStore struct {
+	*Storage.MemoryStore
+	webhookID string
+}
+
+func (s failingStore) AddWebhookDelivery(ctx context.Context, delivery Common.WebhookDelivery) error {
+	if delivery.WebhookID == s.webhookID {
+		return errors.New("disk full")
+	}
+	return s.MemoryStore.AddWebhookDelivery(ctx, delivery)
+}
+
+func TestDispatchRecordFailure(t *testing.T) {
+	ctx := context.Background()
+	r := newReceiver(t, "secret")
+	store := newTestStore(t, r.URL, "secret", EventCreated)
+	if err := store.CreateWebhook(ctx, Common.Webhook{ID: "w2", URL: r.URL, Events: []string{EventCreated}, Secret: "secret", CreatedAt: time.Now()}); err != nil {
+		t.Fatalf("Failed to create webhook: %v", err)
+	}
+	d := NewDispatcher(failingStore{MemoryStore: store, webhookID: "w1"}, r.Client(), testOptions)
+	defer d.Close()
+
+	// Test case 1: A delivery that cannot be recorded is reported, and the other webhooks still get theirs
+	err := d.Dispatch(ctx, EventCreated, Common.Complaint{ID: "c1"}, time.Now())
+	if err == nil {
+		t.Error("Expected the failure to record a delivery to be returned")
+	}
+	drain(t, d)
+	if log := deliveries(t, store); len(log) != 1 || log[0].WebhookID != "w2" || log[0].Status != Common.DeliverySucceeded || r.received() != 1 {
+		t.Errorf("Expected w2 to get its delivery, but got %+v", log)
+	}
+}
+
+func TestListenAndResume(t *testing.T) {
+	ctx := context.Background()
+	r := newReceiver(t, "secret")
+	store := newTestStore(t, r.URL, "secret", EventCreated, EventUpdated, EventResolved)
+
+	// Test case 1: Deliveries left pending by a previous process are resumed
+	pending := Common.WebhookDelivery{ID: "d1", WebhookID: "w1", Event: EventCreated, Payload: `{"id":"e1"}`, Status: Common.DeliveryPending, CreatedAt: time.Now()}
+	store.AddWebhookDelivery(ctx, pending)
+	d := NewDispatcher(store, r.Client(), testOptions)
+	defer d.Close()
+	if n, err := d.ResumePending(ctx); n != 1 || err != nil {
+		t.Fatalf("Expected 1 delivery resumed, but got %d (err: %v)", n, err)
+	}
+	drain(t, d)
+	if got, _ := store.GetWebhookDelivery(ctx, "d1"); got.Status != Common.DeliverySucceeded {
+		t.Errorf("Expected the resumed delivery to succeed, but got %+v", got)
+	}
+
+	// Test case 2: Events published on the bus are delivered with their webhook type
+	bus := Events.NewBus(Events.DefaultHistory)
+	d.Listen(bus)
+	bus.Publish(Events.Created, Common.Complaint{ID: "c1"})
+	bus.Publish(Events.Resolved, Common.Complaint{ID: "c1", Resolved: true})
+	deadline := time.Now().Add(2 * time.Second)
+	for len(deliveries(t, store)) < 3 && time.Now().Before(deadline) {
+		time.Sleep(10 * time.Millisecond)
+	}
+	drain(t, d)
+	log := deliveries(t, store)
+	if len(log) != 3 || r.received() != 3 {
+		t.Fatalf("Expected 2 more deliveries, but got %+v", log)
+	}
+	events := map[string]bool{log[0].Event: true, log[1].Event: true}
+	if !events[EventCreated] || !events[EventResolved] {
+		t.Errorf("Expected the created and resolved events, but got %+v", log[:2])
+	}
+}
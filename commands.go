@@ -0,0 +1,189 @@
+// commands.go
+package main
+
+import (
+	"bufio"
+	"complaint-portal/Auth"
+	"complaint-portal/Common"
+	"complaint-portal/ComplaintService"
+	"complaint-portal/Export"
+	"complaint-portal/Storage"
+	"context"
+	"flag"
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// runCommand runs a maintenance subcommand against the store instead of starting the server.
+// It returns the process exit code.
+func runCommand(store Storage.Store, hasher *Auth.SecretHasher, args []string) int {
+	switch args[0] {
+	case "repair":
+		return runRepair(store, args[1:])
+	case "create-admin":
+		return runCreateAdmin(store, hasher, args[1:])
+	case "export":
+		return runExport(store, args[1:])
+	default:
+		fmt.Fprintf(os.Stderr, "unknown command %q\n", args[0])
+		return 2
+	}
+}
+
+// runRepair reconciles users' Complaints lists with the complaints collection.
+func runRepair(store Storage.Store, args []string) int {
+	fs := flag.NewFlagSet("repair", flag.ExitOnError)
+	dryRun := fs.Bool("dry-run", false, "report inconsistencies without fixing them")
+	fs.Parse(args)
+
+	report, err := Storage.Repair(context.Background(), store, *dryRun)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "repair failed: %v\n", err)
+		return 1
+	}
+
+	for _, id := range report.Orphans {
+		fmt.Printf("orphan complaint %s: added to its owner's list\n", id)
+	}
+	for userID, ids := range report.Dangling {
+		for _, id := range ids {
+			fmt.Printf("user %s: removed dangling complaint ID %s\n", userID, id)
+		}
+	}
+	for _, id := range report.Ownerless {
+		fmt.Printf("complaint %s: owner does not exist, left untouched\n", id)
+	}
+	if *dryRun {
+		fmt.Printf("dry run: %d user(s) would be updated\n", report.UsersUpdated)
+	} else {
+		fmt.Printf("%d user(s) updated\n", report.UsersUpdated)
+	}
+	return 0
+}
+
+// runCreateAdmin creates a staff account directly in the store. It is how the
+// first admin is bootstrapped, since CreateStaffUser can only be called by admins.
+func runCreateAdmin(store Storage.Store, hasher *Auth.SecretHasher, args []string) int {
+	fs := flag.NewFlagSet("create-admin", flag.ExitOnError)
+	name := fs.String("name", "", "name of the new user")
+	email := fs.String("email", "", "email of the new user")
+	role := fs.String("role", string(Common.RoleAdmin), "role of the new user: admin or agent")
+	fs.Parse(args)
+
+	if r := Common.Role(*role); r != Common.RoleAdmin && r != Common.RoleAgent {
+		fmt.Fprintln(os.Stderr, Common.ErrInvalidStaffRole)
+		return 2
+	}
+
+	user, secretCode, err := ComplaintService.NewServer(store, hasher, nil).CreateAccount(context.Background(), *name, *email, Common.Role(*role))
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "create-admin failed: %v\n", err)
+		return 1
+	}
+
+	fmt.Printf("created %s %s\n", user.Role, user.ID)
+	fmt.Printf("secret code: %s\n", secretCode)
+	return 0
+}
+
+// exportSorts maps the values of the export -sort flag to sort orders.
+var exportSorts = map[string]Storage.ComplaintSort{
+	"newest":           Storage.NewestFirst,
+	"oldest":           Storage.OldestFirst,
+	"highest-severity": Storage.HighestSeverityFirst,
+	"lowest-severity":  Storage.LowestSeverityFirst,
+}
+
+// runExport writes complaints, with their users' names and emails, as CSV or
+// NDJSON. It takes the same filters as the complaint listings.
+func runExport(store Storage.Store, args []string) int {
+	fs := flag.NewFlagSet("export", flag.ExitOnError)
+	formatName := fs.String("format", "csv", "file format: csv or ndjson")
+	columnList := fs.String("columns", "", "comma-separated columns to export, in order (default all)")
+	out := fs.String("out", "", "file to write (default standard output)")
+	userID := fs.String("user", "", "only export this user's complaints")
+	minSeverity := fs.Int("min-severity", 0, "lowest severity exported")
+	maxSeverity := fs.Int("max-severity", 0, "highest severity exported")
+	statuses := fs.String("status", "", "comma-separated statuses to export, e.g. Open,InProgress")
+	resolved := fs.String("resolved", "", "true to only export resolved complaints, false for unresolved ones")
+	since := fs.String("since", "", "earliest creation time exported, RFC 3339")
+	until := fs.String("until", "", "first creation time past the export, RFC 3339")
+	sortName := fs.String("sort", "newest", "order: newest, oldest, highest-severity or lowest-severity")
+	fs.Parse(args)
+
+	format, err := Export.ParseFormat(*formatName)
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		return 2
+	}
+	var columns []string
+	if *columnList != "" {
+		columns = strings.Split(*columnList, ",")
+	}
+	parsed, err := Export.ParseColumns(columns)
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		return 2
+	}
+
+	query := Storage.ComplaintQuery{UserID: *userID, MinSeverity: *minSeverity, MaxSeverity: *maxSeverity}
+	sort, ok := exportSorts[*sortName]
+	if !ok {
+		fmt.Fprintln(os.Stderr, Common.ErrInvalidSort)
+		return 2
+	}
+	query.Sort = sort
+	if *statuses != "" {
+		for _, name := range strings.Split(*statuses, ",") {
+			s := Common.ComplaintStatus(strings.TrimSpace(name))
+			if !s.IsValid() {
+				fmt.Fprintln(os.Stderr, Common.ErrInvalidStatus)
+				return 2
+			}
+			query.Statuses = append(query.Statuses, s)
+		}
+	}
+	if *resolved != "" {
+		r, err := strconv.ParseBool(*resolved)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "invalid -resolved: %v\n", err)
+			return 2
+		}
+		query.Resolved = &r
+	}
+	for _, t := range []struct {
+		value string
+		dest  *time.Time
+	}{{*since, &query.CreatedSince}, {*until, &query.CreatedUntil}} {
+		if t.value == "" {
+			continue
+		}
+		if *t.dest, err = time.Parse(time.RFC3339, t.value); err != nil {
+			fmt.Fprintf(os.Stderr, "invalid time: %v\n", err)
+			return 2
+		}
+	}
+
+	w := os.Stdout
+	if *out != "" {
+		if w, err = os.Create(*out); err != nil {
+			fmt.Fprintf(os.Stderr, "export failed: %v\n", err)
+			return 1
+		}
+		defer w.Close()
+	}
+	buf := bufio.NewWriter(w)
+	n, err := Export.Write(context.Background(), store, query, buf, format, parsed)
+	if err == nil {
+		err = buf.Flush()
+	}
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "export failed: %v\n", err)
+		return 1
+	}
+	fmt.Fprintf(os.Stderr, "exported %d complaint(s)\n", n)
+	return 0
+}
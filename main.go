@@ -2,34 +2,300 @@
 package main
 
 import (
+	"complaint-portal/Audit"
+	"complaint-portal/Auth"
 	"complaint-portal/Common"
 	"complaint-portal/ComplaintService"
+	"complaint-portal/Config"
+	"complaint-portal/Events"
+	"complaint-portal/Gateway"
 	pb "complaint-portal/Generated/ComplaintService"
-	"log"
+	"complaint-portal/Health"
+	"complaint-portal/Logging"
+	"complaint-portal/Notify"
+	"complaint-portal/Storage"
+	"complaint-portal/Webhook"
+	"context"
+	"errors"
+	"flag"
+	"log/slog"
 	"net"
+	"net/http"
+	"net/smtp"
+	"os"
+	"os/signal"
+	"syscall"
+	"time"
 
-	
 	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials/insecure"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
+	"google.golang.org/grpc/reflection"
 )
 
 func main() {
-	// Initialize Firebase first
-	Common.InitFirebase()
-	defer Common.FirestoreClient.Close() // Ensure the client is closed when the app exits
+	printConfig := flag.Bool("print-config", false, "print the configuration, with secrets redacted, and exit")
+	cfg, err := Config.Load(flag.CommandLine, os.Args[1:], os.Getenv)
+	if err != nil {
+		fatal(Common.LogInvalidConfig, err)
+	}
+	if *printConfig {
+		if err := Config.Write(os.Stdout, cfg); err != nil {
+			fatal(Common.LogInvalidConfig, err)
+		}
+	}
+	if err := cfg.Validate(); err != nil {
+		fatal(Common.LogInvalidConfig, err)
+	}
+	if *printConfig {
+		return
+	}
 
-	log.Printf(Common.LogStartingServer + Common.GRPC_Port)
+	// Everything is logged through slog from here on, the log package included.
+	logger, err := Logging.New(os.Stderr, cfg.LoggingOptions())
+	if err != nil {
+		fatal(Common.LogFailedToLog, err)
+	}
+	slog.SetDefault(logger)
 
-	lis, err := net.Listen(Common.TCP, Common.GRPC_Port)
+	key := []byte(cfg.Auth.SessionKey)
+	if len(key) == 0 {
+		slog.Warn(Common.LogEphemeralSession)
+		key = Auth.RandomKey()
+	}
+	sessions, err := Auth.NewSessions(key, cfg.Auth.SessionTTL)
 	if err != nil {
-		log.Fatalf(Common.LogFailedToListen, err)
+		fatal(Common.LogInvalidAuthSettings, err)
 	}
 
-	s := grpc.NewServer()
+	// Validate requires a pepper unless nothing outlives the process.
+	pepper := []byte(cfg.Auth.SecretPepper)
+	if len(pepper) == 0 {
+		pepper = Auth.RandomKey()
+	}
+	hasher, err := Auth.NewSecretHasher(pepper)
+	if err != nil {
+		fatal(Common.LogInvalidAuthSettings, err)
+	}
 
-	// Register our server implementation
-	pb.RegisterComplaintServiceServer(s, &ComplaintService.Server{})
+	// Initialize Firebase first when it is the selected backend
+	if cfg.Storage.Backend == Storage.BackendFirestore {
+		Common.InitFirebase(cfg.FirebaseOptions())
+	}
 
-	if err := s.Serve(lis); err != nil {
-		log.Fatalf(Common.LogFailedToServe, err)
+	store, err := Storage.Open(cfg.StorageOptions())
+	if err != nil {
+		fatal(Common.LogFailedToOpenStore, err)
 	}
-}
\ No newline at end of file
+	slog.Info(Common.LogUsingStorage, Common.LogKeyBackend, cfg.Storage.Backend)
+
+	// Users stored before secret codes were hashed are rehashed before anyone logs in.
+	if n, err := Auth.RehashSecretCodes(context.Background(), store, hasher); err != nil {
+		fatal(Common.LogFailedToRehash, err)
+	} else if n > 0 {
+		slog.Info(Common.LogRehashedSecrets, Common.LogKeyCount, n)
+	}
+
+	// Firestore documents written before timestamps were recorded get them from
+	// the document metadata, and complaints written before the status lifecycle
+	// get a status; the SQL backend backfills both in its migrations.
+	if fs, ok := store.(*Storage.FirestoreStore); ok {
+		if n, err := fs.BackfillTimestamps(context.Background()); err != nil {
+			fatal(Common.LogFailedToBackfill, err)
+		} else if n > 0 {
+			slog.Info(Common.LogBackfilledTimestamps, Common.LogKeyCount, n)
+		}
+		if n, err := fs.BackfillStatuses(context.Background()); err != nil {
+			fatal(Common.LogFailedToFillStatuses, err)
+		} else if n > 0 {
+			slog.Info(Common.LogBackfilledStatuses, Common.LogKeyCount, n)
+		}
+	}
+
+	// Maintenance commands run against the store and exit without serving.
+	if flag.NArg() > 0 {
+		code := runCommand(store, hasher, flag.Args())
+		store.Close()
+		os.Exit(code)
+	}
+
+	slog.Info(Common.LogStartingServer, Common.LogKeyAddr, cfg.Listen.GRPC)
+
+	lis, err := net.Listen(Common.TCP, cfg.Listen.GRPC)
+	if err != nil {
+		fatal(Common.LogFailedToListen, err)
+	}
+
+	// Every call is first given a request ID, and logged once it ends, refused
+	// ones included. Every RPC goes through the authorization interceptor; see
+	// Auth.MethodAccess. Calls presenting a secret code are rate limited before
+	// it, so that wrong codes rejected by the authorization interceptor count
+	// against the client. Changes are audited last, once the caller is known.
+	// Streams only read, so they are not audited.
+	clientPolicy, globalPolicy := cfg.RateLimitPolicies()
+	perClient, global := Auth.NewMemoryLimiter(clientPolicy), Auth.NewMemoryLimiter(globalPolicy)
+	s := grpc.NewServer(
+		grpc.ChainUnaryInterceptor(
+			Logging.UnaryServerInterceptor(logger),
+			Auth.RateLimitInterceptor(perClient, global),
+			Auth.UnaryServerInterceptor(store, hasher, sessions),
+			Audit.UnaryServerInterceptor(store),
+		),
+		grpc.ChainStreamInterceptor(
+			Logging.StreamServerInterceptor(logger),
+			Auth.RateLimitStreamInterceptor(perClient, global),
+			Auth.StreamServerInterceptor(store, hasher, sessions),
+		),
+	)
+
+	// Register our server implementation, with the search index filled before
+	// the first request.
+	server := ComplaintService.NewServer(store, hasher, sessions)
+	server.Limits = cfg.ServiceLimits()
+	if n, err := server.Index.Build(context.Background(), store); err != nil {
+		fatal(Common.LogFailedToIndex, err)
+	} else {
+		slog.Info(Common.LogIndexedComplaints, Common.LogKeyCount, n)
+	}
+	var notifier *Notify.Notifier
+	if cfg.Notify.SMTPAddr != "" {
+		notifier = newNotifier(store, cfg)
+		server.EnableNotifications(notifier)
+		slog.Info(Common.LogNotifying, Common.LogKeyAddr, cfg.Notify.SMTPAddr)
+	}
+	// Deliveries left pending by the last run are retried first.
+	dispatcher := Webhook.NewDispatcher(store, &http.Client{}, cfg.WebhookOptions())
+	if n, err := dispatcher.ResumePending(context.Background()); err != nil {
+		slog.Error(Common.LogFailedToResume, Common.LogKeyError, err)
+	} else if n > 0 {
+		slog.Info(Common.LogResumedDeliveries, Common.LogKeyCount, n)
+	}
+	server.EnableWebhooks(dispatcher)
+	pb.RegisterComplaintServiceServer(s, server)
+
+	// The health service reports the server as serving while the store answers.
+	checker := Health.NewChecker(store, []string{pb.ComplaintService_ServiceDesc.ServiceName}, cfg.HealthOptions())
+	healthpb.RegisterHealthServer(s, checker.Server)
+	checker.Start()
+	if cfg.Listen.Reflection {
+		reflection.Register(s)
+		slog.Info(Common.LogReflectionEnabled)
+	}
+
+	// Changes made through this server are published as they are written; the
+	// listener adds those made through other servers sharing the database.
+	watchCtx, stopWatching := context.WithCancel(context.Background())
+	if fs, ok := store.(*Storage.FirestoreStore); ok && cfg.Storage.Firestore.Watch {
+		slog.Info(Common.LogWatchingFirestore)
+		go func() {
+			err := fs.WatchComplaints(watchCtx, func(c Common.Complaint, created bool) {
+				if created {
+					server.Events.PublishIfNew(Events.Created, c)
+				} else {
+					server.Events.PublishIfNew(Events.UpdateType(c), c)
+				}
+			})
+			if err != nil && watchCtx.Err() == nil {
+				slog.Error(Common.LogFirestoreWatchFailed, Common.LogKeyError, err)
+			}
+		}()
+	}
+
+	var gateway *http.Server
+	if cfg.Listen.HTTP != "" {
+		gateway = serveGateway(s, cfg.Listen.HTTP)
+	}
+
+	// Serve until SIGTERM or SIGINT, then stop taking requests, let those in
+	// flight finish within the shutdown timeout and flush the background work.
+	signals := make(chan os.Signal, 1)
+	signal.Notify(signals, syscall.SIGTERM, syscall.SIGINT)
+	served := make(chan error, 1)
+	go func() { served <- s.Serve(lis) }()
+	select {
+	case err := <-served:
+		slog.Error(Common.LogFailedToServe, Common.LogKeyError, err)
+	case sig := <-signals:
+		slog.Info(Common.LogShuttingDown, Common.LogKeySignal, sig.String(), Common.LogKeyTimeout, cfg.Listen.ShutdownTimeout.String())
+	}
+	signal.Stop(signals)
+
+	ctx, cancel := context.WithTimeout(context.Background(), cfg.Listen.ShutdownTimeout)
+	defer cancel()
+	checker.Shutdown()
+	server.Shutdown()
+	if gateway != nil {
+		gateway.Shutdown(ctx)
+	}
+	stopped := make(chan struct{})
+	go func() {
+		s.GracefulStop()
+		close(stopped)
+	}()
+	select {
+	case <-stopped:
+	case <-ctx.Done():
+		slog.Warn(Common.LogShutdownTimedOut)
+		s.Stop()
+	}
+	stopWatching()
+
+	// Emails only live in memory, so they get what is left of the timeout.
+	// Webhook deliveries waiting for a retry are logged and resumed on restart.
+	if notifier != nil {
+		if err := notifier.Drain(ctx); err != nil {
+			slog.Warn(Common.LogFailedToDrain, Common.LogKeyCount, notifier.Pending(), Common.LogKeyError, err)
+		}
+		notifier.Close()
+	}
+	dispatcher.Close()
+	if err := store.Close(); err != nil {
+		slog.Error(Common.LogFailedToCloseStore, Common.LogKeyError, err)
+	}
+	slog.Info(Common.LogStopped)
+}
+
+// serveGateway serves the REST gateway on addr, relaying requests to s over an
+// in-process connection, and returns its HTTP server.
+func serveGateway(s *grpc.Server, addr string) *http.Server {
+	gatewayLis := Gateway.NewListener()
+	go s.Serve(gatewayLis)
+	conn, err := grpc.NewClient("passthrough:///"+Common.GatewayNetwork,
+		grpc.WithContextDialer(gatewayLis.DialContext),
+		grpc.WithTransportCredentials(insecure.NewCredentials()),
+	)
+	if err != nil {
+		fatal(Common.LogFailedToServeGateway, err)
+	}
+	httpServer := &http.Server{Addr: addr, Handler: Gateway.New(conn), ReadHeaderTimeout: 10 * time.Second}
+	slog.Info(Common.LogStartingGateway, Common.LogKeyAddr, addr)
+	go func() {
+		if err := httpServer.ListenAndServe(); err != nil && !errors.Is(err, http.ErrServerClosed) {
+			fatal(Common.LogFailedToServeGateway, err)
+		}
+	}()
+	return httpServer
+}
+
+// newNotifier returns a notifier sending through the configured SMTP server.
+func newNotifier(store Storage.Store, cfg Config.Config) *Notify.Notifier {
+	templates := Notify.DefaultTemplates()
+	if cfg.Notify.Templates != "" {
+		var err error
+		if templates, err = Notify.LoadTemplates(cfg.Notify.Templates); err != nil {
+			fatal(Common.LogFailedToLoadNotify, err)
+		}
+	}
+	sender := &Notify.SMTPSender{Addr: cfg.Notify.SMTPAddr, From: cfg.Notify.SMTPFrom}
+	if user := cfg.Notify.SMTPUsername; user != "" {
+		host, _, _ := net.SplitHostPort(cfg.Notify.SMTPAddr)
+		sender.Auth = smtp.PlainAuth("", user, string(cfg.Notify.SMTPPassword), host)
+	}
+	return Notify.NewNotifier(store, sender, templates, cfg.NotifyOptions())
+}
+
+// fatal logs msg with err as an error and exits.
+func fatal(msg string, err error) {
+	slog.Error(msg, Common.LogKeyError, err)
+	os.Exit(1)
+}